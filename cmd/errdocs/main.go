@@ -0,0 +1,21 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+)
+
+// generates docs/errors.md from the errors catalogue
+func main() {
+	out := flag.String("out", "docs/errors.md", "arquivo gerado")
+	flag.Parse()
+
+	err := os.WriteFile(*out, []byte(domainErrors.Markdown()), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Generated", *out)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// files: the parts of the package swagger-ui-dist served by /docs
+var files = map[string]string{
+	"package/swagger-ui.css":       "swagger-ui.css",
+	"package/swagger-ui-bundle.js": "swagger-ui-bundle.js",
+	"package/LICENSE":              "LICENSE",
+}
+
+// vendors the Swagger UI of the npm registry into the package that embeds it
+func main() {
+	version := flag.String("version", "4.15.5", "versão do swagger-ui-dist")
+	out := flag.String("out", "swaggerui", "pasta dos arquivos")
+	flag.Parse()
+
+	url := "https://registry.npmjs.org/swagger-ui-dist/-/swagger-ui-dist-" + *version + ".tgz"
+	resp, err := http.Get(url)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Fatalf("%s: %s", url, resp.Status)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		log.Fatal(err)
+	}
+	archive := tar.NewReader(gz)
+
+	found := 0
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		name, ok := files[header.Name]
+		if !ok {
+			continue
+		}
+
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(*out, name), data, 0644)
+		if err != nil {
+			log.Fatal(err)
+		}
+		found++
+	}
+	if found != len(files) {
+		log.Fatalf("%s: %d of %d files found", url, found, len(files))
+	}
+	log.Println("Generated swagger-ui-dist", *version, "in", *out)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -8,7 +9,9 @@ import (
 	"time"
 
 	"github.com/gorilla/handlers"
+	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/configsAPI"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/scheduler"
 	"github.com/johnHPX/blog-hard-backend/internal/interf/routes"
 )
 
@@ -26,11 +29,38 @@ func main() {
 		projectConfigs.Port = "40183"
 	}
 
+	schedulerConfigs, err := c.SchedulerConfigs()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if schedulerConfigs.Interval <= 0 {
+		schedulerConfigs.Interval = 60
+	}
+
+	// scheduled posts, the replicas share the work through an advisory lock
+	systemService := service.NewSystemService()
+	scheduler.Start(context.Background(), scheduler.Job{
+		Name:  "publish scheduled posts",
+		Every: time.Duration(schedulerConfigs.Interval) * time.Second,
+		Run: func() error {
+			n, err := systemService.PublishScheduled()
+			if n > 0 {
+				log.Printf("%d scheduled posts published", n)
+			}
+			return err
+		},
+	})
+	log.Println("Scheduler Started...")
+
 	log.Println("Initialized Routes")
 
 	//init web service
 	wsvc := routes.NewWebService()
-	wsvc.Init()
+	err = wsvc.Init()
+	if err != nil {
+		log.Fatal(err)
+	}
 	loggedRouter := handlers.LoggingHandler(os.Stdout, wsvc.GetRouters())
 	//server setup
 	srv := &http.Server{
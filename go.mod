@@ -7,6 +7,8 @@ require (
 	github.com/johnHPX/validator-hard v0.0.0-20220804212857-dd6a86225b2d
 	github.com/lib/pq v1.10.6
 	github.com/xhit/go-simple-mail/v2 v2.11.0
+	github.com/yuin/goldmark v1.5.4
+	golang.org/x/crypto v0.0.0-20220722155217-630584e8d5aa
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -18,7 +20,6 @@ require (
 	github.com/go-test/deep v1.0.8 // indirect
 	github.com/paemuri/brdoc v1.1.2 // indirect
 	github.com/toorop/go-dkim v0.0.0-20201103131630-e1cd1a0a5208 // indirect
-	golang.org/x/crypto v0.0.0-20220722155217-630584e8d5aa // indirect
 )
 
 require (
@@ -1,24 +1,24 @@
 package service
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
-	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
 )
 
 type userToken struct {
-	UserID string
-	Kind   string
+	UserID   string
+	Kind     string
+	Language string
 }
 
 type accessServiceInterface interface {
-	CreateAToken(userID, kind string) (string, error)
+	CreateAToken(userID, kind, language string) (string, error)
 	CreateRToken() (string, error)
 	ValidateAToken(r *http.Request) error
 	ValidateRToken(rtoken string) error
@@ -44,7 +44,7 @@ func (s *accessServiceImpl) GenerateNewToken(userID string) (string, error) {
 
 	// verific if rtoken was blocked
 	if access.IsBlocked {
-		return "", errors.New(messages.TokenBlocked)
+		return "", domainErrors.ErrTokenBlocked
 	}
 
 	// validate rtoken
@@ -78,7 +78,7 @@ func (s *accessServiceImpl) GenerateNewToken(userID string) (string, error) {
 	}
 
 	// create a new atoken
-	newAToken, err := s.CreateAToken(user.UserID, user.Kind)
+	newAToken, err := s.CreateAToken(user.UserID, user.Kind, user.Language)
 	if err != nil {
 		return "", err
 	}
@@ -88,12 +88,13 @@ func (s *accessServiceImpl) GenerateNewToken(userID string) (string, error) {
 	return newAToken, nil
 }
 
-func (s *accessServiceImpl) CreateAToken(userID, kind string) (string, error) {
+func (s *accessServiceImpl) CreateAToken(userID, kind, language string) (string, error) {
 	permissions := jwt.MapClaims{}
 	permissions["authorized"] = true
 	permissions["exp"] = time.Now().Add(time.Hour * 4).Unix()
 	permissions["userID"] = userID
 	permissions["kind"] = kind
+	permissions["language"] = language
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, permissions)
 	return token.SignedString([]byte(s.SecretKey))
 }
@@ -126,7 +127,7 @@ func (s *accessServiceImpl) ValidateAToken(r *http.Request) error {
 		return nil
 	}
 
-	return errors.New(messages.InvalideToken)
+	return domainErrors.ErrInvalidToken
 }
 
 func (s *accessServiceImpl) ValidateRToken(rtoken string) error {
@@ -139,7 +140,7 @@ func (s *accessServiceImpl) ValidateRToken(rtoken string) error {
 		return nil
 	}
 
-	return errors.New(messages.InvalideToken)
+	return domainErrors.ErrInvalidToken
 }
 
 func (s *accessServiceImpl) ValidateAndExtractTokenRecovery(r *http.Request) (string, error) {
@@ -150,17 +151,17 @@ func (s *accessServiceImpl) ValidateAndExtractTokenRecovery(r *http.Request) (st
 	}
 
 	if permissions, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID := permissions["userID-recovery"]
-		recovery := permissions["recovery"]
+		userID, ok := permissions["userID-recovery"].(string)
+		recovery, _ := permissions["recovery"].(bool)
 
-		if !recovery.(bool) {
-			return "", errors.New(messages.InvalideToken)
+		if !ok || !recovery {
+			return "", domainErrors.ErrInvalidToken
 		}
 
-		return userID.(string), nil
+		return userID, nil
 	}
 
-	return "", errors.New(messages.InvalideToken)
+	return "", domainErrors.ErrInvalidToken
 }
 
 func (s *accessServiceImpl) ExtractTokenInfo(r *http.Request) (*userToken, error) {
@@ -171,16 +172,26 @@ func (s *accessServiceImpl) ExtractTokenInfo(r *http.Request) (*userToken, error
 	}
 
 	if permissions, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID := permissions["userID"]
-		kind := permissions["kind"]
+		// the recovery tokens are valid too, but they have no user nor kind
+		userID, ok := permissions["userID"].(string)
+		if !ok {
+			return nil, domainErrors.ErrInvalidToken
+		}
+		kind, ok := permissions["kind"].(string)
+		if !ok {
+			return nil, domainErrors.ErrInvalidToken
+		}
+		// tokens created before the language claim don't have it
+		language, _ := permissions["language"].(string)
 
 		return &userToken{
-			UserID: userID.(string),
-			Kind:   kind.(string),
+			UserID:   userID,
+			Kind:     kind,
+			Language: language,
 		}, nil
 	}
 
-	return nil, errors.New(messages.InvalideToken)
+	return nil, domainErrors.ErrInvalidToken
 }
 
 func (s *accessServiceImpl) ExtractInvalideToken(r *http.Request) (string, error) {
@@ -190,8 +201,11 @@ func (s *accessServiceImpl) ExtractInvalideToken(r *http.Request) (string, error
 		if err.Error() == "Token is expired" {
 			permissions, ok := token.Claims.(jwt.MapClaims)
 			if ok {
-				userID := permissions["userID"]
-				return userID.(string), nil
+				userID, ok := permissions["userID"].(string)
+				if !ok {
+					return "", domainErrors.ErrInvalidToken
+				}
+				return userID, nil
 			}
 		}
 	}
@@ -2,22 +2,28 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
-	"github.com/johnHPX/validator-hard/pkg/validator"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/slug"
 )
 
 type categoryServiceInterface interface {
-	CreateCategory(name string) error
-	ListCategory(offset, limit, page int) ([]models.Category, int, error)
-	ListCategoryByPost(postID string, offset, limit, page int) ([]models.Category, int, error)
+	CreateCategory(name, categorySlug, description, coverID, parentID string) error
+	ListCategory(p *pagination.Params) ([]models.Category, *pagination.Page, error)
+	ListCategoryByPost(postID string, p *pagination.Params) ([]models.Category, *pagination.Page, error)
 	FindCategory(categoryID string) (*models.Category, error)
-	UpdateCategory(categoryID, name string) error
+	UpdateCategory(categoryID, name, categorySlug, description, coverID, parentID string) error
 	RemoveCategory(categoryID string) error
+	Tree() ([]models.CategoryNode, error)
+	MergeCategory(categoryID string, from []string) error
 }
 
 type categoryServiceImpl struct {
@@ -25,22 +31,141 @@ type categoryServiceImpl struct {
 	kind   string
 }
 
-func (s *categoryServiceImpl) CreateCategory(name string) error {
+// checkParent: the parent must exist and can't be the category nor one of its descendants,
+// the tree keeps at most models.CategoryDepth levels. Without parent the category is a root
+func checkParent(categoryID, parentID string) (string, error) {
+	val := newValidator()
+	parentVal, err := val.CheckAnyData("categoria pai", 36, parentID, false)
+	if err != nil {
+		return "", err
+	}
+	parentID = parentVal.(string)
+	if parentID == "" {
+		return "", nil
+	}
+
+	repCategory := repository.NewCategoryRepository()
+	categories, err := repCategory.All()
+	if err != nil {
+		return "", err
+	}
+
+	parents := make(map[string]string, len(categories))
+	children := make(map[string][]string)
+	for _, v := range categories {
+		parents[v.CategoryID] = v.ParentID
+		children[v.ParentID] = append(children[v.ParentID], v.CategoryID)
+	}
+	if _, ok := parents[parentID]; !ok {
+		return "", domainErrors.Validation("categoria pai", messages.FieldInvalid, fmt.Errorf("category %s not found", parentID))
+	}
+
+	depth := 0
+	for id := parentID; id != "" && depth <= models.CategoryDepth; id = parents[id] {
+		if id == categoryID {
+			return "", domainErrors.ErrCategoryCycle
+		}
+		depth++
+	}
+
+	if levels := depth + categoryHeight(categoryID, children, 1); levels > models.CategoryDepth {
+		return "", domainErrors.Validation("categoria pai", messages.FieldInvalid, fmt.Errorf("the tree would have %d levels, at most %d", levels, models.CategoryDepth))
+	}
+
+	return parentID, nil
+}
+
+// categorySlugFallback: slug of a category name without any letter or digit
+const categorySlugFallback = "categoria"
+
+// makeCategorySlug: the slug of the text, the fallback of the posts isn't used for the categories
+func makeCategorySlug(text string) string {
+	s := slug.Make(text)
+	if s == slug.Fallback && !strings.EqualFold(strings.TrimSpace(text), slug.Fallback) {
+		return categorySlugFallback
+	}
+	return s
+}
+
+// checkCategorySlug: a slug that is sent is normalized and must be free. Without it a new category gets
+// a free one made from its name, and the others keep theirs, so a rename doesn't break the links
+func checkCategorySlug(categoryID, name, current, sent string) (string, error) {
+	val := newValidator()
+	slugVal, err := val.CheckAnyData("slug", slug.MaxLength, sent, false)
+	if err != nil {
+		return "", err
+	}
+
+	repCategory := repository.NewCategoryRepository()
+	if slugVal.(string) != "" {
+		categorySlug := makeCategorySlug(slugVal.(string))
+		other, err := repCategory.FindSlug(categorySlug)
+		if err == nil && other.CategoryID != categoryID {
+			return "", domainErrors.ErrCategorySlugTaken
+		}
+		if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+			return "", err
+		}
+		return categorySlug, nil
+	}
+
+	if current != "" {
+		return current, nil
+	}
+	return repCategory.FreeSlug(makeCategorySlug(name), categoryID)
+}
+
+// categoryHeight: the levels of the category's subtree, itself included
+func categoryHeight(categoryID string, children map[string][]string, level int) int {
+	height := 1
+	if level > models.CategoryDepth {
+		return height
+	}
+	for _, v := range children[categoryID] {
+		if h := 1 + categoryHeight(v, children, level+1); h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+func (s *categoryServiceImpl) CreateCategory(name, categorySlug, description, coverID, parentID string) error {
 
 	if s.kind != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	nameVal, err := val.CheckAnyData("nome", 255, name, true)
 	if err != nil {
 		return err
 	}
+	descriptionVal, err := val.CheckAnyData("descrição", 1000, description, false)
+	if err != nil {
+		return err
+	}
+	coverID, err = checkCover(coverID)
+	if err != nil {
+		return err
+	}
 
 	categoryID := uuid.New()
+	parentID, err = checkParent(categoryID.String(), parentID)
+	if err != nil {
+		return err
+	}
+	categorySlug, err = checkCategorySlug(categoryID.String(), nameVal.(string), "", categorySlug)
+	if err != nil {
+		return err
+	}
+
 	categoryEntity := new(models.Category)
 	categoryEntity.CategoryID = categoryID.String()
 	categoryEntity.Name = nameVal.(string)
+	categoryEntity.Slug = categorySlug
+	categoryEntity.Description = descriptionVal.(string)
+	categoryEntity.CoverID = coverID
+	categoryEntity.ParentID = parentID
 
 	repCategory := repository.NewCategoryRepository()
 	err = repCategory.Store(categoryEntity)
@@ -51,53 +176,56 @@ func (s *categoryServiceImpl) CreateCategory(name string) error {
 	return nil
 }
 
-func (s *categoryServiceImpl) ListCategory(offset, limit, page int) ([]models.Category, int, error) {
+// ListCategory: the categories with their number of published posts
+func (s *categoryServiceImpl) ListCategory(p *pagination.Params) ([]models.Category, *pagination.Page, error) {
 
 	repCategory := repository.NewCategoryRepository()
-	categoryEntities, err := repCategory.List(offset, limit, page)
+	categoryEntities, page, err := repCategory.List(p)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
-	count, err := repCategory.Count()
+	err = p.SetTotal(page, repCategory.Count)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return categoryEntities, count, nil
+	return categoryEntities, page, nil
 }
 
-func (s *categoryServiceImpl) ListCategoryByPost(postID string, offset, limit, page int) ([]models.Category, int, error) {
+func (s *categoryServiceImpl) ListCategoryByPost(postID string, p *pagination.Params) ([]models.Category, *pagination.Page, error) {
 
 	if s.kind != "adm" {
-		return nil, 0, errors.New(messages.AdmMessage)
+		return nil, nil, domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	postIDval, err := val.CheckAnyData("id da postagem", 36, postID, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	repCategory := repository.NewCategoryRepository()
-	categoryEntities, err := repCategory.ListPost(postIDval.(string), offset, limit, page)
+	categoryEntities, page, err := repCategory.ListPost(postIDval.(string), p)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
-	count, err := repCategory.CountPost(postIDval.(string))
+	err = p.SetTotal(page, func() (int, error) {
+		return repCategory.CountPost(postIDval.(string))
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return categoryEntities, count, nil
+	return categoryEntities, page, nil
 }
 
 func (s *categoryServiceImpl) FindCategory(categoryID string) (*models.Category, error) {
 
 	if s.kind != "adm" {
-		return nil, errors.New(messages.AdmMessage)
+		return nil, domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	categoryIDval, err := val.CheckAnyData("id da categoria", 36, categoryID, true)
 	if err != nil {
 		return nil, err
@@ -112,13 +240,14 @@ func (s *categoryServiceImpl) FindCategory(categoryID string) (*models.Category,
 	return comment, nil
 }
 
-func (s *categoryServiceImpl) UpdateCategory(categoryID, name string) error {
+// UpdateCategory: without parentID the category becomes a root, without slug it keeps its one
+func (s *categoryServiceImpl) UpdateCategory(categoryID, name, categorySlug, description, coverID, parentID string) error {
 
 	if s.kind != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	categoryIDVal, err := val.CheckAnyData("id da categoria", 36, categoryID, true)
 	if err != nil {
 		return err
@@ -128,11 +257,36 @@ func (s *categoryServiceImpl) UpdateCategory(categoryID, name string) error {
 		return err
 	}
 
-	categoryEntity := new(models.Category)
-	categoryEntity.CategoryID = categoryIDVal.(string)
-	categoryEntity.Name = nameVal.(string)
+	descriptionVal, err := val.CheckAnyData("descrição", 1000, description, false)
+	if err != nil {
+		return err
+	}
+	coverID, err = checkCover(coverID)
+	if err != nil {
+		return err
+	}
 
 	repCategory := repository.NewCategoryRepository()
+	categoryEntity, err := repCategory.Find(categoryIDVal.(string))
+	if err != nil {
+		return err
+	}
+
+	parentID, err = checkParent(categoryEntity.CategoryID, parentID)
+	if err != nil {
+		return err
+	}
+	categorySlug, err = checkCategorySlug(categoryEntity.CategoryID, nameVal.(string), categoryEntity.Slug, categorySlug)
+	if err != nil {
+		return err
+	}
+
+	categoryEntity.Name = nameVal.(string)
+	categoryEntity.Slug = categorySlug
+	categoryEntity.Description = descriptionVal.(string)
+	categoryEntity.CoverID = coverID
+	categoryEntity.ParentID = parentID
+
 	err = repCategory.Update(categoryEntity)
 	if err != nil {
 		return err
@@ -144,10 +298,10 @@ func (s *categoryServiceImpl) UpdateCategory(categoryID, name string) error {
 func (s *categoryServiceImpl) RemoveCategory(categoryID string) error {
 
 	if s.kind != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	categoryIDVal, err := val.CheckAnyData("id da categoria", 36, categoryID, true)
 	if err != nil {
 		return err
@@ -162,6 +316,85 @@ func (s *categoryServiceImpl) RemoveCategory(categoryID string) error {
 	return nil
 }
 
+// Tree: the roots with their descendants, a category whose parent is gone is shown as a root
+func (s *categoryServiceImpl) Tree() ([]models.CategoryNode, error) {
+
+	repCategory := repository.NewCategoryRepository()
+	categories, err := repCategory.All()
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make(map[string]bool, len(categories))
+	for _, v := range categories {
+		exists[v.CategoryID] = true
+	}
+	children := make(map[string][]models.Category)
+	for _, v := range categories {
+		parentID := v.ParentID
+		if !exists[parentID] {
+			parentID = ""
+		}
+		children[parentID] = append(children[parentID], v)
+	}
+
+	return categoryNodes("", children, 1), nil
+}
+
+// categoryNodes: the children keep the alphabetical order of All
+func categoryNodes(parentID string, children map[string][]models.Category, level int) []models.CategoryNode {
+	nodes := make([]models.CategoryNode, 0, len(children[parentID]))
+	for _, v := range children[parentID] {
+		node := models.CategoryNode{Category: v}
+		if level < models.CategoryDepth {
+			node.Children = categoryNodes(v.CategoryID, children, level+1)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// MergeCategory: the posts and the children of the categories from go to the category, the categories from are removed.
+// A category can't be merged into one of its descendants
+func (s *categoryServiceImpl) MergeCategory(categoryID string, from []string) error {
+
+	if s.kind != "adm" {
+		return domainErrors.ErrAdminOnly
+	}
+
+	val := newValidator()
+	categoryIDVal, err := val.CheckAnyData("id da categoria", 36, categoryID, true)
+	if err != nil {
+		return err
+	}
+	from, err = checkIDs("categorias", from, postCategoriesMax)
+	if err != nil {
+		return err
+	}
+	if len(from) == 0 {
+		return domainErrors.Validation("categorias", messages.FieldRequired, errors.New("no categories to merge"))
+	}
+
+	repCategory := repository.NewCategoryRepository()
+	category, err := repCategory.Find(categoryIDVal.(string))
+	if err != nil {
+		return err
+	}
+
+	for _, v := range from {
+		if v == category.CategoryID {
+			return domainErrors.Validation("categorias", messages.FieldInvalid, fmt.Errorf("category %s merged into itself", v))
+		}
+		// the children of v get the category as parent
+		_, err = checkParent(v, category.CategoryID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return repCategory.Merge(category.CategoryID, from)
+}
+
 func NewCategoryService(userID, kind string) categoryServiceInterface {
 	return &categoryServiceImpl{
 		userID: userID,
@@ -5,14 +5,15 @@ import (
 
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
-	"github.com/johnHPX/validator-hard/pkg/validator"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/markdown"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 )
 
 type commentServiceInterface interface {
 	CreateComment(postID, title, content string) error
-	ListCommentsPost(postID string, offset, limit, page int) ([]models.Comment, int, error)
-	ListCommentsUser(offset, limit, page int) ([]models.Comment, int, error)
-	ListCommentsPostUser(postID string, offset, limit, page int) ([]models.Comment, int, error)
+	ListCommentsPost(postID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error)
+	ListCommentsUser(p *pagination.Params) ([]models.Comment, *pagination.Page, error)
+	ListCommentsPostUser(postID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error)
 	FindComment(commentID string) (*models.Comment, error)
 	UpdateComment(commentID, title, content string) error
 	RemoveComment(commentID string) error
@@ -24,7 +25,7 @@ type commentServiceImpl struct {
 }
 
 func (s *commentServiceImpl) CreateComment(postID, title, content string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 
 	postIDval, err := val.CheckAnyData("post id", 36, postID, true)
 	if err != nil {
@@ -44,6 +45,10 @@ func (s *commentServiceImpl) CreateComment(postID, title, content string) error
 	commentEntity.CommentID = commentID.String()
 	commentEntity.Title = titleVal.(string)
 	commentEntity.Content = contentVal.(string)
+	commentEntity.ContentHTML, err = markdown.RenderRestricted(commentEntity.Content)
+	if err != nil {
+		return err
+	}
 	commentEntity.UserID = s.userID
 	commentEntity.PostID = postIDval.(string)
 
@@ -62,74 +67,80 @@ func (s *commentServiceImpl) CreateComment(postID, title, content string) error
 	return nil
 }
 
-func (s *commentServiceImpl) ListCommentsPost(postID string, offset, limit, page int) ([]models.Comment, int, error) {
-	val := validator.NewValidator()
+func (s *commentServiceImpl) ListCommentsPost(postID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error) {
+	val := newValidator()
 	postIDval, err := val.CheckAnyData("id da postagem", 36, postID, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	repComment := repository.NewCommentRepository()
-	commentsEntities, err := repComment.List(postIDval.(string), offset, limit, page)
+	commentsEntities, page, err := repComment.List(postIDval.(string), p)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
-	count, err := repComment.Count(postIDval.(string))
+	err = p.SetTotal(page, func() (int, error) {
+		return repComment.Count(postIDval.(string))
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return commentsEntities, count, nil
+	return commentsEntities, page, nil
 }
 
-func (s *commentServiceImpl) ListCommentsUser(offset, limit, page int) ([]models.Comment, int, error) {
+func (s *commentServiceImpl) ListCommentsUser(p *pagination.Params) ([]models.Comment, *pagination.Page, error) {
 
-	val := validator.NewValidator()
+	val := newValidator()
 	userIDval, err := val.CheckAnyData("post id", 36, s.userID, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	repComment := repository.NewCommentRepository()
-	commentsEntities, err := repComment.ListUser(userIDval.(string), offset, limit, page)
+	commentsEntities, page, err := repComment.ListUser(userIDval.(string), p)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
-	count, err := repComment.CountUser(s.userID)
+	err = p.SetTotal(page, func() (int, error) {
+		return repComment.CountUser(s.userID)
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return commentsEntities, count, nil
+	return commentsEntities, page, nil
 }
 
-func (s *commentServiceImpl) ListCommentsPostUser(postID string, offset, limit, page int) ([]models.Comment, int, error) {
+func (s *commentServiceImpl) ListCommentsPostUser(postID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error) {
 
-	val := validator.NewValidator()
+	val := newValidator()
 	postIDval, err := val.CheckAnyData("post id", 36, postID, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 	userIDval, err := val.CheckAnyData("post id", 36, s.userID, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	repComment := repository.NewCommentRepository()
-	commentsEntities, err := repComment.ListUserPost(postIDval.(string), userIDval.(string), offset, limit, page)
+	commentsEntities, page, err := repComment.ListUserPost(postIDval.(string), userIDval.(string), p)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
-	count, err := repComment.CountUserPost(postIDval.(string), s.userID)
+	err = p.SetTotal(page, func() (int, error) {
+		return repComment.CountUserPost(postIDval.(string), s.userID)
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return commentsEntities, count, nil
+	return commentsEntities, page, nil
 }
 
 func (s *commentServiceImpl) FindComment(commentID string) (*models.Comment, error) {
-	val := validator.NewValidator()
+	val := newValidator()
 	commentIDval, err := val.CheckAnyData("post id", 36, commentID, true)
 	if err != nil {
 		return nil, err
@@ -145,7 +156,7 @@ func (s *commentServiceImpl) FindComment(commentID string) (*models.Comment, err
 }
 
 func (s *commentServiceImpl) UpdateComment(commentID, title, content string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 	commentIDVal, err := val.CheckAnyData("id do comentario", 36, commentID, true)
 	if err != nil {
 		return err
@@ -163,6 +174,10 @@ func (s *commentServiceImpl) UpdateComment(commentID, title, content string) err
 	commentEntity.CommentID = commentIDVal.(string)
 	commentEntity.Title = titleVal.(string)
 	commentEntity.Content = contentVal.(string)
+	commentEntity.ContentHTML, err = markdown.RenderRestricted(commentEntity.Content)
+	if err != nil {
+		return err
+	}
 
 	repComment := repository.NewCommentRepository()
 	err = repComment.Update(commentEntity)
@@ -174,7 +189,7 @@ func (s *commentServiceImpl) UpdateComment(commentID, title, content string) err
 }
 
 func (s *commentServiceImpl) RemoveComment(commentID string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 	commentIDVal, err := val.CheckAnyData("id do comentario", 36, commentID, true)
 	if err != nil {
 		return err
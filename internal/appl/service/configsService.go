@@ -1,12 +1,9 @@
 package service
 
 import (
-	"errors"
-
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
-	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
-	"github.com/johnHPX/validator-hard/pkg/validator"
 )
 
 type configsServiceInterface interface {
@@ -25,10 +22,10 @@ type configsServiceImpl struct {
 func (s *configsServiceImpl) Store(collors, links, menuAs []string, bannerURL string) error {
 
 	if s.kindID != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	collorsVal := make([]string, 0)
 	for _, v := range collors {
 		collor, err := val.CheckAnyData("cor do site", 16, v, true)
@@ -73,7 +70,7 @@ func (s *configsServiceImpl) Store(collors, links, menuAs []string, bannerURL st
 
 func (s *configsServiceImpl) List(offset, limit, page int) ([]models.Configs, int, error) {
 	if s.kindID != "adm" {
-		return nil, 0, errors.New(messages.AdmMessage)
+		return nil, 0, domainErrors.ErrAdminOnly
 	}
 
 	repConfigs := repository.NewConfigsRepository()
@@ -92,7 +89,7 @@ func (s *configsServiceImpl) List(offset, limit, page int) ([]models.Configs, in
 
 func (s *configsServiceImpl) Find(configID int) (*models.Configs, error) {
 	if s.kindID != "adm" {
-		return nil, errors.New(messages.AdmMessage)
+		return nil, domainErrors.ErrAdminOnly
 	}
 	repConfigs := repository.NewConfigsRepository()
 	config, err := repConfigs.Find(configID)
@@ -105,10 +102,10 @@ func (s *configsServiceImpl) Find(configID int) (*models.Configs, error) {
 
 func (s *configsServiceImpl) Update(id int, collors, links, menuAs []string, bannerURL string) error {
 	if s.kindID != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 
 	// idVal, err := val.CheckAnyData("id de config", 100, id, true)
 	// if err != nil {
@@ -165,7 +162,7 @@ func (s *configsServiceImpl) Update(id int, collors, links, menuAs []string, ban
 
 func (s *configsServiceImpl) Remove(id int) error {
 	if s.kindID != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 	repConfigs := repository.NewConfigsRepository()
 	err := repConfigs.Remove(id)
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/configsAPI"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/storage"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/thumbnail"
+)
+
+// mediaTypes: the accepted types, sniffed from the content, and the extension of their files
+var mediaTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type mediaServiceInterface interface {
+	Upload(name string, data []byte) (*models.Media, error)
+	List(p *pagination.Params) ([]models.Media, *pagination.Page, error)
+	Remove(id string) error
+	Open(key string) (io.ReadSeekCloser, string, error)
+	MaxSize() (int64, error)
+}
+
+type mediaServiceImpl struct {
+	UserID string
+	Kind   string
+}
+
+// MaxSize: the biggest file accepted, in bytes
+func (s *mediaServiceImpl) MaxSize() (int64, error) {
+	config := configsAPI.NewConfigs()
+	mediaConfig, err := config.MediaConfigs()
+	if err != nil {
+		return 0, err
+	}
+	return mediaConfig.MaxSize, nil
+}
+
+// Upload: the same content is kept once, uploading it again returns the media already stored
+func (s *mediaServiceImpl) Upload(name string, data []byte) (*models.Media, error) {
+	err := checkWriter(s.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	config := configsAPI.NewConfigs()
+	mediaConfig, err := config.MediaConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > mediaConfig.MaxSize {
+		return nil, domainErrors.ErrMediaTooLarge
+	}
+
+	val := newValidator()
+	nameVal, err := val.CheckAnyData("arquivo", 255, name, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// the type declared by the client isn't trusted
+	mimeType := http.DetectContentType(data)
+	ext, ok := mediaTypes[mimeType]
+	if !ok {
+		return nil, domainErrors.ErrMediaType
+	}
+
+	// the original is served as it's stored, its EXIF may hold where it was taken
+	data, err = thumbnail.Strip(data, mimeType)
+	if err != nil {
+		return nil, domainErrors.ErrMediaType
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	repMedia := repository.NewMediaRepository()
+	media, err := repMedia.FindHash(s.UserID, hash)
+	if err == nil {
+		return media, nil
+	}
+	if !errors.Is(err, domainErrors.ErrNotFound) {
+		return nil, err
+	}
+
+	media = new(models.Media)
+	media.MediaID = uuid.New().String()
+	media.UserID = s.UserID
+	media.Name = nameVal.(string)
+	media.Hash = hash
+	media.MIME = mimeType
+	media.Size = int64(len(data))
+
+	// the content uploaded by another user already has its files, each user gets its own media
+	shared, err := repMedia.FindFile(hash)
+	switch {
+	case err == nil:
+		media.Key = shared.Key
+		media.ThumbKey = shared.ThumbKey
+		media.Width = shared.Width
+		media.Height = shared.Height
+	case errors.Is(err, domainErrors.ErrNotFound):
+		err = s.putFiles(media, data, ext, mediaConfig.ThumbnailWidth)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	stored, err := repMedia.Store(media)
+	if err != nil {
+		return nil, err
+	}
+
+	// another upload of the same content by the user was stored first
+	if !stored {
+		return repMedia.FindHash(s.UserID, hash)
+	}
+
+	return media, nil
+}
+
+// putFiles: stores the content and its thumbnail, keyed by the hash of the content
+func (s *mediaServiceImpl) putFiles(media *models.Media, data []byte, ext string, thumbnailWidth int) error {
+	media.Key = media.Hash + ext
+
+	// there's no WebP decoder in the standard library, its size stays unknown
+	var err error
+	if media.MIME != "image/webp" {
+		media.Width, media.Height, err = thumbnail.Size(data)
+		if err != nil {
+			return domainErrors.ErrMediaType
+		}
+	}
+
+	files, err := storage.NewStorage()
+	if err != nil {
+		return err
+	}
+
+	err = files.Put(media.Key, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	if media.MIME != "image/webp" {
+		thumb, err := thumbnail.Make(data, thumbnailWidth)
+		if err != nil {
+			log.Printf("thumbnail of the media %s not made: %v", media.MediaID, err)
+		} else {
+			thumbKey := media.Hash + "-thumb" + mediaTypes[thumb.MIME]
+			err = files.Put(thumbKey, bytes.NewReader(thumb.Data))
+			if err != nil {
+				log.Printf("thumbnail of the media %s not stored: %v", media.MediaID, err)
+			} else {
+				media.ThumbKey = thumbKey
+			}
+		}
+	}
+
+	return nil
+}
+
+// List: the editors and the admins see all the media, the authors see theirs
+func (s *mediaServiceImpl) List(p *pagination.Params) ([]models.Media, *pagination.Page, error) {
+	err := checkWriter(s.Kind)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userID := s.UserID
+	if canEditAll(s.Kind) {
+		userID = ""
+	}
+
+	repMedia := repository.NewMediaRepository()
+	medias, page, err := repMedia.List(userID, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.SetTotal(page, func() (int, error) {
+		return repMedia.Count(userID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return medias, page, nil
+}
+
+func (s *mediaServiceImpl) Remove(id string) error {
+	err := checkWriter(s.Kind)
+	if err != nil {
+		return err
+	}
+
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id", 36, id, true)
+	if err != nil {
+		return err
+	}
+
+	repMedia := repository.NewMediaRepository()
+	media, err := repMedia.Find(IdVal.(string))
+	if err != nil {
+		return err
+	}
+
+	if !canEditAll(s.Kind) && media.UserID != s.UserID {
+		return domainErrors.ErrAnotherUser
+	}
+
+	err = repMedia.Remove(media.MediaID)
+	if err != nil {
+		return err
+	}
+
+	// the row is already gone, a file left behind is only logged
+	files, err := storage.NewStorage()
+	if err != nil {
+		log.Printf("files of the media %s not removed: %v", media.MediaID, err)
+		return nil
+	}
+	for _, key := range []string{media.Key, media.ThumbKey} {
+		if key == "" {
+			continue
+		}
+		// the file of a content uploaded by other users stays while their media refer to it
+		used, err := repMedia.FileUsed(key)
+		if err != nil {
+			log.Printf("file %s of the media %s not removed: %v", key, media.MediaID, err)
+			continue
+		}
+		if used {
+			continue
+		}
+		err = files.Remove(key)
+		if err != nil {
+			log.Printf("file %s of the media %s not removed: %v", key, media.MediaID, err)
+		}
+	}
+
+	return nil
+}
+
+// Open: the stored file and its content type, only the keys made by Upload are served
+func (s *mediaServiceImpl) Open(key string) (io.ReadSeekCloser, string, error) {
+	if !validMediaKey(key) {
+		return nil, "", domainErrors.ErrNotFound
+	}
+
+	files, err := storage.NewStorage()
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := files.Open(key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			return nil, "", domainErrors.ErrNotFound
+		}
+		return nil, "", err
+	}
+
+	return file, mime.TypeByExtension(filepath.Ext(key)), nil
+}
+
+// validMediaKey: a sha256 hex, maybe with -thumb, and the extension of an accepted type
+func validMediaKey(key string) bool {
+	ext := filepath.Ext(key)
+	known := false
+	for _, e := range mediaTypes {
+		known = known || e == ext
+	}
+	name := strings.TrimSuffix(strings.TrimSuffix(key, ext), "-thumb")
+	if !known || len(name) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
+
+func NewMediaService(userID, kind string) mediaServiceInterface {
+	return &mediaServiceImpl{
+		UserID: userID,
+		Kind:   kind,
+	}
+}
@@ -1,15 +1,11 @@
 package service
 
 import (
-	"errors"
-
 	"github.com/google/uuid"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
-	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
-
-	"github.com/johnHPX/validator-hard/pkg/validator"
 )
 
 type numberLikesServiceInterface interface {
@@ -22,7 +18,7 @@ type numberLikesServiceImpl struct {
 }
 
 func (s *numberLikesServiceImpl) LikePost(postID string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 	PostIDVal, err := val.CheckAnyData("id", 36, postID, true)
 	if err != nil {
 		return err
@@ -33,7 +29,7 @@ func (s *numberLikesServiceImpl) LikePost(postID string) error {
 	numberLikesEntity, err := repNumberLikes.Find(postID, s.userID)
 	if err == nil {
 		if numberLikesEntity.ValueLike {
-			return errors.New(messages.LikePost)
+			return domainErrors.ErrAlreadyLiked
 		} else {
 			err = repNumberLikes.Update(numberLikesEntity.NumberLikesID, true)
 			if err != nil {
@@ -58,7 +54,7 @@ func (s *numberLikesServiceImpl) LikePost(postID string) error {
 }
 
 func (s *numberLikesServiceImpl) DislikePost(postID string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 	PostIDVal, err := val.CheckAnyData("id", 36, postID, true)
 	if err != nil {
 		return err
@@ -67,7 +63,7 @@ func (s *numberLikesServiceImpl) DislikePost(postID string) error {
 	repNumberLikes := repository.NewNumberLikerRepository()
 	entity, err := repNumberLikes.Find(PostIDVal.(string), s.userID)
 	if err != nil {
-		return errors.New(messages.DeslikePost)
+		return domainErrors.ErrNotLiked
 	}
 
 	if entity.ValueLike {
@@ -76,7 +72,7 @@ func (s *numberLikesServiceImpl) DislikePost(postID string) error {
 			return err
 		}
 	} else {
-		return errors.New("esse post já foi discutido por você!")
+		return domainErrors.ErrNotLiked
 	}
 
 	return nil
@@ -2,18 +2,27 @@ package service
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
-	"github.com/johnHPX/validator-hard/pkg/validator"
 )
 
+// postCategoriesMax: categories of a post
+const postCategoriesMax = 20
+
+// batchPostsMax: posts linked to a category in one request
+const batchPostsMax = 100
+
 type postCategoryServiceInterface interface {
 	StorePostCategory(postID, categoryID string) error
 	RemovePostCategory(postID, categoryID string) error
+	SetPostCategories(postID string, categoryIDs []string) error
+	StoreCategoryPosts(categoryID string, postIDs []string) (int, error)
 }
 
 type postCategoryServiceImpl struct {
@@ -24,10 +33,10 @@ type postCategoryServiceImpl struct {
 func (s *postCategoryServiceImpl) StorePostCategory(postID, categoryID string) error {
 
 	if s.kind != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	postIDval, err := val.CheckAnyData("id da postagem", 36, postID, true)
 	if err != nil {
 		return err
@@ -48,7 +57,7 @@ func (s *postCategoryServiceImpl) StorePostCategory(postID, categoryID string) e
 
 	_, err = repPostCategory.Find(postIDval.(string), categoryIDval.(string))
 	if err == nil {
-		return errors.New("esse cadastro já foi realizado!")
+		return domainErrors.ErrAlreadyLinked
 	}
 
 	err = repPostCategory.Store(postCategoryEntity)
@@ -63,10 +72,10 @@ func (s *postCategoryServiceImpl) StorePostCategory(postID, categoryID string) e
 func (s *postCategoryServiceImpl) RemovePostCategory(postID, categoryID string) error {
 
 	if s.kind != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	postIDval, err := val.CheckAnyData("id da postagem", 36, postID, true)
 	if err != nil {
 		return err
@@ -80,7 +89,7 @@ func (s *postCategoryServiceImpl) RemovePostCategory(postID, categoryID string)
 
 	_, err = repPostCategory.Find(postIDval.(string), categoryIDval.(string))
 	if err != nil {
-		return errors.New("já foi deletetado ou não existe")
+		return domainErrors.ErrNotFound
 	}
 
 	err = repPostCategory.Remove(postIDval.(string), categoryIDval.(string))
@@ -91,6 +100,122 @@ func (s *postCategoryServiceImpl) RemovePostCategory(postID, categoryID string)
 	return nil
 }
 
+// checkIDs: the ids of field without the repeated ones, at most max. An empty list is accepted, the callers that need ids check it
+func checkIDs(field string, ids []string, max int) ([]string, error) {
+	val := newValidator()
+	checked := make([]string, 0, len(ids))
+	seen := make(map[string]bool)
+	for _, v := range ids {
+		idVal, err := val.CheckAnyData(field, 36, v, true)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[idVal.(string)] {
+			seen[idVal.(string)] = true
+			checked = append(checked, idVal.(string))
+		}
+	}
+	if len(checked) > max {
+		return nil, domainErrors.Validation(field, messages.FieldInvalid, fmt.Errorf("%d ids, at most %d", len(checked), max))
+	}
+	return checked, nil
+}
+
+// SetPostCategories: the categories become the post's ones in a single transaction, an empty list removes all of them
+func (s *postCategoryServiceImpl) SetPostCategories(postID string, categoryIDs []string) error {
+
+	if s.kind != "adm" {
+		return domainErrors.ErrAdminOnly
+	}
+
+	val := newValidator()
+	postIDval, err := val.CheckAnyData("id da postagem", 36, postID, true)
+	if err != nil {
+		return err
+	}
+	if categoryIDs == nil {
+		return domainErrors.Validation("categorias", messages.FieldRequired, errors.New("no categories list"))
+	}
+	categoryIDs, err = checkIDs("categorias", categoryIDs, postCategoriesMax)
+	if err != nil {
+		return err
+	}
+
+	repPost := repository.NewPostRepository()
+	_, err = repPost.Find(postIDval.(string))
+	if err != nil {
+		return err
+	}
+
+	repCategory := repository.NewCategoryRepository()
+	links := make([]models.PostCategory, 0, len(categoryIDs))
+	for _, v := range categoryIDs {
+		_, err = repCategory.Find(v)
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return domainErrors.Validation("categorias", messages.FieldInvalid, fmt.Errorf("category %s not found", v))
+		}
+		if err != nil {
+			return err
+		}
+		links = append(links, models.PostCategory{
+			PostCategoryId: uuid.New().String(),
+			PostId:         postIDval.(string),
+			CategoryId:     v,
+		})
+	}
+
+	repPostCategory := repository.NewPostCategoryRepository()
+	return repPostCategory.Set(postIDval.(string), links)
+}
+
+// StoreCategoryPosts: links the category to the posts in a single transaction, the posts already linked are skipped.
+// The number of new links is returned
+func (s *postCategoryServiceImpl) StoreCategoryPosts(categoryID string, postIDs []string) (int, error) {
+
+	if s.kind != "adm" {
+		return 0, domainErrors.ErrAdminOnly
+	}
+
+	val := newValidator()
+	categoryIDval, err := val.CheckAnyData("id da categoria", 36, categoryID, true)
+	if err != nil {
+		return 0, err
+	}
+	postIDs, err = checkIDs("postagens", postIDs, batchPostsMax)
+	if err != nil {
+		return 0, err
+	}
+	if len(postIDs) == 0 {
+		return 0, domainErrors.Validation("postagens", messages.FieldRequired, errors.New("no posts to link"))
+	}
+
+	repCategory := repository.NewCategoryRepository()
+	_, err = repCategory.Find(categoryIDval.(string))
+	if err != nil {
+		return 0, err
+	}
+
+	repPost := repository.NewPostRepository()
+	links := make([]models.PostCategory, 0, len(postIDs))
+	for _, v := range postIDs {
+		_, err = repPost.Find(v)
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return 0, domainErrors.Validation("postagens", messages.FieldInvalid, fmt.Errorf("post %s not found", v))
+		}
+		if err != nil {
+			return 0, err
+		}
+		links = append(links, models.PostCategory{
+			PostCategoryId: uuid.New().String(),
+			PostId:         v,
+			CategoryId:     categoryIDval.(string),
+		})
+	}
+
+	repPostCategory := repository.NewPostCategoryRepository()
+	return repPostCategory.StoreMany(links)
+}
+
 func NewPostCategoryService(userID, kind string) postCategoryServiceInterface {
 	return &postCategoryServiceImpl{
 		userID: userID,
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/diff"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/markdown"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+)
+
+type postRevisionServiceInterface interface {
+	List(postID string, p *pagination.Params) ([]models.PostRevision, *pagination.Page, error)
+	Diff(postID string, from, to int) (*models.PostRevisionDiff, error)
+	Restore(postID string, number int) error
+}
+
+type postRevisionServiceImpl struct {
+	UserID string
+	Kind   string
+}
+
+// checkPost: the revisions hold the drafts, only the editors, the admins and the post's author see them
+func (s *postRevisionServiceImpl) checkPost(postID string) (*models.Post, error) {
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id", 36, postID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	repPost := repository.NewPostRepository()
+	post, err := repPost.Find(IdVal.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	if !canEditAll(s.Kind) && post.UserID != s.UserID {
+		return nil, domainErrors.ErrAnotherUser
+	}
+
+	return post, nil
+}
+
+func (s *postRevisionServiceImpl) List(postID string, p *pagination.Params) ([]models.PostRevision, *pagination.Page, error) {
+	post, err := s.checkPost(postID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repRevision := repository.NewPostRevisionRepository()
+	revisions, page, err := repRevision.List(post.PostID, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.SetTotal(page, func() (int, error) {
+		return repRevision.Count(post.PostID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return revisions, page, nil
+}
+
+// Diff: changes from the revision from to the revision to, without to it's the last revision
+// and without from it's the revision before to
+func (s *postRevisionServiceImpl) Diff(postID string, from, to int) (*models.PostRevisionDiff, error) {
+	post, err := s.checkPost(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	repRevision := repository.NewPostRevisionRepository()
+	if to == 0 {
+		// the revisions are numbered from 1 without gaps
+		to, err = repRevision.Count(post.PostID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if from == 0 && to > 1 {
+		from = to - 1
+	}
+	if from < 1 {
+		return nil, domainErrors.Validation("revisão", messages.FieldInvalid, fmt.Errorf("from revision %d", from))
+	}
+
+	fromRevision, err := repRevision.Find(post.PostID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := repRevision.Find(post.PostID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PostRevisionDiff{
+		From:    fromRevision,
+		To:      toRevision,
+		Title:   diffLines(fromRevision.Title, toRevision.Title),
+		Content: diffLines(fromRevision.Content, toRevision.Content),
+	}, nil
+}
+
+func diffLines(a, b string) []models.DiffLine {
+	lines := diff.Lines(a, b)
+	result := make([]models.DiffLine, 0, len(lines))
+	for _, v := range lines {
+		result = append(result, models.DiffLine{
+			Op:   string(v.Op),
+			Text: v.Text,
+		})
+	}
+	return result
+}
+
+// Restore: the old revision becomes the post again as a new revision, the history is never rewritten
+func (s *postRevisionServiceImpl) Restore(postID string, number int) error {
+	post, err := s.checkPost(postID)
+	if err != nil {
+		return err
+	}
+
+	err = checkEditor(s.UserID, s.Kind, post)
+	if err != nil {
+		return err
+	}
+
+	repRevision := repository.NewPostRevisionRepository()
+	old, err := repRevision.Find(post.PostID, number)
+	if err != nil {
+		return err
+	}
+
+	post.Title = old.Title
+	post.Content = old.Content
+	post.ContentHTML, err = markdown.Render(post.Content)
+	if err != nil {
+		return err
+	}
+
+	revision := new(models.PostRevision)
+	revision.RevisionID = uuid.New().String()
+	revision.UserID = s.UserID
+	revision.RestoredFrom = old.Number
+
+	repPost := repository.NewPostRepository()
+	return repPost.Update(post, revision)
+}
+
+func NewPostRevisionService(userID, kind string) postRevisionServiceInterface {
+	return &postRevisionServiceImpl{
+		UserID: userID,
+		Kind:   kind,
+	}
+}
@@ -2,45 +2,173 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/configsAPI"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/markdown"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
-	"github.com/johnHPX/validator-hard/pkg/validator"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/seo"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/views"
 )
 
 type postServieInterface interface {
-	Store(title, content string) error
-	List(offset, limit, page int) ([]models.Post, error)
+	Store(title, content, excerpt, coverID string, seo models.PostSEO, tags []string) error
+	List(sort, order, category, from, to, author string, p *pagination.Params) ([]models.Post, *pagination.Page, error)
 	Count() (int, error)
 	Find(id string) (*models.Post, error)
-	ListTitle(title string, offeset, limit, page int) ([]models.Post, error)
+	FindSlug(slug string, visit views.Visit) (*models.Post, error)
+	FindPublic(id string, visit views.Visit) (*models.Post, error)
+	ListTitle(title string, p *pagination.Params) ([]models.Post, *pagination.Page, error)
 	CountTitle(title string) (int, error)
-	ListByCategory(categoryName string, offset, limit, page int) ([]models.Post, int, error)
-	Update(id, title, content string) error
+	ListByCategory(categoryName string, descendants bool, p *pagination.Params) ([]models.Post, *pagination.Page, error)
+	ListByAuthor(nick string, p *pagination.Params) ([]models.Post, *pagination.Page, error)
+	ListByTag(tag string, p *pagination.Params) ([]models.Post, *pagination.Page, error)
+	Update(id, title, content, excerpt, coverID string, seo models.PostSEO, tags []string) error
 	Remove(id string) error
+	ListOwn(status string, p *pagination.Params) ([]models.Post, *pagination.Page, error)
+	ChangeStatus(id, action string) error
+	Schedule(id, publishAt string) error
+	Head(slug string) (*seo.Head, error)
+	Related(id string, limit int) ([]models.Post, error)
 }
 
+// postContentSize: the markdown of a post, long articles included
+const postContentSize = 200000
+
 type postServiceImpl struct {
 	UserID string
 	Kind   string
 }
 
-func (s *postServiceImpl) Store(title, content string) error {
+// canEditAll: the editors and the admins edit every post
+func canEditAll(kind string) bool {
+	return kind == models.KindAdmin || kind == models.KindEditor
+}
+
+// checkWriter: the authors, the editors and the admins write posts
+func checkWriter(kind string) error {
+	if canEditAll(kind) || kind == models.KindAuthor {
+		return nil
+	}
+	return domainErrors.ErrRoleRequired
+}
+
+// checkEditor: the editors and the admins edit every post, the authors only their own
+func checkEditor(userID, kind string, post *models.Post) error {
+	err := checkWriter(kind)
+	if err != nil {
+		return err
+	}
+	if !canEditAll(kind) && post.UserID != userID {
+		return domainErrors.ErrAnotherUser
+	}
+	return nil
+}
+
+// checkCover: an optional cover, it must be a stored media
+func checkCover(coverID string) (string, error) {
+	val := newValidator()
+	CoverVal, err := val.CheckAnyData("capa", 36, coverID, false)
+	if err != nil {
+		return "", err
+	}
+	coverID = CoverVal.(string)
+	if coverID != "" {
+		repMedia := repository.NewMediaRepository()
+		_, err = repMedia.Find(coverID)
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return "", domainErrors.Validation("capa", messages.FieldInvalid, err)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return coverID, nil
+}
+
+// checkPostSEO: the cover must be a stored media and the urls absolute, all of them are optional
+func checkPostSEO(coverID string, postSEO models.PostSEO) (string, models.PostSEO, error) {
+	val := newValidator()
+	coverID, err := checkCover(coverID)
+	if err != nil {
+		return "", postSEO, err
+	}
+
+	fields := []struct {
+		name  string
+		size  int
+		value *string
+	}{
+		{"meta descrição", 300, &postSEO.MetaDescription},
+		{"url canônica", 2048, &postSEO.CanonicalURL},
+		{"og título", 200, &postSEO.OGTitle},
+		{"og descrição", 300, &postSEO.OGDescription},
+		{"og imagem", 2048, &postSEO.OGImage},
+		{"twitter card", 20, &postSEO.TwitterCard},
+	}
+	for _, v := range fields {
+		value, err := val.CheckAnyData(v.name, v.size, *v.value, false)
+		if err != nil {
+			return "", postSEO, err
+		}
+		*v.value = value.(string)
+	}
+
+	err = checkURL("url canônica", postSEO.CanonicalURL)
+	if err != nil {
+		return "", postSEO, err
+	}
+	err = checkURL("og imagem", postSEO.OGImage)
+	if err != nil {
+		return "", postSEO, err
+	}
+
+	switch postSEO.TwitterCard {
+	case "", models.CardSummary, models.CardSummaryLarge:
+	default:
+		return "", postSEO, domainErrors.Validation("twitter card", messages.FieldInvalid, errors.New(postSEO.TwitterCard))
+	}
+
+	return coverID, postSEO, nil
+}
+
+// Store: without excerpt the post gets the automatic one, made of its first paragraphs.
+// The tags that don't exist yet are created
+func (s *postServiceImpl) Store(title, content, excerpt, coverID string, postSEO models.PostSEO, tags []string) error {
 
-	if s.Kind != "adm" {
-		return errors.New(messages.AdmMessage)
+	err := checkWriter(s.Kind)
+	if err != nil {
+		return err
 	}
 
 	// validator
-	val := validator.NewValidator()
+	val := newValidator()
 	TitleVal, err := val.CheckAnyData("titulo", 255, title, true)
 	if err != nil {
 		return err
 	}
-	ContentVal, err := val.CheckAnyData("conteudo", 9999, content, true)
+	ContentVal, err := val.CheckAnyData("conteudo", postContentSize, content, true)
+	if err != nil {
+		return err
+	}
+	ExcerptVal, err := val.CheckAnyData("resumo", 500, excerpt, false)
+	if err != nil {
+		return err
+	}
+	coverID, postSEO, err = checkPostSEO(coverID, postSEO)
+	if err != nil {
+		return err
+	}
+	postTags, err := checkTags(tags)
 	if err != nil {
 		return err
 	}
@@ -55,10 +183,25 @@ func (s *postServiceImpl) Store(title, content string) error {
 	postEntity.PostID = postID.String()
 	postEntity.Title = TitleVal.(string)
 	postEntity.Content = ContentVal.(string)
+	postEntity.Excerpt = ExcerptVal.(string)
+	postEntity.CoverID = coverID
+	postEntity.SEO = postSEO
+	postEntity.Tags = postTags
+	postEntity.ContentHTML, err = markdown.Render(postEntity.Content)
+	if err != nil {
+		return err
+	}
 	postEntity.Likes = 0
+	postEntity.UserID = s.UserID
+	postEntity.Status = models.PostDraft
+
+	// the first revision of the post
+	revision := new(models.PostRevision)
+	revision.RevisionID = uuid.New().String()
+	revision.UserID = s.UserID
 
 	// create post rep
-	err = repPost.Store(postEntity)
+	err = repPost.Store(postEntity, revision)
 	if err != nil {
 		return err
 	}
@@ -66,38 +209,39 @@ func (s *postServiceImpl) Store(title, content string) error {
 	return nil
 }
 
-func (s *postServiceImpl) List(offset, limit, page int) ([]models.Post, error) {
+func (s *postServiceImpl) List(sort, order, category, from, to, author string, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
 
-	repPost := repository.NewPostRepository()
-	posts, err := repPost.List(offset, limit, page)
+	filter, err := checkPostFilter(category, from, to, author)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	filter.Status = models.PostPublished
+	err = checkPostSort(p, sort, order)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	repNumberLikes := repository.NewNumberLikerRepository()
-
-	entities := make([]models.Post, 0)
-	for _, v := range posts {
-		countLikes, err := repNumberLikes.CountLikes(v.PostID)
-		if err != nil {
-			return nil, err
-		}
+	// the likes come with the posts, they can be sorted by it
+	repPost := repository.NewPostRepository()
+	posts, page, err := repPost.List(filter, p)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		entities = append(entities, models.Post{
-			PostID:  v.PostID,
-			Title:   v.Title,
-			Content: v.Content,
-			Likes:   countLikes,
-		})
+	err = p.SetTotal(page, func() (int, error) {
+		return repPost.Count(filter)
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return entities, nil
+	return posts, page, nil
 }
 
 func (s *postServiceImpl) Count() (int, error) {
 
 	repPost := repository.NewPostRepository()
-	count, err := repPost.Count()
+	count, err := repPost.Count(new(models.PostFilter))
 	if err != nil {
 		return 0, err
 	}
@@ -106,12 +250,14 @@ func (s *postServiceImpl) Count() (int, error) {
 
 }
 
+// Find: the post in any status, for who can edit it
 func (s *postServiceImpl) Find(id string) (*models.Post, error) {
-	if s.Kind != "adm" {
-		return nil, errors.New(messages.AdmMessage)
+	err := checkWriter(s.Kind)
+	if err != nil {
+		return nil, err
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	IdVal, err := val.CheckAnyData("id", 255, id, true)
 	if err != nil {
 		return nil, err
@@ -123,6 +269,11 @@ func (s *postServiceImpl) Find(id string) (*models.Post, error) {
 		return nil, err
 	}
 
+	err = checkEditor(s.UserID, s.Kind, post)
+	if err != nil {
+		return nil, err
+	}
+
 	repNumberLikes := repository.NewNumberLikerRepository()
 	countLikes, err := repNumberLikes.CountLikes(post.PostID)
 	if err != nil {
@@ -131,44 +282,207 @@ func (s *postServiceImpl) Find(id string) (*models.Post, error) {
 
 	post.Likes = countLikes
 
+	repTag := repository.NewTagRepository()
+	post.Tags, err = repTag.Post(post.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	post.Series, err = postSeries(post.PostID)
+	if err != nil {
+		return nil, err
+	}
+
 	return post, nil
 }
 
-func (s *postServiceImpl) ListTitle(title string, offset, limit, page int) ([]models.Post, error) {
-	val := validator.NewValidator()
-	TitleVal, err := val.CheckAnyData("titulo", 255, title, true)
+// FindSlug: the published post of a slug, an old slug finds the post too and its Slug is the current one.
+// The view is only counted at the current slug, where the old ones lead
+func (s *postServiceImpl) FindSlug(slug string, visit views.Visit) (*models.Post, error) {
+	val := newValidator()
+	SlugVal, err := val.CheckAnyData("slug", 300, slug, true)
+	if err != nil {
+		return nil, err
+	}
+
+	repPost := repository.NewPostRepository()
+	post, err := repPost.FindSlug(SlugVal.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	if post.Slug != SlugVal.(string) {
+		visit.Visitor = ""
+	}
+
+	return s.public(post, visit)
+}
+
+// FindPublic: the published post with its likes, categories and comments, for the readers
+func (s *postServiceImpl) FindPublic(id string, visit views.Visit) (*models.Post, error) {
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id", 36, id, true)
 	if err != nil {
 		return nil, err
 	}
 
 	repPost := repository.NewPostRepository()
-	posts, err := repPost.ListTitle(TitleVal.(string), offset, limit, page)
+	post, err := repPost.Find(IdVal.(string))
 	if err != nil {
 		return nil, err
 	}
 
+	return s.public(post, visit)
+}
+
+// public: completes a post shown to the readers and counts the visitor's view, without visitor (a bot) nothing is counted
+func (s *postServiceImpl) public(post *models.Post, visit views.Visit) (*models.Post, error) {
+	// the drafts don't exist for the readers
+	if post.Status != models.PostPublished {
+		return nil, domainErrors.ErrNotFound
+	}
+
 	repNumberLikes := repository.NewNumberLikerRepository()
+	countLikes, err := repNumberLikes.CountLikes(post.PostID)
+	if err != nil {
+		return nil, err
+	}
+	post.Likes = countLikes
+
+	repCategory := repository.NewCategoryRepository()
+	post.Categories, err = repCategory.NamesPost(post.PostID)
+	if err != nil {
+		return nil, err
+	}
+	post.Breadcrumbs, err = repCategory.Breadcrumbs(post.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	repTag := repository.NewTagRepository()
+	post.Tags, err = repTag.Post(post.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	post.Series, err = postSeries(post.PostID)
+	if err != nil {
+		return nil, err
+	}
 
+	repComment := repository.NewCommentRepository()
+	post.Comments, err = repComment.Count(post.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if visit.Visitor != "" {
+		// a view that isn't counted doesn't keep the reader from the post
+		repView := repository.NewPostViewRepository()
+		counted, err := repView.Store(post.PostID, visit.Visitor, visit.Day)
+		if err != nil {
+			log.Printf("view of the post %s not counted: %v", post.PostID, err)
+		} else if counted {
+			post.Views++
+		}
+	}
+
+	return post, nil
+}
+
+// relatedMax: related posts of a post, the cache keeps this many.
+// relatedMaxAge: a cache that outlives it is computed again, it's how a post related only by its text
+// gets into the lists that didn't have it, the triggers clear the ones sharing a category or a tag
+const (
+	relatedMax    = 6
+	relatedMaxAge = 24 * time.Hour
+)
+
+// Related: the published posts closest to the published post, by shared categories and tags and by text.
+// They're computed on the first read after a change and cached per post
+func (s *postServiceImpl) Related(id string, limit int) ([]models.Post, error) {
+	if limit <= 0 || limit > relatedMax {
+		limit = relatedMax
+	}
+
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id", 36, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	repPost := repository.NewPostRepository()
+	post, err := repPost.Find(IdVal.(string))
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != models.PostPublished {
+		return nil, domainErrors.ErrNotFound
+	}
+
+	repRelated := repository.NewPostRelatedRepository()
+	ids, err := repRelated.Cached(post.PostID, relatedMaxAge)
+	if errors.Is(err, domainErrors.ErrNotFound) {
+		ids, err = repRelated.Compute(post.PostID, relatedMax)
+		if err != nil {
+			return nil, err
+		}
+		// a cache that isn't saved is computed again on the next read
+		err = repRelated.Store(post.PostID, ids)
+		if err != nil {
+			log.Printf("related posts of the post %s not cached: %v", post.PostID, err)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	if len(ids) == 0 {
+		return []models.Post{}, nil
+	}
+
+	return repRelated.Posts(ids)
+}
+
+func (s *postServiceImpl) ListTitle(title string, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
+	val := newValidator()
+	TitleVal, err := val.CheckAnyData("titulo", 255, title, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repPost := repository.NewPostRepository()
+	posts, page, err := repPost.ListTitle(TitleVal.(string), p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repNumberLikes := repository.NewNumberLikerRepository()
 	entities := make([]models.Post, 0)
 	for _, v := range posts {
 		countLikes, err := repNumberLikes.CountLikes(v.PostID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		entities = append(entities, models.Post{
-			PostID:  v.PostID,
-			Title:   v.Title,
-			Content: v.Content,
-			Likes:   countLikes,
-		})
+		v.Likes = countLikes
+		entities = append(entities, v)
+	}
+
+	err = p.SetTotal(page, func() (int, error) {
+		return repPost.CountTitle(TitleVal.(string))
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return entities, nil
+	return entities, page, nil
 }
 
 func (s *postServiceImpl) CountTitle(title string) (int, error) {
-	val := validator.NewValidator()
+	val := newValidator()
 	TitleVal, err := val.CheckAnyData("titulo", 255, title, true)
 	if err != nil {
 		return 0, err
@@ -183,17 +497,18 @@ func (s *postServiceImpl) CountTitle(title string) (int, error) {
 	return count, nil
 }
 
-func (s *postServiceImpl) ListByCategory(categoryName string, offset, limit, page int) ([]models.Post, int, error) {
-	val := validator.NewValidator()
+// ListByCategory: the published posts of the category, with descendants the ones of its subcategories too
+func (s *postServiceImpl) ListByCategory(categoryName string, descendants bool, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
+	val := newValidator()
 	categoryVal, err := val.CheckAnyData("categoria", 255, categoryName, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	repPost := repository.NewPostRepository()
-	posts, err := repPost.ListCategory(categoryVal.(string), offset, limit, page)
+	posts, page, err := repPost.ListCategory(categoryVal.(string), descendants, p)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	repNumberLikes := repository.NewNumberLikerRepository()
@@ -201,31 +516,89 @@ func (s *postServiceImpl) ListByCategory(categoryName string, offset, limit, pag
 	for _, v := range posts {
 		countLikes, err := repNumberLikes.CountLikes(v.PostID)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, err
 		}
 
-		entities = append(entities, models.Post{
-			PostID:  v.PostID,
-			Title:   v.Title,
-			Content: v.Content,
-			Likes:   countLikes,
-		})
+		v.Likes = countLikes
+		entities = append(entities, v)
 	}
 
-	count, err := repPost.CountCategory(categoryVal.(string))
+	err = p.SetTotal(page, func() (int, error) {
+		return repPost.CountCategory(categoryVal.(string), descendants)
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return entities, count, nil
+	return entities, page, nil
 }
 
-func (s *postServiceImpl) Update(id, title, content string) error {
-	if s.Kind != "adm" {
-		return errors.New(messages.AdmMessage)
+// ListByAuthor: the published posts of the author, an unknown nick has no posts
+func (s *postServiceImpl) ListByAuthor(nick string, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
+	val := newValidator()
+	nickVal, err := val.CheckAnyData("nick", 255, nick, true)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	val := validator.NewValidator()
+	filter := &models.PostFilter{
+		Author: nickVal.(string),
+		Status: models.PostPublished,
+	}
+
+	repPost := repository.NewPostRepository()
+	posts, page, err := repPost.List(filter, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.SetTotal(page, func() (int, error) {
+		return repPost.Count(filter)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return posts, page, nil
+}
+
+// ListByTag: the published posts of the tag, the tag is found by its slug in any case and accents
+func (s *postServiceImpl) ListByTag(tag string, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
+	_, tagSlug, err := tagName(tag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := &models.PostFilter{
+		Tag:    tagSlug,
+		Status: models.PostPublished,
+	}
+
+	repPost := repository.NewPostRepository()
+	posts, page, err := repPost.List(filter, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.SetTotal(page, func() (int, error) {
+		return repPost.Count(filter)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return posts, page, nil
+}
+
+// Update: an empty excerpt gives back the automatic one, the cover and the seo are replaced as well.
+// Without tags (nil) the post keeps its ones, an empty list removes them
+func (s *postServiceImpl) Update(id, title, content, excerpt, coverID string, postSEO models.PostSEO, tags []string) error {
+	err := checkWriter(s.Kind)
+	if err != nil {
+		return err
+	}
+
+	val := newValidator()
 	IdVal, err := val.CheckAnyData("id", 36, id, true)
 	if err != nil {
 		return err
@@ -234,18 +607,50 @@ func (s *postServiceImpl) Update(id, title, content string) error {
 	if err != nil {
 		return err
 	}
-	ContentVal, err := val.CheckAnyData("conteudo", 9999, content, true)
+	ContentVal, err := val.CheckAnyData("conteudo", postContentSize, content, true)
+	if err != nil {
+		return err
+	}
+	ExcerptVal, err := val.CheckAnyData("resumo", 500, excerpt, false)
+	if err != nil {
+		return err
+	}
+	coverID, postSEO, err = checkPostSEO(coverID, postSEO)
+	if err != nil {
+		return err
+	}
+	postTags, err := checkTags(tags)
+	if err != nil {
+		return err
+	}
+
+	repPost := repository.NewPostRepository()
+	post, err := repPost.Find(IdVal.(string))
+	if err != nil {
+		return err
+	}
+
+	err = checkEditor(s.UserID, s.Kind, post)
 	if err != nil {
 		return err
 	}
 
-	post := new(models.Post)
-	post.PostID = IdVal.(string)
 	post.Title = TitleVal.(string)
 	post.Content = ContentVal.(string)
+	post.Excerpt = ExcerptVal.(string)
+	post.CoverID = coverID
+	post.SEO = postSEO
+	post.Tags = postTags
+	post.ContentHTML, err = markdown.Render(post.Content)
+	if err != nil {
+		return err
+	}
 
-	repPost := repository.NewPostRepository()
-	err = repPost.Update(post)
+	revision := new(models.PostRevision)
+	revision.RevisionID = uuid.New().String()
+	revision.UserID = s.UserID
+
+	err = repPost.Update(post, revision)
 	if err != nil {
 		return err
 	}
@@ -254,17 +659,28 @@ func (s *postServiceImpl) Update(id, title, content string) error {
 }
 
 func (s *postServiceImpl) Remove(id string) error {
-	if s.Kind != "adm" {
-		return errors.New(messages.AdmMessage)
+	err := checkWriter(s.Kind)
+	if err != nil {
+		return err
 	}
-	val := validator.NewValidator()
+	val := newValidator()
 	IdVal, err := val.CheckAnyData("id", 36, id, true)
 	if err != nil {
 		return err
 	}
 
 	repPost := repository.NewPostRepository()
-	err = repPost.Remove(IdVal.(string))
+	post, err := repPost.Find(IdVal.(string))
+	if err != nil {
+		return err
+	}
+
+	err = checkEditor(s.UserID, s.Kind, post)
+	if err != nil {
+		return err
+	}
+
+	err = repPost.Remove(post.PostID)
 	if err != nil {
 		return err
 	}
@@ -272,6 +688,279 @@ func (s *postServiceImpl) Remove(id string) error {
 	return nil
 }
 
+// ListOwn: posts of the user in any status, status filters one of them
+func (s *postServiceImpl) ListOwn(status string, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
+	switch status {
+	case "", models.PostDraft, models.PostInReview, models.PostScheduled, models.PostPublished, models.PostArchived:
+	default:
+		return nil, nil, domainErrors.Validation("status", messages.FieldInvalid, fmt.Errorf("unknown status %q", status))
+	}
+
+	val := newValidator()
+	userIDVal, err := val.CheckAnyData("id", 36, s.UserID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := &models.PostFilter{
+		Status: status,
+		UserID: userIDVal.(string),
+	}
+
+	repPost := repository.NewPostRepository()
+	posts, page, err := repPost.List(filter, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.SetTotal(page, func() (int, error) {
+		return repPost.Count(filter)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return posts, page, nil
+}
+
+// postAction: a step of the posts' workflow, from are the statuses the step starts from
+type postAction struct {
+	from []string
+	to   string
+	// byAuthor: the post's author can take the step, the others steps are only for the editors and the admins
+	byAuthor bool
+}
+
+// postActions: draft -> in_review -> published -> archived, publish skips the review
+// and publishes a scheduled post right away
+var postActions = map[string]postAction{
+	"submit":     {from: []string{models.PostDraft}, to: models.PostInReview, byAuthor: true},
+	"approve":    {from: []string{models.PostInReview}, to: models.PostPublished},
+	"publish":    {from: []string{models.PostDraft, models.PostInReview, models.PostScheduled}, to: models.PostPublished},
+	"unpublish":  {from: []string{models.PostPublished}, to: models.PostDraft},
+	"unschedule": {from: []string{models.PostScheduled}, to: models.PostDraft},
+	"archive":    {from: []string{models.PostDraft, models.PostScheduled, models.PostPublished}, to: models.PostArchived},
+}
+
+// scheduleFrom: statuses a post can be scheduled from, a scheduled post can be moved to another time
+var scheduleFrom = []string{models.PostDraft, models.PostInReview, models.PostScheduled}
+
+// ChangeStatus: takes a step of the workflow, the author is notified when the post is published
+func (s *postServiceImpl) ChangeStatus(id, action string) error {
+	step, ok := postActions[action]
+	if !ok {
+		return domainErrors.ErrNotFound
+	}
+
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id", 36, id, true)
+	if err != nil {
+		return err
+	}
+
+	repPost := repository.NewPostRepository()
+	post, err := repPost.Find(IdVal.(string))
+	if err != nil {
+		return err
+	}
+
+	if !canEditAll(s.Kind) {
+		if !step.byAuthor {
+			return domainErrors.ErrRoleRequired
+		}
+		if post.UserID != s.UserID {
+			return domainErrors.ErrAnotherUser
+		}
+	}
+
+	// the status is checked again by the update, two requests can't take the same step
+	err = repPost.UpdateStatus(post.PostID, step.from, step.to)
+	if err != nil {
+		return err
+	}
+
+	// the post is already public, a failed email doesn't make the step fail
+	if step.to == models.PostPublished {
+		systemService := NewSystemService()
+		err = systemService.SendEmailPostPublished(post.PostID)
+		if err != nil {
+			log.Printf("post %s published, email not sent: %v", post.PostID, err)
+		}
+	}
+
+	return nil
+}
+
+// Schedule: the post is published at publishAt by the scheduler, only the editors and the admins schedule
+func (s *postServiceImpl) Schedule(id, publishAt string) error {
+	if !canEditAll(s.Kind) {
+		return domainErrors.ErrRoleRequired
+	}
+
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id", 36, id, true)
+	if err != nil {
+		return err
+	}
+	PublishAtVal, err := val.CheckAnyData("data de publicação", 64, publishAt, true)
+	if err != nil {
+		return err
+	}
+
+	t, err := time.Parse(time.RFC3339, PublishAtVal.(string))
+	if err != nil {
+		return domainErrors.Validation("data de publicação", messages.FieldInvalid, err)
+	}
+	if !t.After(time.Now()) {
+		return domainErrors.Validation("data de publicação", messages.FieldInvalid, fmt.Errorf("publish at %s in the past", publishAt))
+	}
+
+	repPost := repository.NewPostRepository()
+	return repPost.Schedule(IdVal.(string), scheduleFrom, t)
+}
+
+// checkPostSort: sets the order of a post list, the newest first when sort and order are empty
+func checkPostSort(p *pagination.Params, sort, order string) error {
+	asc := false
+	switch strings.ToLower(order) {
+	case "", "desc":
+	case "asc":
+		asc = true
+	default:
+		return domainErrors.Validation("order", messages.FieldInvalid, fmt.Errorf("unknown order %q", order))
+	}
+
+	switch sort {
+	case "", "created_at":
+		sort = ""
+	case "likes", "title", "views":
+	default:
+		return domainErrors.Validation("sort", messages.FieldInvalid, fmt.Errorf("unknown sort field %q", sort))
+	}
+
+	return p.SortBy(sort, asc)
+}
+
+// checkPostFilter: category is a list of slugs or names separated by commas, from and to are dates or RFC 3339 times
+func checkPostFilter(category, from, to, author string) (*models.PostFilter, error) {
+	filter := new(models.PostFilter)
+
+	for _, v := range strings.Split(category, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			filter.Categories = append(filter.Categories, v)
+		}
+	}
+
+	if from != "" {
+		t, err := parseDate(from, false)
+		if err != nil {
+			return nil, domainErrors.Validation("from", messages.FieldInvalid, err)
+		}
+		filter.From = &t
+	}
+
+	if to != "" {
+		t, err := parseDate(to, true)
+		if err != nil {
+			return nil, domainErrors.Validation("to", messages.FieldInvalid, err)
+		}
+		filter.To = &t
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return nil, domainErrors.Validation("to", messages.FieldInvalid, fmt.Errorf("to %s before from %s", to, from))
+	}
+
+	filter.Author = strings.TrimSpace(author)
+
+	return filter, nil
+}
+
+// parseDate: a day as the end of a range includes the whole day
+func parseDate(value string, end bool) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+	t, err = time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// Head: the metadata of the page of a published post, for the frontends rendered on the server.
+// The fields of the post's seo come first, then the title, the excerpt and the cover
+func (s *postServiceImpl) Head(slug string) (*seo.Head, error) {
+	val := newValidator()
+	SlugVal, err := val.CheckAnyData("slug", 255, slug, true)
+	if err != nil {
+		return nil, err
+	}
+
+	repPost := repository.NewPostRepository()
+	post, err := repPost.FindSlug(SlugVal.(string))
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != models.PostPublished {
+		return nil, domainErrors.ErrNotFound
+	}
+
+	repCategory := repository.NewCategoryRepository()
+	categories, err := repCategory.NamesPost(post.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	config := configsAPI.NewConfigs()
+	site, err := config.SiteConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	page := seo.Page{
+		SiteName:      site.Name,
+		Locale:        site.Locale,
+		Twitter:       site.Twitter,
+		Title:         post.Title,
+		Description:   post.SEO.MetaDescription,
+		URL:           post.SEO.CanonicalURL,
+		OGTitle:       post.SEO.OGTitle,
+		OGDescription: post.SEO.OGDescription,
+		Image:         post.SEO.OGImage,
+		Card:          post.SEO.TwitterCard,
+		Author:        post.AuthorName,
+		Published:     post.PublishedAt,
+		Modified:      post.UpdatedAt,
+		Keywords:      categories,
+		Words:         post.Summary.Words,
+	}
+	if page.Description == "" {
+		page.Description = post.Excerpt
+	}
+	if page.Description == "" {
+		page.Description = post.Summary.Excerpt
+	}
+	if page.URL == "" {
+		page.URL = strings.TrimSuffix(site.URL, "/") + site.PostPath + post.Slug
+	}
+	if page.Image == "" && post.Cover != nil {
+		page.Image = strings.TrimSuffix(site.APIURL, "/") + models.MediaPath + post.Cover.Key
+		page.ImageWidth = post.Cover.Width
+		page.ImageHeight = post.Cover.Height
+	}
+	if page.Author == "" {
+		page.Author = post.AuthorNick
+	}
+
+	return seo.Build(page), nil
+}
+
 func NewPostService(userID, kind string) postServieInterface {
 	return &postServiceImpl{
 		UserID: userID,
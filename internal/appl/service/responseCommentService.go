@@ -5,13 +5,14 @@ import (
 
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
-	"github.com/johnHPX/validator-hard/pkg/validator"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/markdown"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 )
 
 type responseCommentServiceInterface interface {
 	Store(commentID, title, content string) error
-	List(commentID string, offset, limit, page int) ([]models.ResponseComment, int, error)
-	ListUser(offset, limit, page int) ([]models.ResponseComment, int, error)
+	List(commentID string, p *pagination.Params) ([]models.ResponseComment, *pagination.Page, error)
+	ListUser(p *pagination.Params) ([]models.ResponseComment, *pagination.Page, error)
 	Update(responseCommentID, title, content string) error
 	Remove(responseCommentID string) error
 }
@@ -21,7 +22,7 @@ type responseCommentServiceImpl struct {
 }
 
 func (s *responseCommentServiceImpl) Store(commentID, title, content string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 
 	commentIDval, err := val.CheckAnyData("id do comentario", 36, commentID, true)
 	if err != nil {
@@ -41,6 +42,10 @@ func (s *responseCommentServiceImpl) Store(commentID, title, content string) err
 	responseCommentEntity.ResponseCommentID = responseCommentID.String()
 	responseCommentEntity.Title = titleVal.(string)
 	responseCommentEntity.Content = contentVal.(string)
+	responseCommentEntity.ContentHTML, err = markdown.RenderRestricted(responseCommentEntity.Content)
+	if err != nil {
+		return err
+	}
 	responseCommentEntity.CommentID = commentIDval.(string)
 	responseCommentEntity.UserID = s.userID
 
@@ -59,43 +64,47 @@ func (s *responseCommentServiceImpl) Store(commentID, title, content string) err
 	return nil
 }
 
-func (s *responseCommentServiceImpl) List(commentID string, offset, limit, page int) ([]models.ResponseComment, int, error) {
-	val := validator.NewValidator()
+func (s *responseCommentServiceImpl) List(commentID string, p *pagination.Params) ([]models.ResponseComment, *pagination.Page, error) {
+	val := newValidator()
 	commentIDval, err := val.CheckAnyData("id do comentario", 36, commentID, true)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
 	repResponseComment := repository.NewResponseCommmentRepository()
-	responseCommentsEntities, err := repResponseComment.List(commentIDval.(string), offset, limit, page)
+	responseCommentsEntities, page, err := repResponseComment.List(commentIDval.(string), p)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
-	count, err := repResponseComment.Count(commentIDval.(string))
+	err = p.SetTotal(page, func() (int, error) {
+		return repResponseComment.Count(commentIDval.(string))
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return responseCommentsEntities, count, nil
+	return responseCommentsEntities, page, nil
 }
 
-func (s *responseCommentServiceImpl) ListUser(offset, limit, page int) ([]models.ResponseComment, int, error) {
+func (s *responseCommentServiceImpl) ListUser(p *pagination.Params) ([]models.ResponseComment, *pagination.Page, error) {
 
 	repResponseComment := repository.NewResponseCommmentRepository()
-	commentsEntities, err := repResponseComment.ListUser(s.userID, offset, limit, page)
+	commentsEntities, page, err := repResponseComment.ListUser(s.userID, p)
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
-	count, err := repResponseComment.CountUser(s.userID)
+	err = p.SetTotal(page, func() (int, error) {
+		return repResponseComment.CountUser(s.userID)
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, nil, err
 	}
 
-	return commentsEntities, count, nil
+	return commentsEntities, page, nil
 }
 
 func (s *responseCommentServiceImpl) Update(responseCommentID, title, content string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 	responseCommentIDVal, err := val.CheckAnyData("id do comentario", 36, responseCommentID, true)
 	if err != nil {
 		return err
@@ -113,6 +122,10 @@ func (s *responseCommentServiceImpl) Update(responseCommentID, title, content st
 	responseCommentEntity.ResponseCommentID = responseCommentIDVal.(string)
 	responseCommentEntity.Title = titleVal.(string)
 	responseCommentEntity.Content = contentVal.(string)
+	responseCommentEntity.ContentHTML, err = markdown.RenderRestricted(responseCommentEntity.Content)
+	if err != nil {
+		return err
+	}
 
 	repComment := repository.NewResponseCommmentRepository()
 	err = repComment.Update(responseCommentEntity)
@@ -124,7 +137,7 @@ func (s *responseCommentServiceImpl) Update(responseCommentID, title, content st
 }
 
 func (s *responseCommentServiceImpl) Remove(responseCommentID string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 	responseCommentIDVal, err := val.CheckAnyData("id do comentario", 36, responseCommentID, true)
 	if err != nil {
 		return err
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+)
+
+// searchCommentsMax: matched comments shown by post
+const searchCommentsMax = 3
+
+type searchServiceInterface interface {
+	Search(query, kind string, comments bool, p *pagination.Params) ([]models.SearchPost, []models.SearchComment, *pagination.Page, error)
+}
+
+type searchServiceImpl struct{}
+
+// Search: finds the posts or, with kind comments, the comments, by relevance. The posts bring the comments
+// of each one that match the query too when comments are asked
+func (s *searchServiceImpl) Search(query, kind string, comments bool, p *pagination.Params) ([]models.SearchPost, []models.SearchComment, *pagination.Page, error) {
+	val := newValidator()
+	queryVal, err := val.CheckAnyData("busca", 255, query, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = p.SortBy("rank", false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	switch kind {
+	case "", "posts":
+		posts, page, err := s.posts(queryVal.(string), comments, p)
+		return posts, nil, page, err
+	case "comments":
+		repSearch := repository.NewSearchRepository()
+		list, page, err := repSearch.Comments(queryVal.(string), p)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		err = p.SetTotal(page, func() (int, error) {
+			return repSearch.CountComments(queryVal.(string))
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return nil, list, page, nil
+	}
+
+	return nil, nil, nil, domainErrors.Validation("type", messages.FieldInvalid, fmt.Errorf("unknown search type %q", kind))
+}
+
+func (s *searchServiceImpl) posts(query string, comments bool, p *pagination.Params) ([]models.SearchPost, *pagination.Page, error) {
+	repSearch := repository.NewSearchRepository()
+	posts, page, err := repSearch.Posts(query, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = p.SetTotal(page, func() (int, error) {
+		return repSearch.CountPosts(query)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !comments || len(posts) == 0 {
+		return posts, page, nil
+	}
+
+	postIDs := make([]string, 0, len(posts))
+	byPost := make(map[string]int, len(posts))
+	for i, v := range posts {
+		postIDs = append(postIDs, v.PostID)
+		byPost[v.PostID] = i
+	}
+	list, err := repSearch.PostComments(query, postIDs, searchCommentsMax)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, v := range list {
+		i := byPost[v.PostID]
+		posts[i].Comments = append(posts[i].Comments, v)
+	}
+
+	return posts, page, nil
+}
+
+func NewSearchService() searchServiceInterface {
+	return &searchServiceImpl{}
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/slug"
+)
+
+// seriesPartsMax: posts of a series
+const seriesPartsMax = 100
+
+type seriesServiceInterface interface {
+	Store(title, description string) (string, error)
+	List(p *pagination.Params) ([]models.Series, *pagination.Page, error)
+	Find(id string) (*models.Series, error)
+	FindSlug(slug string) (*models.Series, error)
+	Update(id, title, description string) error
+	Remove(id string) error
+	SetParts(id string, postIDs []string) error
+}
+
+type seriesServiceImpl struct {
+	UserID string
+	Kind   string
+}
+
+// checkSeriesEditor: the editors and the admins edit every series, the authors only their own
+func checkSeriesEditor(userID, kind string, series *models.Series) error {
+	err := checkWriter(kind)
+	if err != nil {
+		return err
+	}
+	if !canEditAll(kind) && series.UserID != userID {
+		return domainErrors.ErrAnotherUser
+	}
+	return nil
+}
+
+// Store: the slug is made from the title and kept across the renames, the id of the new series is returned
+func (s *seriesServiceImpl) Store(title, description string) (string, error) {
+	err := checkWriter(s.Kind)
+	if err != nil {
+		return "", err
+	}
+
+	val := newValidator()
+	TitleVal, err := val.CheckAnyData("titulo", 255, title, true)
+	if err != nil {
+		return "", err
+	}
+	DescriptionVal, err := val.CheckAnyData("descrição", 1000, description, false)
+	if err != nil {
+		return "", err
+	}
+
+	series := &models.Series{
+		SeriesID:    uuid.New().String(),
+		Title:       TitleVal.(string),
+		Description: DescriptionVal.(string),
+		UserID:      s.UserID,
+	}
+
+	repSeries := repository.NewSeriesRepository()
+	series.Slug, err = repSeries.FreeSlug(slug.Make(series.Title), series.SeriesID)
+	if err != nil {
+		return "", err
+	}
+
+	err = repSeries.Store(series)
+	if err != nil {
+		return "", err
+	}
+
+	return series.SeriesID, nil
+}
+
+// List: the series with published parts
+func (s *seriesServiceImpl) List(p *pagination.Params) ([]models.Series, *pagination.Page, error) {
+	repSeries := repository.NewSeriesRepository()
+	list, page, err := repSeries.List(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = p.SetTotal(page, repSeries.Count)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return list, page, nil
+}
+
+// withParts: the series with its published parts in order, a series without them doesn't exist for the readers
+func withParts(series *models.Series) (*models.Series, error) {
+	repSeries := repository.NewSeriesRepository()
+	parts, err := repSeries.Parts(series.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, domainErrors.ErrNotFound
+	}
+
+	series.Parts = parts
+	return series, nil
+}
+
+// Find: the series of the id with its published parts
+func (s *seriesServiceImpl) Find(id string) (*models.Series, error) {
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id da série", 36, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	repSeries := repository.NewSeriesRepository()
+	series, err := repSeries.Find(IdVal.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return withParts(series)
+}
+
+// FindSlug: the series of the slug with its published parts
+func (s *seriesServiceImpl) FindSlug(seriesSlug string) (*models.Series, error) {
+	val := newValidator()
+	SlugVal, err := val.CheckAnyData("slug", 300, seriesSlug, true)
+	if err != nil {
+		return nil, err
+	}
+
+	repSeries := repository.NewSeriesRepository()
+	series, err := repSeries.FindSlug(SlugVal.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return withParts(series)
+}
+
+func (s *seriesServiceImpl) find(id string) (*models.Series, error) {
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id da série", 36, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	repSeries := repository.NewSeriesRepository()
+	series, err := repSeries.Find(IdVal.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkSeriesEditor(s.UserID, s.Kind, series)
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (s *seriesServiceImpl) Update(id, title, description string) error {
+	series, err := s.find(id)
+	if err != nil {
+		return err
+	}
+
+	val := newValidator()
+	TitleVal, err := val.CheckAnyData("titulo", 255, title, true)
+	if err != nil {
+		return err
+	}
+	DescriptionVal, err := val.CheckAnyData("descrição", 1000, description, false)
+	if err != nil {
+		return err
+	}
+
+	series.Title = TitleVal.(string)
+	series.Description = DescriptionVal.(string)
+
+	repSeries := repository.NewSeriesRepository()
+	return repSeries.Update(series)
+}
+
+// Remove: the posts stay, out of any series
+func (s *seriesServiceImpl) Remove(id string) error {
+	series, err := s.find(id)
+	if err != nil {
+		return err
+	}
+
+	repSeries := repository.NewSeriesRepository()
+	return repSeries.Remove(series.SeriesID)
+}
+
+// SetParts: the posts, in their order, become all the parts of the series in a single transaction.
+// The authors only put their own posts, and a post of another series must be taken out of it first
+func (s *seriesServiceImpl) SetParts(id string, postIDs []string) error {
+	series, err := s.find(id)
+	if err != nil {
+		return err
+	}
+
+	if postIDs == nil {
+		return domainErrors.Validation("postagens", messages.FieldRequired, errors.New("no posts list"))
+	}
+	postIDs, err = checkIDs("postagens", postIDs, seriesPartsMax)
+	if err != nil {
+		return err
+	}
+
+	repPost := repository.NewPostRepository()
+	for _, v := range postIDs {
+		post, err := repPost.Find(v)
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return domainErrors.Validation("postagens", messages.FieldInvalid, fmt.Errorf("post %s not found", v))
+		}
+		if err != nil {
+			return err
+		}
+		err = checkEditor(s.UserID, s.Kind, post)
+		if err != nil {
+			return err
+		}
+	}
+
+	repSeries := repository.NewSeriesRepository()
+	owners, err := repSeries.Owners(postIDs)
+	if err != nil {
+		return err
+	}
+	for _, v := range owners {
+		if v != series.SeriesID {
+			return domainErrors.ErrPostInSeries
+		}
+	}
+
+	return repSeries.SetParts(series.SeriesID, postIDs)
+}
+
+// postSeries: where the published post is in its series, nil when it isn't in one
+func postSeries(postID string) (*models.PostSeries, error) {
+	repSeries := repository.NewSeriesRepository()
+	series, err := repSeries.Post(postID)
+	if errors.Is(err, domainErrors.ErrNotFound) {
+		return nil, nil
+	}
+	return series, err
+}
+
+func NewSeriesService(userID, kind string) seriesServiceInterface {
+	return &seriesServiceImpl{
+		UserID: userID,
+		Kind:   kind,
+	}
+}
@@ -2,9 +2,12 @@ package service
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/configsAPI"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 	mail "github.com/xhit/go-simple-mail/v2"
 )
 
@@ -12,6 +15,8 @@ type systemServiceInterface interface {
 	SendEmail(template, emailToDestiny, messageTitle string) error
 	SendEmailComment(commentId string) error
 	SendEmailResponseComment(responseCommentId string) error
+	SendEmailPostPublished(postID string) error
+	PublishScheduled() (int, error)
 }
 
 type systemServiceImpl struct{}
@@ -53,6 +58,33 @@ func (S *systemServiceImpl) SendEmail(template, emailToDestiny, messageTitle str
 	return nil
 }
 
+// updatesTemplate: email of the blog's updates, in the language of the user greeted
+func (s *systemServiceImpl) updatesTemplate(language, greeting, heading, body string) string {
+	if language == "" {
+		language = messages.DefaultLanguage
+	}
+	title := messages.Translate(language, messages.EmailUpdatesSubject)
+	return fmt.Sprintf(`
+
+	<!DOCTYPE html>
+	<html lang="%s">
+		<head>
+			<meta charset="UTF-8">
+			<title>%s</title>
+		</head>
+		<body>
+			<h1>%s</h1>
+
+			<p>%s</p>
+
+			<h3>%s</h3>
+			<p>%s</p>
+		</body>
+	</html>
+
+`, language, title, title, greeting, heading, body)
+}
+
 func (s *systemServiceImpl) SendEmailComment(commentId string) error {
 
 	repComment := repository.NewCommentRepository()
@@ -73,33 +105,18 @@ func (s *systemServiceImpl) SendEmailComment(commentId string) error {
 		return err
 	}
 
-	template := fmt.Sprintf(`
-
-	<!DOCTYPE html>
-	<html lang="pt-br">
-		<head>
-			<meta charset="UTF-8">
-			<title>Atualizações do site</title>
-		</head>
-		<body>
-			<h1>Atualizações do Blog</h1>
-
-			<p>Olá Adminstrador! Aqui está as novidades do seu blog!</p>
-
-			<h3>Um Usuário comentou em um dos Seus Posts!</h3>
-			<p>O usuario %s comentou no Post %s</p>
-		</body>
-	</html>
-
-`, userEntity.Nick, postEntity.Title)
-
-	admUserEntities, err := repUser.ListUserAdm("adm", 0, 10, 1)
+	admUserEntities, _, err := repUser.ListUserAdm("adm", &pagination.Params{Limit: pagination.DefaultLimit})
 	if err != nil {
 		return err
 	}
 
 	for _, v := range admUserEntities {
-		err = s.SendEmail(template, v.Email, "Atualizações do Blog")
+		template := s.updatesTemplate(v.Language,
+			messages.Translate(v.Language, messages.EmailUpdatesGreeting),
+			messages.Translate(v.Language, messages.EmailCommentHeading),
+			messages.Translate(v.Language, messages.EmailCommentBody, userEntity.Nick, postEntity.Title),
+		)
+		err = s.SendEmail(template, v.Email, messages.Translate(v.Language, messages.EmailUpdatesSubject))
 		if err != nil {
 			return err
 		}
@@ -138,39 +155,70 @@ func (s *systemServiceImpl) SendEmailResponseComment(responseCommentId string) e
 		return err
 	}
 
-	template1 := fmt.Sprintf(`
+	admUserEntities, _, err := repUser.ListUserAdm("adm", &pagination.Params{Limit: pagination.DefaultLimit})
+	if err != nil {
+		return err
+	}
 
-	<!DOCTYPE html>
-	<html lang="pt-br">
-		<head>
-			<meta charset="UTF-8">
-			<title>Atualizações do site</title>
-		</head>
-		<body>
-			<h1>Atualizações do Blog</h1>
+	for _, v := range admUserEntities {
+		template := s.updatesTemplate(v.Language,
+			messages.Translate(v.Language, messages.EmailUpdatesGreeting),
+			messages.Translate(v.Language, messages.EmailResponseHeading),
+			messages.Translate(v.Language, messages.EmailResponseBody, userEntityResponseComment.Nick, userEntityComment.Nick, postEntity.Title),
+		)
+		err = s.SendEmail(template, v.Email, messages.Translate(v.Language, messages.EmailUpdatesSubject))
+		if err != nil {
+			return err
+		}
+	}
 
-			<p>Olá Adminstrador! Aqui está as novidades do seu blog!</p>
+	return nil
+}
 
-			<h3>Um Usuário respondeu ao um comentario de um dos Seus Posts!</h3>
-			<p>O usuario %s respondeu ao comentario de %s no Post %s</p>
-		</body>
-	</html>
+// SendEmailPostPublished: tells the author that the post is public, posts without author are skipped
+func (s *systemServiceImpl) SendEmailPostPublished(postID string) error {
+	repPost := repository.NewPostRepository()
+	postEntity, err := repPost.Find(postID)
+	if err != nil {
+		return err
+	}
 
-`, userEntityResponseComment.Nick, userEntityComment.Nick, postEntity.Title)
+	if postEntity.UserID == "" {
+		return nil
+	}
 
-	admUserEntities, err := repUser.ListUserAdm("adm", 0, 10, 1)
+	repUser := repository.NewUserRepository()
+	userEntity, err := repUser.Find(postEntity.UserID)
 	if err != nil {
 		return err
 	}
 
-	for _, v := range admUserEntities {
-		err = s.SendEmail(template1, v.Email, "Atualizações do Blog")
+	// the author isn't greeted as the administrator
+	template := s.updatesTemplate(userEntity.Language,
+		messages.Translate(userEntity.Language, messages.EmailAuthorGreeting, userEntity.Nick),
+		messages.Translate(userEntity.Language, messages.EmailPublishedHeading),
+		messages.Translate(userEntity.Language, messages.EmailPublishedBody, postEntity.Title),
+	)
+	return s.SendEmail(template, userEntity.Email, messages.Translate(userEntity.Language, messages.EmailUpdatesSubject))
+}
+
+// PublishScheduled: publishes the posts whose time has come, with the same notification of a manual publish
+func (s *systemServiceImpl) PublishScheduled() (int, error) {
+	repPost := repository.NewPostRepository()
+	ids, err := repPost.PublishScheduled()
+	if err != nil {
+		return 0, err
+	}
+
+	// the posts are already public, a failed email doesn't stop the others
+	for _, id := range ids {
+		err = s.SendEmailPostPublished(id)
 		if err != nil {
-			return err
+			log.Printf("post %s published, email not sent: %v", id, err)
 		}
 	}
 
-	return nil
+	return len(ids), nil
 }
 
 func NewSystemService() systemServiceInterface {
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/slug"
+)
+
+// postTagsMax: tags of a post
+const postTagsMax = 10
+
+// cloudMax: the most tags of a cloud
+const cloudMax = 200
+
+type tagServiceInterface interface {
+	Cloud(limit int) ([]models.Tag, error)
+	Rename(id, name string) error
+	Merge(id string, from []string) error
+}
+
+type tagServiceImpl struct {
+	UserID string
+	Kind   string
+}
+
+// tagName: the name with its spaces collapsed and its slug, a name without latin letters or digits is refused
+// The slug has at most twice the letters of the name (ß, æ and œ turn into two), the column fits 100
+func tagName(name string) (string, string, error) {
+	val := newValidator()
+	nameVal, err := val.CheckAnyData("tag", 50, strings.Join(strings.Fields(name), " "), true)
+	if err != nil {
+		return "", "", err
+	}
+	name = nameVal.(string)
+
+	s := slug.Make(name)
+	if s == slug.Fallback && !strings.EqualFold(name, slug.Fallback) {
+		return "", "", domainErrors.Validation("tag", messages.FieldInvalid, fmt.Errorf("tag %q has no letters", name))
+	}
+	return name, s, nil
+}
+
+// checkTags: the tags of a post, the repeated ones (same slug) are kept once.
+// nil stays nil, an update without tags keeps the post's ones
+func checkTags(names []string) ([]models.Tag, error) {
+	if names == nil {
+		return nil, nil
+	}
+
+	tags := make([]models.Tag, 0, len(names))
+	seen := make(map[string]bool)
+	for _, v := range names {
+		name, s, err := tagName(v)
+		if err != nil {
+			return nil, err
+		}
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		tags = append(tags, models.Tag{
+			TagID: uuid.New().String(),
+			Name:  name,
+			Slug:  s,
+		})
+	}
+
+	if len(tags) > postTagsMax {
+		return nil, domainErrors.Validation("tags", messages.FieldInvalid, fmt.Errorf("%d tags, at most %d", len(tags), postTagsMax))
+	}
+
+	return tags, nil
+}
+
+// Cloud: the most used tags with their number of published posts
+func (s *tagServiceImpl) Cloud(limit int) ([]models.Tag, error) {
+	if limit <= 0 || limit > cloudMax {
+		limit = cloudMax
+	}
+
+	repTag := repository.NewTagRepository()
+	return repTag.Cloud(limit)
+}
+
+// Rename: a name with the slug of another tag is refused, the two must be merged
+func (s *tagServiceImpl) Rename(id, name string) error {
+	if s.Kind != models.KindAdmin {
+		return domainErrors.ErrAdminOnly
+	}
+
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id", 36, id, true)
+	if err != nil {
+		return err
+	}
+	name, tagSlug, err := tagName(name)
+	if err != nil {
+		return err
+	}
+
+	repTag := repository.NewTagRepository()
+	tag, err := repTag.Find(IdVal.(string))
+	if err != nil {
+		return err
+	}
+
+	other, err := repTag.FindSlug(tagSlug)
+	if err == nil && other.TagID != tag.TagID {
+		return domainErrors.ErrTagTaken
+	}
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		return err
+	}
+
+	tag.Name = name
+	tag.Slug = tagSlug
+	return repTag.Update(tag)
+}
+
+// Merge: the posts of the tags from get the tag id, the tags from are removed
+func (s *tagServiceImpl) Merge(id string, from []string) error {
+	if s.Kind != models.KindAdmin {
+		return domainErrors.ErrAdminOnly
+	}
+
+	val := newValidator()
+	IdVal, err := val.CheckAnyData("id", 36, id, true)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(from))
+	seen := make(map[string]bool)
+	for _, v := range from {
+		fromVal, err := val.CheckAnyData("tags", 36, v, true)
+		if err != nil {
+			return err
+		}
+		fromID := fromVal.(string)
+		if fromID == IdVal.(string) {
+			return domainErrors.Validation("tags", messages.FieldInvalid, fmt.Errorf("tag %s merged into itself", fromID))
+		}
+		if !seen[fromID] {
+			seen[fromID] = true
+			ids = append(ids, fromID)
+		}
+	}
+	if len(ids) == 0 {
+		return domainErrors.Validation("tags", messages.FieldRequired, errors.New("no tags to merge"))
+	}
+
+	repTag := repository.NewTagRepository()
+	tag, err := repTag.Find(IdVal.(string))
+	if err != nil {
+		return err
+	}
+
+	return repTag.Merge(tag.TagID, ids)
+}
+
+func NewTagService(userID, kind string) tagServiceInterface {
+	return &tagServiceImpl{
+		UserID: userID,
+		Kind:   kind,
+	}
+}
@@ -9,21 +9,22 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/repository"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
-	"github.com/johnHPX/validator-hard/pkg/validator"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 )
 
 type userServiceInterface interface {
-	Store(name, telephone, nick, email, secret string) error
-	StoreADM(name, telephone, nick, email, secret, kind string) error
-	List(offset, limit, page int) ([]models.User, error)
+	Store(name, telephone, nick, email, secret, language string) error
+	StoreADM(name, telephone, nick, email, secret, kind, language string) error
+	List(p *pagination.Params) ([]models.User, *pagination.Page, error)
 	Count() (int, error)
-	ListName(name string, offset, limit, page int) ([]models.User, error)
+	ListName(name string, p *pagination.Params) ([]models.User, *pagination.Page, error)
 	CountName(name string) (int, error)
 	Find(id string) (*models.User, error)
-	Update(id, name, telefone, nick, email, kind string) error
+	Update(id, name, telefone, nick, email, kind, language string) error
 	Remove(id string) error
 	Login(emailOrNick, secret string) (string, error)
 	SendCodeGeneratedToEmail(email string) error
@@ -38,9 +39,9 @@ type userServiceImpl struct {
 	Kind   string
 }
 
-func (s *userServiceImpl) Store(name, telephone, nick, email, secret string) error {
+func (s *userServiceImpl) Store(name, telephone, nick, email, secret, language string) error {
 
-	val := validator.NewValidator()
+	val := newValidator()
 	Name, err := val.CheckAnyData("nome", 255, name, true)
 	if err != nil {
 		return err
@@ -61,6 +62,10 @@ func (s *userServiceImpl) Store(name, telephone, nick, email, secret string) err
 	if err != nil {
 		return err
 	}
+	Language, err := checkLanguage(language)
+	if err != nil {
+		return err
+	}
 
 	repUser := repository.NewUserRepository()
 	repPerson := repository.NewPersonRepository()
@@ -85,10 +90,11 @@ func (s *userServiceImpl) Store(name, telephone, nick, email, secret string) err
 			Name:      Name.(string),
 			Telephone: Telephone.(string),
 		},
-		Nick:   Nick.(string),
-		Email:  Email.(string),
-		Secret: Password,
-		Kind:   "user",
+		Nick:     Nick.(string),
+		Email:    Email.(string),
+		Secret:   Password,
+		Kind:     "user",
+		Language: Language,
 	}
 
 	err = repUser.Store(e)
@@ -104,13 +110,13 @@ func (s *userServiceImpl) Store(name, telephone, nick, email, secret string) err
 	return nil
 }
 
-func (s *userServiceImpl) StoreADM(name, telephone, nick, email, secret, kind string) error {
+func (s *userServiceImpl) StoreADM(name, telephone, nick, email, secret, kind, language string) error {
 
 	if s.Kind != "adm" {
-		return errors.New(messages.AdmMessage)
+		return domainErrors.ErrAdminOnly
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	Name, err := val.CheckAnyData("nome", 255, name, true)
 	if err != nil {
 		return err
@@ -135,6 +141,14 @@ func (s *userServiceImpl) StoreADM(name, telephone, nick, email, secret, kind st
 	if err != nil {
 		return err
 	}
+	KindVal, err = checkKind(KindVal.(string))
+	if err != nil {
+		return err
+	}
+	Language, err := checkLanguage(language)
+	if err != nil {
+		return err
+	}
 
 	repUser := repository.NewUserRepository()
 	repPerson := repository.NewPersonRepository()
@@ -159,10 +173,11 @@ func (s *userServiceImpl) StoreADM(name, telephone, nick, email, secret, kind st
 			Name:      Name.(string),
 			Telephone: Telephone.(string),
 		},
-		Nick:   Nick.(string),
-		Email:  Email.(string),
-		Secret: Password,
-		Kind:   KindVal.(string),
+		Nick:     Nick.(string),
+		Email:    Email.(string),
+		Secret:   Password,
+		Kind:     KindVal.(string),
+		Language: Language,
 	}
 
 	err = repUser.Store(e)
@@ -178,25 +193,29 @@ func (s *userServiceImpl) StoreADM(name, telephone, nick, email, secret, kind st
 	return nil
 }
 
-func (s *userServiceImpl) List(offset, limit, page int) ([]models.User, error) {
+func (s *userServiceImpl) List(p *pagination.Params) ([]models.User, *pagination.Page, error) {
 
 	if s.Kind != "adm" {
-		return nil, errors.New(messages.AdmMessage)
+		return nil, nil, domainErrors.ErrAdminOnly
 	}
 
 	repUser := repository.NewUserRepository()
-	entities, err := repUser.List(offset, limit, page)
+	entities, page, err := repUser.List(p)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	err = p.SetTotal(page, repUser.Count)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return entities, nil
+	return entities, page, nil
 }
 
 func (s *userServiceImpl) Count() (int, error) {
 
 	if s.Kind != "adm" {
-		return 0, errors.New(messages.AdmMessage)
+		return 0, domainErrors.ErrAdminOnly
 	}
 
 	repUser := repository.NewUserRepository()
@@ -207,25 +226,31 @@ func (s *userServiceImpl) Count() (int, error) {
 	return count, nil
 }
 
-func (s *userServiceImpl) ListName(name string, offset, limit, page int) ([]models.User, error) {
+func (s *userServiceImpl) ListName(name string, p *pagination.Params) ([]models.User, *pagination.Page, error) {
 
 	if s.Kind != "adm" {
-		return nil, errors.New(messages.AdmMessage)
+		return nil, nil, domainErrors.ErrAdminOnly
 	}
 
 	repUser := repository.NewUserRepository()
-	entities, err := repUser.ListName(name, offset, limit, page)
+	entities, page, err := repUser.ListName(name, p)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	err = p.SetTotal(page, func() (int, error) {
+		return repUser.CountListName(name)
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return entities, nil
+	return entities, page, nil
 }
 
 func (s *userServiceImpl) CountName(name string) (int, error) {
 
 	if s.Kind != "adm" {
-		return 0, errors.New(messages.AdmMessage)
+		return 0, domainErrors.ErrAdminOnly
 	}
 
 	repUser := repository.NewUserRepository()
@@ -239,7 +264,7 @@ func (s *userServiceImpl) CountName(name string) (int, error) {
 func (s *userServiceImpl) Find(id string) (*models.User, error) {
 
 	if s.UserID != id && s.Kind != "adm" {
-		return nil, errors.New(messages.AnotherUser)
+		return nil, domainErrors.ErrAnotherUser
 	}
 
 	repUser := repository.NewUserRepository()
@@ -251,13 +276,13 @@ func (s *userServiceImpl) Find(id string) (*models.User, error) {
 	return user, nil
 }
 
-func (s *userServiceImpl) Update(id, name, telefone, nick, email, kind string) error {
+func (s *userServiceImpl) Update(id, name, telefone, nick, email, kind, language string) error {
 
 	if s.UserID != id && s.Kind != "adm" {
-		return errors.New(messages.AnotherUser)
+		return domainErrors.ErrAnotherUser
 	}
 
-	val := validator.NewValidator()
+	val := newValidator()
 	NameVal, err := val.CheckAnyData("nome", 255, name, true)
 	if err != nil {
 		return err
@@ -279,10 +304,6 @@ func (s *userServiceImpl) Update(id, name, telefone, nick, email, kind string) e
 		return err
 	}
 
-	if s.Kind != "adm" {
-		KindVal = "user"
-	}
-
 	// repositorys
 	repUser := repository.NewUserRepository()
 	repPerson := repository.NewPersonRepository()
@@ -293,6 +314,16 @@ func (s *userServiceImpl) Update(id, name, telefone, nick, email, kind string) e
 		return err
 	}
 
+	// only the admins change the kinds, the others keep their own
+	if s.Kind != "adm" {
+		KindVal = user.Kind
+	} else {
+		KindVal, err = checkKind(KindVal.(string))
+		if err != nil {
+			return err
+		}
+	}
+
 	// Update person
 	person := new(models.Person)
 	person.PersonID = user.PersonID
@@ -309,6 +340,13 @@ func (s *userServiceImpl) Update(id, name, telefone, nick, email, kind string) e
 	userEntity.Nick = NickVal.(string)
 	userEntity.Email = EmailVal.(string)
 	userEntity.Kind = KindVal.(string)
+	userEntity.Language = user.Language
+	if language != "" {
+		userEntity.Language, err = checkLanguage(language)
+		if err != nil {
+			return err
+		}
+	}
 	err = repUser.Update(userEntity)
 	if err != nil {
 		return err
@@ -320,7 +358,7 @@ func (s *userServiceImpl) Update(id, name, telefone, nick, email, kind string) e
 func (s *userServiceImpl) Remove(id string) error {
 
 	if s.UserID != id && s.Kind != "adm" {
-		return errors.New(messages.AnotherUser)
+		return domainErrors.ErrAnotherUser
 	}
 
 	// repositorys
@@ -349,7 +387,7 @@ func (s *userServiceImpl) Remove(id string) error {
 }
 
 func (s *userServiceImpl) Login(emailOrNick, secret string) (string, error) {
-	val := validator.NewValidator()
+	val := newValidator()
 	EmailOrNickVal, err := val.CheckAnyData("email ou nick", 255, emailOrNick, true)
 	if err != nil {
 		return "", err
@@ -361,6 +399,10 @@ func (s *userServiceImpl) Login(emailOrNick, secret string) (string, error) {
 	// finding user by email or nick
 	user, err := repUser.FindByEmailOrNick(EmailOrNickVal.(string))
 	if err != nil {
+		// the login doesn't tell if it was the user or the password that didn't match
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			return "", domainErrors.ErrInvalidCredentials
+		}
 		return "", err
 	}
 
@@ -372,7 +414,7 @@ func (s *userServiceImpl) Login(emailOrNick, secret string) (string, error) {
 
 	// create a token for this user
 	svcAccess := NewAccessService()
-	atoken, err := svcAccess.CreateAToken(user.UserID, user.Kind)
+	atoken, err := svcAccess.CreateAToken(user.UserID, user.Kind, user.Language)
 	if err != nil {
 		return "", err
 	}
@@ -391,7 +433,7 @@ func (s *userServiceImpl) Login(emailOrNick, secret string) (string, error) {
 
 		// verific if was blocked
 		if accessEntity.IsBlocked {
-			return "", errors.New(messages.UserBlocked)
+			return "", domainErrors.ErrUserBlocked
 		}
 
 		// remove a old rtoken
@@ -412,7 +454,7 @@ func (s *userServiceImpl) Login(emailOrNick, secret string) (string, error) {
 
 func (s *userServiceImpl) SendCodeGeneratedToEmail(email string) error {
 	// valide email
-	val := validator.NewValidator()
+	val := newValidator()
 	emailVal, err := val.CheckAnyData("email", 255, email, true)
 	if err != nil {
 		return err
@@ -451,21 +493,25 @@ func (s *userServiceImpl) SendCodeGeneratedToEmail(email string) error {
 	}
 
 	// configuring the email to send
+	// the email is written in the user's language
 	var template = fmt.Sprintf(`
 		<html>
 			<head>
 				<meta http-equiv="Content-Type" content="text/html; charset=utf-8" />
-				<title>Código de Recuperação!</title>
+				<title>%s</title>
 			</head>
 			<body>
-				<p>Olá %s, aqui está o seu código de recuperação de senha: <mark>%s</mark></p>
+				<p>%s</p>
 			</body>
 		<html/>
-	`, userEntity.Name, generatedCode)
+	`,
+		messages.Translate(userEntity.Language, messages.EmailRecoveryTitle),
+		messages.Translate(userEntity.Language, messages.EmailRecoveryBody, userEntity.Name, generatedCode),
+	)
 
 	// send email
 	systemService := NewSystemService()
-	err = systemService.SendEmail(template, userEntity.Email, "Seu código de Verificação!")
+	err = systemService.SendEmail(template, userEntity.Email, messages.Translate(userEntity.Language, messages.EmailRecoverySubject))
 	if err != nil {
 		return err
 	}
@@ -486,7 +532,7 @@ func (s *userServiceImpl) SendCodeGeneratedToEmail(email string) error {
 
 func (s *userServiceImpl) VerificCode(code string) (string, error) {
 	// valide camp
-	val := validator.NewValidator()
+	val := newValidator()
 	codeVal, err := val.CheckAnyData("código", 6, code, true)
 	if err != nil {
 		return "", err
@@ -513,7 +559,7 @@ func (s *userServiceImpl) VerificCode(code string) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		return "", errors.New("Seu código está expirado!")
+		return "", domainErrors.ErrCodeExpired
 	}
 
 	// generated token for recovery password
@@ -534,7 +580,7 @@ func (s *userServiceImpl) VerificCode(code string) (string, error) {
 }
 
 func (s *userServiceImpl) SecretRecovery(newSecret string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 	newSecretVal, err := val.CheckPassword(255, newSecret, "", "create")
 	if err != nil {
 		return err
@@ -550,7 +596,7 @@ func (s *userServiceImpl) SecretRecovery(newSecret string) error {
 }
 
 func (s *userServiceImpl) SecretUpdate(oldSecret, newSecret string) error {
-	val := validator.NewValidator()
+	val := newValidator()
 	newSecretVal, err := val.CheckPassword(255, newSecret, "", "create")
 	if err != nil {
 		return err
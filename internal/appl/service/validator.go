@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/validator-hard/pkg/validator"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// validatorImpl: validator-hard with its errors converted to the catalogue's errors
+type validatorImpl struct {
+	val validator.Validator
+}
+
+// rule: validator-hard only answers in portuguese, the rule is recovered from its message to be translated
+func rule(err error) messages.Key {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "obrigatório"):
+		return messages.FieldRequired
+	case strings.Contains(msg, "tamanho"):
+		return messages.FieldTooLong
+	case strings.Contains(msg, "e-mail"):
+		return messages.FieldEmail
+	}
+	return messages.FieldInvalid
+}
+
+func (v *validatorImpl) CheckAnyData(nameData string, sizeData int, data interface{}, required bool) (interface{}, error) {
+	value, err := v.val.CheckAnyData(nameData, sizeData, data, required)
+	if err != nil {
+		return nil, domainErrors.Validation(nameData, rule(err), err)
+	}
+	return value, nil
+}
+
+func (v *validatorImpl) CheckPassword(sizePassword int, passawordData, hashPassword, operation string) (string, error) {
+	hash, err := v.val.CheckPassword(sizePassword, passawordData, hashPassword, operation)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) || errors.Is(err, bcrypt.ErrHashTooShort) {
+			return "", domainErrors.ErrInvalidCredentials.Wrap(err)
+		}
+		return "", domainErrors.Validation("senha", rule(err), err)
+	}
+	return hash, nil
+}
+
+// checkLanguage: the bundle of the language chosen by the user, empty means the default language
+func checkLanguage(language string) (string, error) {
+	if language == "" {
+		return messages.DefaultLanguage, nil
+	}
+	lang, ok := messages.Supported(language)
+	if !ok {
+		return "", domainErrors.Validation("idioma", messages.FieldInvalid, errors.New(language))
+	}
+	return lang, nil
+}
+
+// checkKind: the kinds a user can have, see the kinds of the models
+func checkKind(kind string) (string, error) {
+	switch kind {
+	case models.KindUser, models.KindAuthor, models.KindEditor, models.KindAdmin:
+		return kind, nil
+	}
+	return "", domainErrors.Validation("kind", messages.FieldInvalid, errors.New(kind))
+}
+
+// checkURL: an absolute http or https url, empty is accepted
+func checkURL(field, value string) error {
+	if value == "" {
+		return nil
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return domainErrors.Validation(field, messages.FieldInvalid, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domainErrors.Validation(field, messages.FieldInvalid, errors.New(value))
+	}
+	return nil
+}
+
+func newValidator() validator.Validator {
+	return &validatorImpl{
+		val: validator.NewValidator(),
+	}
+}
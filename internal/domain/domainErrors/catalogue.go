@@ -0,0 +1,89 @@
+package domainErrors
+
+//go:generate go run ../../../cmd/errdocs -out ../../../docs/errors.md
+
+import (
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+)
+
+// Entry: error of the catalogue, the code never changes once published
+type Entry struct {
+	Code        string
+	Kind        Kind
+	Description string
+}
+
+// Catalogue: every error the api can answer, docs/errors.md is generated from it
+var Catalogue = make([]Entry, 0)
+
+func define(code string, kind Kind, message messages.Key, description string) *Error {
+	Catalogue = append(Catalogue, Entry{
+		Code:        code,
+		Kind:        kind,
+		Description: description,
+	})
+	return &Error{
+		Code:    code,
+		Kind:    kind,
+		Message: message,
+	}
+}
+
+var (
+	ErrInvalidRequest = define("invalid_request", KindBadRequest,
+		messages.InvalidRequest, "O corpo ou os parâmetros da requisição não puderam ser lidos.")
+	ErrValidation = define("validation_failed", KindValidation,
+		messages.ValidationFailed, "Algum campo não passou na validação, o atributo `errors` lista os campos e o motivo.")
+	ErrCodeExpired = define("recovery_code_expired", KindBadRequest,
+		messages.CodeExpired, "O código de recuperação de senha passou do prazo de validade.")
+	ErrInvalidToken = define("invalid_token", KindUnauthorized,
+		messages.InvalideToken, "O token de acesso está ausente, é inválido ou expirou sem um token de refresh válido.")
+	ErrTokenBlocked = define("token_blocked", KindUnauthorized,
+		messages.TokenBlocked, "O token de refresh do usuário foi bloqueado.")
+	ErrInvalidCredentials = define("invalid_credentials", KindUnauthorized,
+		messages.InvalidCredentials, "O email, nick ou senha informados não conferem.")
+	ErrAdminOnly = define("admin_only", KindForbidden,
+		messages.AdmMessage, "A funcionalidade só é permitida a administradores.")
+	ErrRoleRequired = define("role_required", KindForbidden,
+		messages.RoleRequired, "O papel do usuário (autor, editor ou administrador) não permite a funcionalidade, por exemplo um leitor criando uma postagem.")
+	ErrAnotherUser = define("another_user", KindForbidden,
+		messages.AnotherUser, "O usuário tentou manipular dados de outro usuário.")
+	ErrUserBlocked = define("user_blocked", KindForbidden,
+		messages.UserBlocked, "O usuário foi bloqueado e não pode se autenticar.")
+	ErrNotFound = define("not_found", KindNotFound,
+		messages.FindError, "O recurso não existe ou foi removido.")
+	ErrUserNotFound = define("user_not_found", KindNotFound,
+		messages.UserNotExists, "Nenhum usuário tem o email ou nick informado.")
+	ErrMethodNotAllowed = define("method_not_allowed", KindMethodNotAllowed,
+		messages.MethodNotAllowed, "A rota existe, mas não aceita o método http usado.")
+	ErrEmailTaken = define("email_taken", KindConflict,
+		messages.EmailIsRegister, "O email já pertence a outra conta.")
+	ErrNickTaken = define("nick_taken", KindConflict,
+		messages.NickIsRegister, "O nick já pertence a outra conta.")
+	ErrAlreadyLiked = define("already_liked", KindConflict,
+		messages.LikePost, "O usuário já curtiu a postagem.")
+	ErrNotLiked = define("not_liked", KindConflict,
+		messages.DeslikePost, "O usuário tentou descurtir uma postagem que não curtiu.")
+	ErrAlreadyLinked = define("already_linked", KindConflict,
+		messages.AlreadyLinked, "A postagem já está vinculada à categoria.")
+	ErrInvalidTransition = define("invalid_transition", KindConflict,
+		messages.InvalidTransition, "A ação não é permitida no status atual da postagem (por exemplo publicar uma postagem arquivada).")
+	ErrTagTaken = define("tag_taken", KindConflict,
+		messages.TagTaken, "O novo nome da tag tem o mesmo slug de outra tag, as duas devem ser juntadas.")
+	ErrCategoryCycle = define("category_cycle", KindConflict,
+		messages.CategoryCycle, "A categoria pai escolhida é a própria categoria ou uma das suas descendentes, a árvore teria um ciclo.")
+	ErrCategorySlugTaken = define("category_slug_taken", KindConflict,
+		messages.CategorySlugTaken, "O slug enviado já é de outra categoria.")
+	ErrPostInSeries = define("post_in_series", KindConflict,
+		messages.PostInSeries, "Uma das postagens já é parte de outra série, ela deve ser tirada de lá antes.")
+	ErrMediaTooLarge = define("media_too_large", KindTooLarge,
+		messages.MediaTooLarge, "O arquivo enviado excede o tamanho máximo de `media.max_size`.")
+	ErrMediaType = define("media_type_unsupported", KindUnsupportedMedia,
+		messages.MediaType, "O conteúdo do arquivo não é uma imagem aceita, o tipo é detectado pelos bytes e não pelo nome ou header.")
+	ErrTooManyRequests = define("too_many_requests", KindTooManyRequests,
+		messages.TooManyRequests, "O limite de requisições da rota foi excedido, veja o header `Retry-After`.")
+	ErrStoreFailed = define("store_failed", KindInternal,
+		messages.StoreError, "O registro não pôde ser criado.")
+	ErrInternal = define("internal", KindInternal,
+		messages.InternalError, "Um erro inesperado aconteceu no servidor.")
+)
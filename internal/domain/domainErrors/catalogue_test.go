@@ -0,0 +1,45 @@
+package domainErrors
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+)
+
+func TestCatalogue(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		codes := make(map[string]bool)
+		for _, v := range Catalogue {
+			if codes[v.Code] {
+				t.Errorf("código %s repetido", v.Code)
+			}
+			codes[v.Code] = true
+
+			for _, lang := range []string{messages.PtBR, messages.En} {
+				if messages.Translate(lang, messages.Title(v.Code)) == string(messages.Title(v.Code)) {
+					t.Errorf("código %s sem título em %s", v.Code, lang)
+				}
+			}
+		}
+
+		err := ErrNotFound.WithMessage(messages.UpdateError)
+		if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrUserNotFound) {
+			t.Error("errors.Is deveria comparar pelo código")
+		}
+		if Validation("email", messages.FieldEmail, errors.New("invalido")).Status() != 422 {
+			t.Error("validação deveria ser 422")
+		}
+	})
+
+	t.Run("documentação atualizada", func(t *testing.T) {
+		doc, err := os.ReadFile("../../../docs/errors.md")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(doc) != Markdown() {
+			t.Error("docs/errors.md desatualizado, rode go generate ./internal/domain/domainErrors")
+		}
+	})
+}
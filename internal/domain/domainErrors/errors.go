@@ -0,0 +1,124 @@
+package domainErrors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+)
+
+// Kind: class of the error, each kind is answered with one http status
+type Kind string
+
+const (
+	KindBadRequest       Kind = "bad_request"
+	KindValidation       Kind = "validation"
+	KindUnauthorized     Kind = "unauthorized"
+	KindForbidden        Kind = "forbidden"
+	KindNotFound         Kind = "not_found"
+	KindMethodNotAllowed Kind = "method_not_allowed"
+	KindConflict         Kind = "conflict"
+	KindTooLarge         Kind = "too_large"
+	KindUnsupportedMedia Kind = "unsupported_media"
+	KindTooManyRequests  Kind = "too_many_requests"
+	KindInternal         Kind = "internal"
+)
+
+var statusByKind = map[Kind]int{
+	KindBadRequest:       http.StatusBadRequest,
+	KindValidation:       http.StatusUnprocessableEntity,
+	KindUnauthorized:     http.StatusUnauthorized,
+	KindForbidden:        http.StatusForbidden,
+	KindNotFound:         http.StatusNotFound,
+	KindMethodNotAllowed: http.StatusMethodNotAllowed,
+	KindConflict:         http.StatusConflict,
+	KindTooLarge:         http.StatusRequestEntityTooLarge,
+	KindUnsupportedMedia: http.StatusUnsupportedMediaType,
+	KindTooManyRequests:  http.StatusTooManyRequests,
+	KindInternal:         http.StatusInternalServerError,
+}
+
+// Status: http status of the kind
+func (k Kind) Status() int {
+	status, ok := statusByKind[k]
+	if !ok {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
+// FieldError: a field that failed the validation, translated when the response is written
+type FieldError struct {
+	Field string
+	Rule  messages.Key
+}
+
+// Error: error with a stable code from the catalogue, returned by services and repositories
+type Error struct {
+	Code    string
+	Kind    Kind
+	Message messages.Key
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	message := messages.Translate(messages.DefaultLanguage, e.Message)
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is: errors with the same code are equal, so errors.Is works with the catalogue's errors
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Status: http status of the error
+func (e *Error) Status() int {
+	return e.Kind.Status()
+}
+
+// Wrap: copy of the error carrying its cause
+func (e *Error) Wrap(err error) *Error {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// WithMessage: copy of the error with another message
+func (e *Error) WithMessage(message messages.Key) *Error {
+	c := *e
+	c.Message = message
+	return &c
+}
+
+// Validation: a field didn't pass the rule, err is the cause kept for the logs
+func Validation(field string, rule messages.Key, err error) *Error {
+	return &Error{
+		Code:    ErrValidation.Code,
+		Kind:    ErrValidation.Kind,
+		Message: ErrValidation.Message,
+		Fields: []FieldError{
+			{
+				Field: field,
+				Rule:  rule,
+			},
+		},
+		Err: err,
+	}
+}
+
+// Unauthorized: wraps an error of the token validation
+func Unauthorized(err error) *Error {
+	if e, ok := err.(*Error); ok && e.Kind == KindUnauthorized {
+		return e
+	}
+	return ErrInvalidToken.Wrap(err)
+}
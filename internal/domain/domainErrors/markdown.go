@@ -0,0 +1,55 @@
+package domainErrors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+)
+
+// Markdown: the errors catalogue as documentation, written to docs/errors.md by cmd/errdocs
+func Markdown() string {
+	var b strings.Builder
+
+	b.WriteString("<!-- Gerado por `go run ./cmd/errdocs`, não edite à mão. -->\n\n")
+	b.WriteString("# Estrutura de erros\n\n")
+	b.WriteString("Quando algum endpoint estiver com erros, a API devolvera o corpo abaixo (RFC 7807), ")
+	b.WriteString("com o header `Content-Type: application/problem+json`.\n\n")
+	b.WriteString("```json\n")
+	b.WriteString("{\n")
+	b.WriteString("  \"type\": \"https://github.com/johnHPX/blog-hard-backend/blob/main/docs/errors.md#not_found\",\n")
+	b.WriteString("  \"title\": \"Não encontrado\",\n")
+	b.WriteString("  \"status\": 404,\n")
+	b.WriteString("  \"detail\": \"Não foi possivel achar\",\n")
+	b.WriteString("  \"instance\": \"/api/v1/posts/6a1f...\",\n")
+	b.WriteString("  \"code\": \"not_found\",\n")
+	b.WriteString("  \"mid\": \"na\"\n")
+	b.WriteString("}\n")
+	b.WriteString("```\n\n")
+
+	b.WriteString("| Atribute Name | Type Value | Description |\n")
+	b.WriteString("| ------------- | ---------- | ----------- |\n")
+	b.WriteString("| `type`        | `string`   | `link para o código nesta página` |\n")
+	b.WriteString("| `title`       | `string`   | `título do código` |\n")
+	b.WriteString("| `status`      | `int`      | `codigo do status` |\n")
+	b.WriteString("| `detail`      | `string`   | `messagem de erro` |\n")
+	b.WriteString("| `instance`    | `string`   | `rota da requisição` |\n")
+	b.WriteString("| `code`        | `string`   | `codigo da API, estável entre versões` |\n")
+	b.WriteString("| `mid`         | `string`   | `messagem de verificação` |\n")
+	b.WriteString("| `errors`      | `array`    | `campos inválidos, só em validation_failed`         |\n\n")
+
+	b.WriteString("## Códigos\n\n")
+	b.WriteString("| Code | Status | Title |\n")
+	b.WriteString("| ---- | ------ | ----- |\n")
+	for _, v := range Catalogue {
+		fmt.Fprintf(&b, "| [`%s`](#%s) | %d | %s |\n", v.Code, v.Code, v.Kind.Status(), messages.Translate(messages.PtBR, messages.Title(v.Code)))
+	}
+
+	for _, v := range Catalogue {
+		fmt.Fprintf(&b, "\n### %s\n\n", v.Code)
+		fmt.Fprintf(&b, "**%d %s**\n\n", v.Kind.Status(), messages.Translate(messages.PtBR, messages.Title(v.Code)))
+		fmt.Fprintf(&b, "%s\n", v.Description)
+	}
+
+	return b.String()
+}
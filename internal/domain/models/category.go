@@ -1,6 +1,25 @@
 package models
 
+import "time"
+
+// CategoryDepth: the deepest level of the categories' tree, a root is the level 1
+const CategoryDepth = 16
+
+// Category: ParentID is empty for the root categories, Slug is kept across the renames
 type Category struct {
-	CategoryID string
-	Name       string
+	CategoryID  string
+	Name        string
+	Slug        string
+	Description string
+	ParentID    string
+	CoverID     string
+	Cover       *Media
+	Posts       int
+	CreatedAt   time.Time
+}
+
+// CategoryNode: a category of the tree with its children in alphabetical order
+type CategoryNode struct {
+	Category
+	Children []CategoryNode
 }
@@ -1,9 +1,13 @@
 package models
 
+import "time"
+
 type Comment struct {
-	CommentID string
-	Title     string
-	Content   string
-	UserID    string
-	PostID    string
+	CommentID   string
+	Title       string
+	Content     string
+	ContentHTML string
+	UserID      string
+	PostID      string
+	CreatedAt   time.Time
 }
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// MediaPath: where the api serves the files, followed by the media's Key
+const MediaPath = "/media/"
+
+// Media: an uploaded file, kept once per content (Hash) in the storage under Key
+type Media struct {
+	MediaID   string
+	UserID    string
+	Name      string
+	Hash      string
+	MIME      string
+	Size      int64
+	Width     int
+	Height    int
+	Key       string
+	ThumbKey  string
+	CreatedAt time.Time
+}
@@ -1,8 +1,82 @@
 package models
 
+import "time"
+
+// statuses of a post, only the published ones are public
+const (
+	PostDraft     = "draft"
+	PostInReview  = "in_review"
+	PostScheduled = "scheduled"
+	PostPublished = "published"
+	PostArchived  = "archived"
+)
+
 type Post struct {
-	PostID  string
-	Title   string
-	Content string
-	Likes   int
+	PostID      string
+	Title       string
+	Slug        string
+	Content     string
+	ContentHTML string
+	Excerpt     string
+	Summary     PostSummary
+	Likes       int
+	Views       int
+	Comments    int
+	Categories  []string
+	Breadcrumbs [][]Category
+	Tags        []Tag
+	Series      *PostSeries
+	UserID      string
+	AuthorNick  string
+	AuthorName  string
+	Status      string
+	PublishedAt *time.Time
+	PublishAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	CoverID     string
+	Cover       *Media
+	SEO         PostSEO
+}
+
+// twitter cards of a post, the large one shows the cover above the title
+const (
+	CardSummary      = "summary"
+	CardSummaryLarge = "summary_large_image"
+)
+
+// PostSEO: metadata of the post's page, the empty fields fall back to the title, the excerpt and the cover
+type PostSEO struct {
+	MetaDescription string
+	CanonicalURL    string
+	OGTitle         string
+	OGDescription   string
+	OGImage         string
+	TwitterCard     string
+}
+
+// PostHeading: an entry of the table of contents of a post, ID is the anchor of the heading
+type PostHeading struct {
+	Level int
+	Text  string
+	ID    string
+}
+
+// PostSummary: read from the content when the post is saved
+type PostSummary struct {
+	Excerpt     string
+	Words       int
+	ReadingTime int
+	TOC         []PostHeading
+}
+
+// PostFilter: filters of the post lists, the empty ones aren't applied
+type PostFilter struct {
+	Categories []string
+	From       *time.Time
+	To         *time.Time
+	Author     string
+	Tag        string
+	Status     string
+	UserID     string
 }
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// PostRevision: a saved version of a post, Number counts the versions of the post from 1
+type PostRevision struct {
+	RevisionID   string
+	PostID       string
+	Number       int
+	Title        string
+	Content      string
+	UserID       string
+	RestoredFrom int
+	CreatedAt    time.Time
+}
+
+// DiffLine: a line of the diff of two revisions, Op is equal, insert or delete
+type DiffLine struct {
+	Op   string
+	Text string
+}
+
+// PostRevisionDiff: the changes from the revision From to the revision To
+type PostRevisionDiff struct {
+	From    *PostRevision
+	To      *PostRevision
+	Title   []DiffLine
+	Content []DiffLine
+}
@@ -1,9 +1,13 @@
 package models
 
+import "time"
+
 type ResponseComment struct {
 	ResponseCommentID string
 	Title             string
 	Content           string
+	ContentHTML       string
 	CommentID         string
 	UserID            string
+	CreatedAt         time.Time
 }
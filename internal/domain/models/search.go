@@ -0,0 +1,17 @@
+package models
+
+// SearchPost: a post found by the search, the headlines have the matched words marked
+type SearchPost struct {
+	Post
+	Rank            float64
+	TitleHeadline   string
+	ContentHeadline string
+	Comments        []SearchComment
+}
+
+// SearchComment: a comment found by the search
+type SearchComment struct {
+	Comment
+	Rank            float64
+	ContentHeadline string
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Series: posts published in parts, in the order of Parts. Posts is the number of published parts
+type Series struct {
+	SeriesID    string
+	Title       string
+	Slug        string
+	Description string
+	UserID      string
+	Posts       int
+	Parts       []SeriesPart
+	CreatedAt   time.Time
+}
+
+// SeriesPart: a published post of a series, Position starts at 1
+type SeriesPart struct {
+	PostID      string
+	Title       string
+	Slug        string
+	Position    int
+	PublishedAt *time.Time
+}
+
+// PostSeries: where a post is in its series, Previous and Next are nil at the ends
+type PostSeries struct {
+	SeriesID string
+	Title    string
+	Slug     string
+	Position int
+	Parts    int
+	Previous *SeriesPart
+	Next     *SeriesPart
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Tag: a free label of the posts, Slug is its identity, the same for any case and accents of the Name
+type Tag struct {
+	TagID     string
+	Name      string
+	Slug      string
+	Posts     int
+	CreatedAt time.Time
+}
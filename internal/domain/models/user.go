@@ -1,10 +1,22 @@
 package models
 
+import "time"
+
+// kinds of the users: the authors write their own posts, the editors and the admins edit all of them
+const (
+	KindUser   = "user"
+	KindAuthor = "author"
+	KindEditor = "editor"
+	KindAdmin  = "adm"
+)
+
 type User struct {
-	UserID string
-	Nick   string
-	Email  string
-	Secret string
-	Kind   string
+	UserID    string
+	Nick      string
+	Email     string
+	Secret    string
+	Kind      string
+	Language  string
+	CreatedAt time.Time
 	Person
 }
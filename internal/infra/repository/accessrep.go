@@ -2,9 +2,9 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
 	"time"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
@@ -89,7 +89,7 @@ func (r *accessRepositoryImpl) Store(token, userID string, expired time.Time) er
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
@@ -124,7 +124,7 @@ func (r *accessRepositoryImpl) BlockAcess(userID string, block bool) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.BlockError)
+		return domainErrors.ErrNotFound.WithMessage(messages.BlockError)
 	}
 
 	return nil
@@ -161,7 +161,7 @@ func (r *accessRepositoryImpl) FindToken(userID string) (*models.Access, error)
 		return access, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
 
 }
 
@@ -195,7 +195,7 @@ func (r *accessRepositoryImpl) UpdateToken(rtoken, userID string) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -231,7 +231,7 @@ func (r *accessRepositoryImpl) RemoveToken(userID string) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
@@ -2,34 +2,90 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
 	"fmt"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/slug"
+	"github.com/lib/pq"
 )
 
 type categoryRepositoryInterface interface {
 	Store(entity *models.Category) error
-	List(offset, limit, page int) ([]models.Category, error)
+	List(p *pagination.Params) ([]models.Category, *pagination.Page, error)
 	Count() (int, error)
-	ListPost(postID string, offset, limit, page int) ([]models.Category, error)
+	ListPost(postID string, p *pagination.Params) ([]models.Category, *pagination.Page, error)
 	CountPost(postID string) (int, error)
+	NamesPost(postID string) ([]string, error)
 	Find(categoryID string) (*models.Category, error)
+	FindSlug(slug string) (*models.Category, error)
+	FreeSlug(base, categoryID string) (string, error)
 	Update(entity *models.Category) error
 	Remove(categoryID string) error
+	All() ([]models.Category, error)
+	Breadcrumbs(postID string) ([][]models.Category, error)
+	Merge(categoryID string, from []string) error
 }
 
 type categoryRepositoryImpl struct{}
 
+// categoryColumns: the columns read by scanIterator, selected from tb_category c with categoryJoins
+const categoryColumns = `
+		c.id,
+		c.name,
+		c.parent_id,
+		c.created_at,
+		c.slug,
+		c.description,
+		c.cover_mid,
+		cm.file_key,
+		cm.thumb_key,
+		cm.width,
+		cm.height,
+		COALESCE(cp.posts, 0)`
+
+// categoryJoins: the cover, a removed media leaves the category without it, and the published posts of
+// every category counted by a single aggregate
+const categoryJoins = `
+	LEFT JOIN tb_media cm ON cm.id = c.cover_mid and cm.deleted_at is null
+	LEFT JOIN (
+		SELECT pc.category_cid, COUNT(*) AS posts
+		FROM tb_post_category pc
+		INNER JOIN tb_post p ON p.id = pc.post_pid
+		WHERE pc.deleted_at is null and p.deleted_at is null and p.status = 'published'
+		GROUP BY pc.category_cid
+	) cp ON cp.category_cid = c.id`
+
 func (r *categoryRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Category, error) {
 	categoryID := sql.NullString{}
 	name := sql.NullString{}
+	parentID := sql.NullString{}
+	createdAt := sql.NullTime{}
+	categorySlug := sql.NullString{}
+	description := sql.NullString{}
+	coverID := sql.NullString{}
+	coverKey := sql.NullString{}
+	coverThumbKey := sql.NullString{}
+	coverWidth := sql.NullInt64{}
+	coverHeight := sql.NullInt64{}
+	posts := sql.NullInt64{}
 
 	err := rows.Scan(
 		&categoryID,
 		&name,
+		&parentID,
+		&createdAt,
+		&categorySlug,
+		&description,
+		&coverID,
+		&coverKey,
+		&coverThumbKey,
+		&coverWidth,
+		&coverHeight,
+		&posts,
 	)
 
 	if err != nil {
@@ -46,9 +102,48 @@ func (r *categoryRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Category,
 		categoryEntity.Name = name.String
 	}
 
+	if parentID.Valid {
+		categoryEntity.ParentID = parentID.String
+	}
+
+	if createdAt.Valid {
+		categoryEntity.CreatedAt = createdAt.Time
+	}
+
+	if categorySlug.Valid {
+		categoryEntity.Slug = categorySlug.String
+	}
+
+	if description.Valid {
+		categoryEntity.Description = description.String
+	}
+
+	if coverID.Valid {
+		categoryEntity.CoverID = coverID.String
+	}
+
+	if coverKey.Valid {
+		categoryEntity.Cover = &models.Media{
+			MediaID:  categoryEntity.CoverID,
+			Key:      coverKey.String,
+			ThumbKey: coverThumbKey.String,
+			Width:    int(coverWidth.Int64),
+			Height:   int(coverHeight.Int64),
+		}
+	}
+
+	if posts.Valid {
+		categoryEntity.Posts = int(posts.Int64)
+	}
+
 	return categoryEntity, nil
 }
 
+// categoryKey: position of a category in the lists' order
+func categoryKey(category models.Category) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: category.CreatedAt, ID: category.CategoryID}
+}
+
 func (r *categoryRepositoryImpl) Store(entity *models.Category) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
@@ -58,9 +153,9 @@ func (r *categoryRepositoryImpl) Store(entity *models.Category) error {
 
 	sqlText := `
 		insert into tb_category
-		(id, name)
+		(id, name, parent_id, slug, description, cover_mid)
 		values
-		($1,$2)
+		($1,$2,$3,$4,$5,$6)
 	`
 
 	stmt, err := db.Prepare(sqlText)
@@ -68,7 +163,7 @@ func (r *categoryRepositoryImpl) Store(entity *models.Category) error {
 		return err
 	}
 
-	result, err := stmt.Exec(entity.CategoryID, entity.Name)
+	result, err := stmt.Exec(entity.CategoryID, entity.Name, sql.NullString{String: entity.ParentID, Valid: entity.ParentID != ""}, entity.Slug, sql.NullString{String: entity.Description, Valid: entity.Description != ""}, sql.NullString{String: entity.CoverID, Valid: entity.CoverID != ""})
 	if err != nil {
 		return err
 	}
@@ -80,31 +175,28 @@ func (r *categoryRepositoryImpl) Store(entity *models.Category) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
 }
 
-func (r *categoryRepositoryImpl) List(offset, limit, page int) ([]models.Category, error) {
+func (r *categoryRepositoryImpl) List(p *pagination.Params) ([]models.Category, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
-	SELECT 
-		id,
-		name
-	FROM tb_category
-	WHERE deleted_at is null
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	sqlText, args := p.Query(`
+	SELECT `+categoryColumns+`
+	FROM tb_category c
+	`+categoryJoins+`
+	WHERE c.deleted_at is null`, "c.created_at", "c.id", nil)
 
-	rows, err := db.Query(sqlText)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -112,12 +204,13 @@ func (r *categoryRepositoryImpl) List(offset, limit, page int) ([]models.Categor
 	for rows.Next() {
 		category, err := r.scanIterator(rows)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		categorys = append(categorys, *category)
 	}
 
-	return categorys, nil
+	categorys, page := pagination.Slice(p, categorys, categoryKey)
+	return categorys, page, nil
 }
 
 func (r *categoryRepositoryImpl) Count() (int, error) {
@@ -144,28 +237,25 @@ func (r *categoryRepositoryImpl) Count() (int, error) {
 	return count, nil
 }
 
-func (r *categoryRepositoryImpl) ListPost(postID string, offset, limit, page int) ([]models.Category, error) {
+func (r *categoryRepositoryImpl) ListPost(postID string, p *pagination.Params) ([]models.Category, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
-	SELECT 
-		c.id,
-		c.name
+	sqlText, args := p.Query(`
+	SELECT `+categoryColumns+`
 	FROM tb_category c
+	`+categoryJoins+`
 	INNER JOIN tb_post_category pc ON pc.category_cid = c.id
 	INNER JOIN tb_post p ON p.id = pc.post_pid
 	WHERE p.deleted_at is null and pc.deleted_at is null and c.deleted_at is null
-	 and p.id = $1
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	 and p.id = $1`, "c.created_at", "c.id", []interface{}{postID})
 
-	rows, err := db.Query(sqlText, postID)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -173,12 +263,13 @@ func (r *categoryRepositoryImpl) ListPost(postID string, offset, limit, page int
 	for rows.Next() {
 		category, err := r.scanIterator(rows)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		categorys = append(categorys, *category)
 	}
 
-	return categorys, nil
+	categorys, page := pagination.Slice(p, categorys, categoryKey)
+	return categorys, page, nil
 }
 
 func (r *categoryRepositoryImpl) CountPost(postID string) (int, error) {
@@ -208,7 +299,8 @@ func (r *categoryRepositoryImpl) CountPost(postID string) (int, error) {
 	return count, nil
 }
 
-func (r *categoryRepositoryImpl) Find(categoryID string) (*models.Category, error) {
+// NamesPost: names of all the categories of the post, in alphabetical order
+func (r *categoryRepositoryImpl) NamesPost(postID string) ([]string, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
 		return nil, err
@@ -217,16 +309,51 @@ func (r *categoryRepositoryImpl) Find(categoryID string) (*models.Category, erro
 
 	sqlText := `
 		SELECT 
-			id, 
-			name
-		FROM tb_category
-		WHERE deleted_at is null and id = $1
+			c.name
+		FROM tb_category c
+		INNER JOIN tb_post_category pc ON pc.category_cid = c.id
+		WHERE pc.deleted_at is null and c.deleted_at is null
+		and pc.post_pid = $1
+		ORDER BY c.name
 	`
 
-	rows, err := db.Query(sqlText, categoryID)
+	rows, err := db.Query(sqlText, postID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, rows.Err()
+}
+
+func (r *categoryRepositoryImpl) find(where string, arg string) (*models.Category, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT ` + categoryColumns + `
+		FROM tb_category c
+		` + categoryJoins + `
+		WHERE c.deleted_at is null and ` + where
+
+	rows, err := db.Query(sqlText, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
 	if rows.Next() {
 		category, err := r.scanIterator(rows)
@@ -237,9 +364,99 @@ func (r *categoryRepositoryImpl) Find(categoryID string) (*models.Category, erro
 		return category, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
+}
+
+func (r *categoryRepositoryImpl) Find(categoryID string) (*models.Category, error) {
+	return r.find("c.id = $1", categoryID)
+}
+
+func (r *categoryRepositoryImpl) FindSlug(slug string) (*models.Category, error) {
+	return r.find("c.slug = $1", slug)
+}
+
+// FreeSlug: base, or base with the first free number, not used by another category
+func (r *categoryRepositoryImpl) FreeSlug(base, categoryID string) (string, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	// the slugs are made of letters, digits and hyphens, there is no wildcard of like in them
+	rows, err := db.Query(`
+		SELECT
+			slug
+		FROM tb_category
+		WHERE deleted_at is null and id <> $2 and (slug = $1 or slug like $1 || '-%')
+	`, base, categoryID)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var s string
+		err = rows.Scan(&s)
+		if err != nil {
+			return "", err
+		}
+		taken[s] = true
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	return slug.Unique(base, func(s string) bool {
+		return taken[s]
+	}), nil
 }
 
+// lockTree: the changes of the tree run one at a time, the readers aren't blocked
+func lockTree(tx *sql.Tx) error {
+	_, err := tx.Exec("LOCK TABLE tb_category IN SHARE ROW EXCLUSIVE MODE")
+	return err
+}
+
+// treeLevels: the levels of the category's path from the root and of its subtree together,
+// and if the category is its own ancestor. The walks stop past models.CategoryDepth
+func treeLevels(tx *sql.Tx, categoryID string) (int, bool, error) {
+	levels := 0
+	cycle := false
+	err := tx.QueryRow(`
+		WITH RECURSIVE up AS (
+			SELECT id, parent_id, 1 AS depth, false AS cycle
+			FROM tb_category
+			WHERE deleted_at is null and id = $1
+			UNION ALL
+			SELECT c.id, c.parent_id, up.depth + 1, c.id = $1
+			FROM tb_category c
+			INNER JOIN up ON c.id = up.parent_id
+			WHERE c.deleted_at is null and not up.cycle and up.depth <= $2
+		), down AS (
+			SELECT id, 1 AS depth
+			FROM tb_category
+			WHERE deleted_at is null and id = $1
+			UNION ALL
+			SELECT c.id, down.depth + 1
+			FROM tb_category c
+			INNER JOIN down ON c.parent_id = down.id
+			WHERE c.deleted_at is null and down.depth <= $2
+		)
+		SELECT
+			coalesce((SELECT max(depth) FROM up) + (SELECT max(depth) FROM down) - 1, 0),
+			coalesce((SELECT bool_or(cycle) FROM up), false)
+	`, categoryID, models.CategoryDepth).Scan(&levels, &cycle)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return levels, cycle, nil
+}
+
+// Update: the parent is checked again inside the transaction, with the tree locked, so two
+// changes at the same time can't make a cycle or a tree deeper than models.CategoryDepth
 func (r *categoryRepositoryImpl) Update(entity *models.Category) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
@@ -247,36 +464,57 @@ func (r *categoryRepositoryImpl) Update(entity *models.Category) error {
 	}
 	defer db.Close()
 
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = lockTree(tx)
+	if err != nil {
+		return err
+	}
+
 	sqlText := `
 		UPDATE tb_category SET
 			name = $2,
+			parent_id = $3,
+			slug = $4,
+			description = $5,
+			cover_mid = $6,
 			updated_at = now()
 		WHERE deleted_at is null and id = $1
 	`
 
-	stmt, err := db.Prepare(sqlText)
+	result, err := tx.Exec(sqlText, entity.CategoryID, entity.Name, sql.NullString{String: entity.ParentID, Valid: entity.ParentID != ""}, entity.Slug, sql.NullString{String: entity.Description, Valid: entity.Description != ""}, sql.NullString{String: entity.CoverID, Valid: entity.CoverID != ""})
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
 
-	result, err := stmt.Exec(entity.CategoryID, entity.Name)
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	if rowsAffected != 1 {
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
+	}
+
+	levels, cycle, err := treeLevels(tx, entity.CategoryID)
 	if err != nil {
 		return err
 	}
-
-	if rowsAffected != 1 {
-		return errors.New(messages.UpdateError)
+	if cycle {
+		return domainErrors.ErrCategoryCycle
+	}
+	if levels > models.CategoryDepth {
+		return domainErrors.Validation("categoria pai", messages.FieldInvalid, fmt.Errorf("the tree would have %d levels, at most %d", levels, models.CategoryDepth))
 	}
 
-	return nil
+	return tx.Commit()
 }
 
+// Remove: the children of the category go to its parent
 func (r *categoryRepositoryImpl) Remove(categoryID string) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
@@ -284,19 +522,165 @@ func (r *categoryRepositoryImpl) Remove(categoryID string) error {
 	}
 	defer db.Close()
 
-	sqlText := `
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
 		UPDATE tb_category SET
 			deleted_at = now()
 		WHERE deleted_at is null and id = $1
+	`, categoryID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE tb_category SET
+			parent_id = (SELECT parent_id FROM tb_category WHERE id = $1),
+			updated_at = now()
+		WHERE deleted_at is null and parent_id = $1
+	`, categoryID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// All: every category in alphabetical order, for the tree
+func (r *categoryRepositoryImpl) All() ([]models.Category, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT ` + categoryColumns + `
+		FROM tb_category c
+		` + categoryJoins + `
+		WHERE c.deleted_at is null
+		ORDER BY c.name, c.id
 	`
 
-	stmt, err := db.Prepare(sqlText)
+	rows, err := db.Query(sqlText)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categorys := make([]models.Category, 0)
+	for rows.Next() {
+		category, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, err
+		}
+		categorys = append(categorys, *category)
+	}
+
+	return categorys, rows.Err()
+}
+
+// Breadcrumbs: a path from the root for each category of the post, in the alphabetical order of the categories
+func (r *categoryRepositoryImpl) Breadcrumbs(postID string) ([][]models.Category, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		WITH RECURSIVE up AS (
+			SELECT c.id AS leaf, c.name AS leaf_name, c.id, c.name, c.slug, c.parent_id, c.created_at, 1 AS depth
+			FROM tb_category c
+			INNER JOIN tb_post_category pc ON pc.category_cid = c.id
+			WHERE pc.deleted_at is null and c.deleted_at is null and pc.post_pid = $1
+			UNION ALL
+			SELECT up.leaf, up.leaf_name, c.id, c.name, c.slug, c.parent_id, c.created_at, up.depth + 1
+			FROM tb_category c
+			INNER JOIN up ON c.id = up.parent_id
+			WHERE c.deleted_at is null and up.depth < $2
+		)
+		SELECT 
+			leaf,
+			id,
+			name,
+			slug,
+			parent_id,
+			created_at
+		FROM up
+		ORDER BY leaf_name, leaf, depth desc
+	`
+
+	rows, err := db.Query(sqlText, postID, models.CategoryDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	paths := make([][]models.Category, 0)
+	last := ""
+	for rows.Next() {
+		leaf := sql.NullString{}
+		categoryID := sql.NullString{}
+		name := sql.NullString{}
+		categorySlug := sql.NullString{}
+		parentID := sql.NullString{}
+		createdAt := sql.NullTime{}
+
+		err = rows.Scan(&leaf, &categoryID, &name, &categorySlug, &parentID, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if leaf.String != last || len(paths) == 0 {
+			paths = append(paths, make([]models.Category, 0))
+			last = leaf.String
+		}
+		paths[len(paths)-1] = append(paths[len(paths)-1], models.Category{
+			CategoryID: categoryID.String,
+			Name:       name.String,
+			Slug:       categorySlug.String,
+			ParentID:   parentID.String,
+			CreatedAt:  createdAt.Time,
+		})
+	}
+
+	return paths, rows.Err()
+}
+
+// Merge: the posts and the children of the categories from go to the category, and the categories from are removed
+func (r *categoryRepositoryImpl) Merge(categoryID string, from []string) error {
+	db, err := databaseConn.Connect()
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-	result, err := stmt.Exec(categoryID)
+	err = lockTree(tx)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec("UPDATE tb_category SET deleted_at = now() WHERE deleted_at is null and id = ANY($1)", pq.Array(from))
 	if err != nil {
 		return err
 	}
@@ -306,11 +690,56 @@ func (r *categoryRepositoryImpl) Remove(categoryID string) error {
 		return err
 	}
 
-	if rowsAffected != 1 {
-		return errors.New(messages.UpdateError)
+	if rowsAffected != int64(len(from)) {
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
-	return nil
+	_, err = tx.Exec(`
+		UPDATE tb_category SET
+			parent_id = $1,
+			updated_at = now()
+		WHERE deleted_at is null and parent_id = ANY($2)
+	`, categoryID, pq.Array(from))
+	if err != nil {
+		return err
+	}
+
+	// the children checked by the service may have changed since, the levels of the category's subtree
+	// are counted again with the children in place
+	levels, _, err := treeLevels(tx, categoryID)
+	if err != nil {
+		return err
+	}
+	if levels > models.CategoryDepth {
+		return domainErrors.Validation("categorias", messages.FieldInvalid, fmt.Errorf("the tree would have %d levels, at most %d", levels, models.CategoryDepth))
+	}
+
+	// a post in two of the categories keeps one link: the one already in the category,
+	// else the oldest, the others are removed
+	_, err = tx.Exec(`
+		UPDATE tb_post_category pc SET
+			deleted_at = now()
+		WHERE pc.deleted_at is null and pc.category_cid = ANY($2) and exists (
+			SELECT 1 FROM tb_post_category o
+			WHERE o.deleted_at is null and o.post_pid = pc.post_pid
+			and (o.category_cid = $1 or (o.category_cid = ANY($2) and (o.created_at, o.id) < (pc.created_at, pc.id)))
+		)
+	`, categoryID, pq.Array(from))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE tb_post_category SET
+			category_cid = $1,
+			updated_at = now()
+		WHERE deleted_at is null and category_cid = ANY($2)
+	`, categoryID, pq.Array(from))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func NewCategoryRepository() categoryRepositoryInterface {
@@ -2,8 +2,8 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
@@ -47,7 +47,7 @@ func (r *codeRecoveryImpl) Store(entity *models.CodeRecovery) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
@@ -78,6 +78,9 @@ func (r *codeRecoveryImpl) Find(code string) (*models.CodeRecovery, error) {
 		&userIDE,
 		&expiredAT,
 	)
+	if err == sql.ErrNoRows {
+		return nil, domainErrors.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +132,7 @@ func (r *codeRecoveryImpl) Remove(code string) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
@@ -2,21 +2,22 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
-	"fmt"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/markdown"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 )
 
 type commentRepositoryInterface interface {
 	Store(entity *models.Comment) error
-	List(postID string, offset, limit, page int) ([]models.Comment, error)
+	List(postID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error)
 	Count(postID string) (int, error)
-	ListUser(userID string, offset, limit, page int) ([]models.Comment, error)
+	ListUser(userID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error)
 	CountUser(userID string) (int, error)
-	ListUserPost(postID, userID string, offset, limit, page int) ([]models.Comment, error)
+	ListUserPost(postID, userID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error)
 	CountUserPost(postID, userID string) (int, error)
 	Find(commentID string) (*models.Comment, error)
 	Update(entity *models.Comment) error
@@ -29,15 +30,19 @@ func (r *commentRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Comment, e
 	commentID := sql.NullString{}
 	title := sql.NullString{}
 	content := sql.NullString{}
+	contentHTML := sql.NullString{}
 	userID := sql.NullString{}
 	postID := sql.NullString{}
+	createdAt := sql.NullTime{}
 
 	err := rows.Scan(
 		&commentID,
 		&title,
 		&content,
+		&contentHTML,
 		&userID,
 		&postID,
+		&createdAt,
 	)
 
 	if err != nil {
@@ -58,6 +63,16 @@ func (r *commentRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Comment, e
 		comment.Content = content.String
 	}
 
+	// the comments saved before the markdown have no html, it's rendered on the read
+	if contentHTML.Valid {
+		comment.ContentHTML = contentHTML.String
+	} else {
+		comment.ContentHTML, err = markdown.RenderRestricted(comment.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if userID.Valid {
 		comment.UserID = userID.String
 	}
@@ -66,9 +81,18 @@ func (r *commentRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Comment, e
 		comment.PostID = postID.String
 	}
 
+	if createdAt.Valid {
+		comment.CreatedAt = createdAt.Time
+	}
+
 	return comment, nil
 }
 
+// commentKey: position of a comment in the lists' order
+func commentKey(comment models.Comment) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: comment.CreatedAt, ID: comment.CommentID}
+}
+
 func (r *commentRepositoryImpl) Store(entity *models.Comment) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
@@ -78,9 +102,9 @@ func (r *commentRepositoryImpl) Store(entity *models.Comment) error {
 
 	sqlText := `
 		insert into tb_comment
-		(id, title, content, user_uid, post_pid)
+		(id, title, content, content_html, user_uid, post_pid)
 		values
-		($1,$2,$3, $4, $5)
+		($1,$2,$3, $4, $5, $6)
 	`
 
 	stmt, err := db.Prepare(sqlText)
@@ -88,7 +112,7 @@ func (r *commentRepositoryImpl) Store(entity *models.Comment) error {
 		return err
 	}
 
-	result, err := stmt.Exec(entity.CommentID, entity.Title, entity.Content, entity.UserID, entity.PostID)
+	result, err := stmt.Exec(entity.CommentID, entity.Title, entity.Content, entity.ContentHTML, entity.UserID, entity.PostID)
 	if err != nil {
 		return err
 	}
@@ -100,34 +124,34 @@ func (r *commentRepositoryImpl) Store(entity *models.Comment) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
 }
 
-func (r *commentRepositoryImpl) List(postID string, offset, limit, page int) ([]models.Comment, error) {
+func (r *commentRepositoryImpl) List(postID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText, args := p.Query(`
 	SELECT 
 		id,
 		title,
 		content,
+		content_html,
 		user_uid,
-		post_pid
+		post_pid,
+		created_at
 	FROM tb_comment
-	WHERE deleted_at is null and post_pid = $1
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	WHERE deleted_at is null and post_pid = $1`, "created_at", "id", []interface{}{postID})
 
-	rows, err := db.Query(sqlText, postID)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -135,12 +159,13 @@ func (r *commentRepositoryImpl) List(postID string, offset, limit, page int) ([]
 	for rows.Next() {
 		comment, err := r.scanIterator(rows)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		comments = append(comments, *comment)
 	}
 
-	return comments, nil
+	comments, page := pagination.Slice(p, comments, commentKey)
+	return comments, page, nil
 }
 
 func (r *commentRepositoryImpl) Count(postID string) (int, error) {
@@ -167,28 +192,28 @@ func (r *commentRepositoryImpl) Count(postID string) (int, error) {
 	return count, nil
 }
 
-func (r *commentRepositoryImpl) ListUser(userID string, offset, limit, page int) ([]models.Comment, error) {
+func (r *commentRepositoryImpl) ListUser(userID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText, args := p.Query(`
 	SELECT 
 		id,
 		title,
 		content,
+		content_html,
 		user_uid,
-		post_pid
+		post_pid,
+		created_at
 	FROM tb_comment
-	WHERE deleted_at is null and user_uid = $1
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	WHERE deleted_at is null and user_uid = $1`, "created_at", "id", []interface{}{userID})
 
-	rows, err := db.Query(sqlText, userID)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -196,12 +221,13 @@ func (r *commentRepositoryImpl) ListUser(userID string, offset, limit, page int)
 	for rows.Next() {
 		comment, err := r.scanIterator(rows)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		comments = append(comments, *comment)
 	}
 
-	return comments, nil
+	comments, page := pagination.Slice(p, comments, commentKey)
+	return comments, page, nil
 }
 
 func (r *commentRepositoryImpl) CountUser(userID string) (int, error) {
@@ -228,28 +254,28 @@ func (r *commentRepositoryImpl) CountUser(userID string) (int, error) {
 	return count, nil
 }
 
-func (r *commentRepositoryImpl) ListUserPost(postID, userID string, offset, limit, page int) ([]models.Comment, error) {
+func (r *commentRepositoryImpl) ListUserPost(postID, userID string, p *pagination.Params) ([]models.Comment, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText, args := p.Query(`
 	SELECT 
 		id,
 		title,
 		content,
+		content_html,
 		user_uid,
-		post_pid
+		post_pid,
+		created_at
 	FROM tb_comment
-	WHERE deleted_at is null and post_pid = $1 and user_uid = $2
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	WHERE deleted_at is null and post_pid = $1 and user_uid = $2`, "created_at", "id", []interface{}{postID, userID})
 
-	rows, err := db.Query(sqlText, postID, userID)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -257,12 +283,13 @@ func (r *commentRepositoryImpl) ListUserPost(postID, userID string, offset, limi
 	for rows.Next() {
 		comment, err := r.scanIterator(rows)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		comments = append(comments, *comment)
 	}
 
-	return comments, nil
+	comments, page := pagination.Slice(p, comments, commentKey)
+	return comments, page, nil
 }
 
 func (r *commentRepositoryImpl) CountUserPost(postID, userID string) (int, error) {
@@ -301,8 +328,10 @@ func (r *commentRepositoryImpl) Find(commentID string) (*models.Comment, error)
 			id, 
 			title,
 			content,
+			content_html,
 			user_uid, 
-			post_pid
+			post_pid,
+			created_at
 		FROM tb_comment
 		WHERE deleted_at is null and id = $1
 	`
@@ -321,7 +350,7 @@ func (r *commentRepositoryImpl) Find(commentID string) (*models.Comment, error)
 		return comment, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
 }
 
 func (r *commentRepositoryImpl) Update(entity *models.Comment) error {
@@ -335,6 +364,7 @@ func (r *commentRepositoryImpl) Update(entity *models.Comment) error {
 		UPDATE tb_comment SET
 			title = $2,
 			content = $3,
+			content_html = $4,
 			updated_at = now()
 		WHERE deleted_at is null and id = $1
 	`
@@ -345,7 +375,7 @@ func (r *commentRepositoryImpl) Update(entity *models.Comment) error {
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(entity.CommentID, entity.Title, entity.Content)
+	result, err := stmt.Exec(entity.CommentID, entity.Title, entity.Content, entity.ContentHTML)
 	if err != nil {
 		return err
 	}
@@ -356,7 +386,7 @@ func (r *commentRepositoryImpl) Update(entity *models.Comment) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -392,7 +422,7 @@ func (r *commentRepositoryImpl) Remove(commentID string) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
@@ -2,9 +2,8 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
-	"fmt"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
@@ -101,7 +100,7 @@ func (r *configsRepositoryImpl) Store(configs *models.Configs) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
@@ -114,15 +113,16 @@ func (r *configsRepositoryImpl) List(offset, limit, page int) ([]models.Configs,
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText := `
 		SELECT
 			id, collors, links, menuAs, bannerURL
 		FROM tb_configs
 		WHERE deleted_at is null
-		LIMIT %v OFFSET ((%v - 1) * %v) + %v
-	`, limit, page, limit, offset)
+		ORDER BY id
+		LIMIT $1 OFFSET (($2 - 1) * $1) + $3
+	`
 
-	rows, err := db.Query(sqlText)
+	rows, err := db.Query(sqlText, limit, page, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -189,7 +189,7 @@ func (r *configsRepositoryImpl) Find(configsID int) (*models.Configs, error) {
 		return config, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
 }
 
 func (r *configsRepositoryImpl) Update(configs *models.Configs) error {
@@ -229,7 +229,7 @@ func (r *configsRepositoryImpl) Update(configs *models.Configs) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -268,7 +268,7 @@ func (r *configsRepositoryImpl) Remove(configsID int) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
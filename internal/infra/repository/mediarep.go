@@ -0,0 +1,333 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+)
+
+type mediaRepositoryInterface interface {
+	Store(entity *models.Media) (bool, error)
+	List(userID string, p *pagination.Params) ([]models.Media, *pagination.Page, error)
+	Count(userID string) (int, error)
+	Find(mediaID string) (*models.Media, error)
+	FindHash(userID, hash string) (*models.Media, error)
+	FindFile(hash string) (*models.Media, error)
+	FileUsed(key string) (bool, error)
+	Remove(mediaID string) error
+}
+
+type mediaRepositoryImpl struct{}
+
+func (r *mediaRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Media, error) {
+	mediaID := sql.NullString{}
+	userID := sql.NullString{}
+	name := sql.NullString{}
+	hash := sql.NullString{}
+	mime := sql.NullString{}
+	size := sql.NullInt64{}
+	width := sql.NullInt64{}
+	height := sql.NullInt64{}
+	fileKey := sql.NullString{}
+	thumbKey := sql.NullString{}
+	createdAt := sql.NullTime{}
+
+	err := rows.Scan(
+		&mediaID,
+		&userID,
+		&name,
+		&hash,
+		&mime,
+		&size,
+		&width,
+		&height,
+		&fileKey,
+		&thumbKey,
+		&createdAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	media := new(models.Media)
+
+	if mediaID.Valid {
+		media.MediaID = mediaID.String
+	}
+
+	if userID.Valid {
+		media.UserID = userID.String
+	}
+
+	if name.Valid {
+		media.Name = name.String
+	}
+
+	if hash.Valid {
+		media.Hash = hash.String
+	}
+
+	if mime.Valid {
+		media.MIME = mime.String
+	}
+
+	if size.Valid {
+		media.Size = size.Int64
+	}
+
+	if width.Valid {
+		media.Width = int(width.Int64)
+	}
+
+	if height.Valid {
+		media.Height = int(height.Int64)
+	}
+
+	if fileKey.Valid {
+		media.Key = fileKey.String
+	}
+
+	if thumbKey.Valid {
+		media.ThumbKey = thumbKey.String
+	}
+
+	if createdAt.Valid {
+		media.CreatedAt = createdAt.Time
+	}
+
+	return media, nil
+}
+
+// mediaKey: position of a media in the lists' order
+func mediaKey(media models.Media) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: media.CreatedAt, ID: media.MediaID}
+}
+
+// Store: false when the user already has a media with the same content, it's kept only once by user
+func (r *mediaRepositoryImpl) Store(entity *models.Media) (bool, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		insert into tb_media
+		(id, user_uid, name, hash, mime, size, width, height, file_key, thumb_key)
+		values
+		($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+		on conflict (user_uid, hash) where deleted_at is null do nothing
+	`
+
+	result, err := db.Exec(sqlText,
+		entity.MediaID,
+		sql.NullString{String: entity.UserID, Valid: entity.UserID != ""},
+		entity.Name,
+		entity.Hash,
+		entity.MIME,
+		entity.Size,
+		sql.NullInt64{Int64: int64(entity.Width), Valid: entity.Width > 0},
+		sql.NullInt64{Int64: int64(entity.Height), Valid: entity.Height > 0},
+		entity.Key,
+		sql.NullString{String: entity.ThumbKey, Valid: entity.ThumbKey != ""},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// List: the media of the user, of everyone when userID is empty
+func (r *mediaRepositoryImpl) List(userID string, p *pagination.Params) ([]models.Media, *pagination.Page, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	sqlText, args := p.Query(`
+	SELECT
+		id,
+		user_uid,
+		name,
+		hash,
+		mime,
+		size,
+		width,
+		height,
+		file_key,
+		thumb_key,
+		created_at
+	FROM tb_media
+	WHERE deleted_at is null and ($1 = '' or user_uid = $1)`, "created_at", "id", []interface{}{userID})
+
+	rows, err := db.Query(sqlText, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	medias := make([]models.Media, 0)
+	for rows.Next() {
+		media, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		medias = append(medias, *media)
+	}
+
+	medias, page := pagination.Slice(p, medias, mediaKey)
+	return medias, page, nil
+}
+
+func (r *mediaRepositoryImpl) Count(userID string) (int, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			COUNT(*)
+		FROM tb_media
+		WHERE deleted_at is null and ($1 = '' or user_uid = $1)
+	`
+
+	var count int
+	row := db.QueryRow(sqlText, userID)
+	err = row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *mediaRepositoryImpl) find(where string, args ...interface{}) (*models.Media, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			id,
+			user_uid,
+			name,
+			hash,
+			mime,
+			size,
+			width,
+			height,
+			file_key,
+			thumb_key,
+			created_at
+		FROM tb_media
+		WHERE deleted_at is null and ` + where + `
+		ORDER BY created_at, id
+		LIMIT 1
+	`
+
+	rows, err := db.Query(sqlText, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		media, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		return media, nil
+	}
+
+	return nil, domainErrors.ErrNotFound
+}
+
+func (r *mediaRepositoryImpl) Find(mediaID string) (*models.Media, error) {
+	return r.find("id = $1", mediaID)
+}
+
+// FindHash: the user's media with the content
+func (r *mediaRepositoryImpl) FindHash(userID, hash string) (*models.Media, error) {
+	return r.find("coalesce(user_uid, '') = $1 and hash = $2", userID, hash)
+}
+
+// FindFile: a media of anyone with the content, the users that upload the same content share its files
+func (r *mediaRepositoryImpl) FindFile(hash string) (*models.Media, error) {
+	return r.find("hash = $1", hash)
+}
+
+// FileUsed: tells if a media still refers to the file of the key
+func (r *mediaRepositoryImpl) FileUsed(key string) (bool, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM tb_media
+			WHERE deleted_at is null and (file_key = $1 or thumb_key = $1)
+		)
+	`
+
+	var used bool
+	err = db.QueryRow(sqlText, key).Scan(&used)
+	if err != nil {
+		return false, err
+	}
+
+	return used, nil
+}
+
+func (r *mediaRepositoryImpl) Remove(mediaID string) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sqlText := `
+		UPDATE tb_media SET
+			deleted_at = now()
+		WHERE deleted_at is null and id = $1
+	`
+
+	result, err := db.Exec(sqlText, mediaID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
+	}
+
+	return nil
+}
+
+func NewMediaRepository() mediaRepositoryInterface {
+	return &mediaRepositoryImpl{}
+}
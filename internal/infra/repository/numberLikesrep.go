@@ -2,8 +2,8 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
@@ -91,7 +91,7 @@ func (r *numberLikesRepositoryImpl) Store(entity *models.NumberLikes) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
@@ -123,7 +123,7 @@ func (r *numberLikesRepositoryImpl) CountLikes(postID string) (int, error) {
 		return int(count.Int64), err
 	}
 
-	return 0, errors.New(messages.CountError)
+	return 0, domainErrors.ErrNotFound.WithMessage(messages.CountError)
 
 }
 
@@ -161,7 +161,7 @@ func (r *numberLikesRepositoryImpl) Find(postID, userID string) (*models.NumberL
 		return nl, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
 }
 
 func (r *numberLikesRepositoryImpl) Update(id string, value bool) error {
@@ -196,7 +196,7 @@ func (r *numberLikesRepositoryImpl) Update(id string, value bool) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -233,7 +233,7 @@ func (r *numberLikesRepositoryImpl) Remove(id string) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
@@ -1,8 +1,7 @@
 package repository
 
 import (
-	"errors"
-
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
@@ -42,7 +41,7 @@ func (r *personRepositoryImpl) Store(entity *models.Person, userID string) error
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
@@ -78,7 +77,7 @@ func (r *personRepositoryImpl) Update(entity *models.Person) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -110,7 +109,7 @@ func (r *personRepositoryImpl) Remove(id string) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
@@ -2,11 +2,12 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/lib/pq"
 )
 
 type postCategoryRepositoryInterface interface {
@@ -14,6 +15,8 @@ type postCategoryRepositoryInterface interface {
 	Find(postID string, categoryID string) (*models.PostCategory, error)
 	Update(entity *models.PostCategory) error
 	Remove(postID, categoryID string) error
+	Set(postID string, links []models.PostCategory) error
+	StoreMany(links []models.PostCategory) (int, error)
 }
 
 type postCategoryRepositoryImpl struct{}
@@ -76,7 +79,7 @@ func (r *postCategoryRepositoryImpl) Store(entity *models.PostCategory) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
@@ -112,7 +115,7 @@ func (r *postCategoryRepositoryImpl) Find(postID string, categoryID string) (*mo
 		return postCategory, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
 }
 
 func (r *postCategoryRepositoryImpl) Update(entity *models.PostCategory) error {
@@ -145,7 +148,7 @@ func (r *postCategoryRepositoryImpl) Update(entity *models.PostCategory) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -176,12 +179,101 @@ func (r *postCategoryRepositoryImpl) Remove(postID, categoryID string) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
 }
 
+// storeLink: links the post to the category when they aren't linked yet, linked tells if it was
+func storeLink(tx *sql.Tx, link models.PostCategory) (bool, error) {
+	result, err := tx.Exec(`
+		INSERT INTO tb_post_category
+		(id, post_pid, category_cid)
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (
+			SELECT 1 FROM tb_post_category
+			WHERE deleted_at is null and post_pid = $2 and category_cid = $3
+		)
+	`, link.PostCategoryId, link.PostId, link.CategoryId)
+	if err != nil {
+		return false, err
+	}
+
+	rowAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowAffected == 1, nil
+}
+
+// Set: the links are the post's categories, the links to the other categories are removed
+func (r *postCategoryRepositoryImpl) Set(postID string, links []models.PostCategory) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, 0, len(links))
+	for _, v := range links {
+		ids = append(ids, v.CategoryId)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE tb_post_category SET
+			deleted_at = now()
+		WHERE deleted_at is null and post_pid = $1 and not (category_cid = ANY($2))
+	`, postID, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+
+	for _, v := range links {
+		_, err = storeLink(tx, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// StoreMany: the links that don't exist yet, all of them or none. The number of new links is returned
+func (r *postCategoryRepositoryImpl) StoreMany(links []models.PostCategory) (int, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	count := 0
+	for _, v := range links {
+		linked, err := storeLink(tx, v)
+		if err != nil {
+			return 0, err
+		}
+		if linked {
+			count++
+		}
+	}
+
+	return count, tx.Commit()
+}
+
 func NewPostCategoryRepository() postCategoryRepositoryInterface {
 	return &postCategoryRepositoryImpl{}
 }
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/lib/pq"
+)
+
+type postRelatedRepositoryInterface interface {
+	Cached(postID string, maxAge time.Duration) ([]string, error)
+	Compute(postID string, limit int) ([]string, error)
+	Store(postID string, related []string) error
+	Posts(ids []string) ([]models.Post, error)
+}
+
+type postRelatedRepositoryImpl struct{}
+
+// Cached: the ids of the related posts computed in the last maxAge. When a post, its categories or its tags
+// change, the triggers of the migration 29 clear its list and the lists holding it. When its status or its links
+// change, the lists of the posts sharing a category or a tag with it are cleared too, the others wait for the age
+func (r *postRelatedRepositoryImpl) Cached(postID string, maxAge time.Duration) ([]string, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT related
+		FROM tb_post_related
+		WHERE post_pid = $1 and created_at > now() - make_interval(secs => $2)
+	`
+
+	related := pq.StringArray{}
+	err = db.QueryRow(sqlText, postID, maxAge.Seconds()).Scan(&related)
+	if err == sql.ErrNoRows {
+		return nil, domainErrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return related, nil
+}
+
+const (
+	// relatedWords: the most frequent words of the post, searched in the others
+	relatedWords = `
+		SELECT w.lexeme
+		FROM tb_post s, unnest(s.search) w
+		WHERE s.id = $1
+		ORDER BY coalesce(array_length(w.positions, 1), 0) DESC, w.lexeme
+		LIMIT 32`
+
+	// relatedCategories: categories shared by the post $1 and the post p
+	relatedCategories = `(
+		SELECT COUNT(*)
+		FROM tb_post_category a
+		INNER JOIN tb_post_category b ON b.category_cid = a.category_cid and b.deleted_at is null
+		INNER JOIN tb_category c ON c.id = a.category_cid and c.deleted_at is null
+		WHERE a.post_pid = $1 and a.deleted_at is null and b.post_pid = p.id
+	)`
+
+	// relatedTags: tags shared by the post $1 and the post p
+	relatedTags = `(
+		SELECT COUNT(*)
+		FROM tb_post_tag a
+		INNER JOIN tb_post_tag b ON b.tag_tid = a.tag_tid
+		INNER JOIN tb_tag t ON t.id = a.tag_tid and t.deleted_at is null
+		WHERE a.post_pid = $1 and b.post_pid = p.id
+	)`
+)
+
+// Compute: the published posts closest to the post, a shared category weighs 3, a shared tag 2,
+// and the rank of the post's words in the text of the other (title over content) up to 10
+func (r *postRelatedRepositoryImpl) Compute(postID string, limit int) ([]string, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// the lexemes are already stemmed, they're quoted into the query as they are
+	sqlText := `
+		WITH q AS (
+			SELECT string_agg('''' || replace(replace(lexeme, '\', '\\'), '''', '''''') || '''', ' | ')::tsquery AS query
+			FROM (` + relatedWords + `) words
+		), scored AS (
+			SELECT
+				p.id,
+				p.published_at,
+				3 * ` + relatedCategories + ` + 2 * ` + relatedTags + ` + 10 * coalesce(ts_rank(p.search, q.query), 0) AS score
+			FROM tb_post p, q
+			WHERE p.deleted_at is null and p.status = 'published' and p.id <> $1
+		)
+		SELECT id
+		FROM scored
+		WHERE score > 0
+		ORDER BY score DESC, published_at DESC NULLS LAST, id
+		LIMIT $2
+	`
+
+	rows, err := db.Query(sqlText, postID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	related := make([]string, 0, limit)
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		related = append(related, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return related, nil
+}
+
+// Store: replaces the cached related posts of the post, an empty list is cached too
+func (r *postRelatedRepositoryImpl) Store(postID string, related []string) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sqlText := `
+		insert into tb_post_related
+		(post_pid, related)
+		values
+		($1, $2)
+		on conflict (post_pid) do update set related = excluded.related, created_at = now()
+	`
+
+	_, err = db.Exec(sqlText, postID, pq.Array(related))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Posts: the published posts of the ids in their order, the others are skipped
+func (r *postRelatedRepositoryImpl) Posts(ids []string) ([]models.Post, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			p.id,
+			p.title,
+			p.content,
+			p.content_html,
+			p.excerpt,
+			p.summary,
+			p.created_at,
+			p.user_uid,
+			` + postAuthor + `,
+			p.status,
+			p.published_at,
+			p.publish_at,
+			p.slug,
+			p.views,
+			p.updated_at,
+			p.cover_mid,
+			p.seo,
+			` + postCover + `,
+			` + postLikes + `
+		FROM tb_post p
+		` + postAuthorJoin + `
+		` + postCoverJoin + `
+		WHERE p.deleted_at is null and p.status = 'published' and p.id = ANY($1)
+		ORDER BY array_position($1::text[], p.id::text)
+	`
+
+	rows, err := db.Query(sqlText, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	repPost := new(postRepositoryImpl)
+	posts := make([]models.Post, 0, len(ids))
+	for rows.Next() {
+		post, err := repPost.scanIterator(rows, true)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, *post)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+func NewPostRelatedRepository() postRelatedRepositoryInterface {
+	return &postRelatedRepositoryImpl{}
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+)
+
+type postRevisionRepositoryInterface interface {
+	List(postID string, p *pagination.Params) ([]models.PostRevision, *pagination.Page, error)
+	Count(postID string) (int, error)
+	Find(postID string, number int) (*models.PostRevision, error)
+}
+
+type postRevisionRepositoryImpl struct{}
+
+// storeRevision: saves the title and content of the post as its next revision, in the transaction of the post
+func storeRevision(tx *sql.Tx, post *models.Post, revision *models.PostRevision) error {
+	sqlText := `
+		insert into tb_post_revision
+		(id, post_pid, number, title, content, user_uid, restored_from)
+		select $1, $2, COALESCE(MAX(number), 0) + 1, $3, $4, $5, $6
+		from tb_post_revision
+		where post_pid = $2
+	`
+
+	result, err := tx.Exec(sqlText,
+		revision.RevisionID,
+		post.PostID,
+		post.Title,
+		post.Content,
+		sql.NullString{String: revision.UserID, Valid: revision.UserID != ""},
+		sql.NullInt64{Int64: int64(revision.RestoredFrom), Valid: revision.RestoredFrom > 0},
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrStoreFailed
+	}
+
+	return nil
+}
+
+func (r *postRevisionRepositoryImpl) scanIterator(rows *sql.Rows) (*models.PostRevision, error) {
+	revisionID := sql.NullString{}
+	postID := sql.NullString{}
+	number := sql.NullInt64{}
+	title := sql.NullString{}
+	content := sql.NullString{}
+	userID := sql.NullString{}
+	restoredFrom := sql.NullInt64{}
+	createdAt := sql.NullTime{}
+
+	err := rows.Scan(
+		&revisionID,
+		&postID,
+		&number,
+		&title,
+		&content,
+		&userID,
+		&restoredFrom,
+		&createdAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revision := new(models.PostRevision)
+
+	if revisionID.Valid {
+		revision.RevisionID = revisionID.String
+	}
+
+	if postID.Valid {
+		revision.PostID = postID.String
+	}
+
+	if number.Valid {
+		revision.Number = int(number.Int64)
+	}
+
+	if title.Valid {
+		revision.Title = title.String
+	}
+
+	if content.Valid {
+		revision.Content = content.String
+	}
+
+	if userID.Valid {
+		revision.UserID = userID.String
+	}
+
+	if restoredFrom.Valid {
+		revision.RestoredFrom = int(restoredFrom.Int64)
+	}
+
+	if createdAt.Valid {
+		revision.CreatedAt = createdAt.Time
+	}
+
+	return revision, nil
+}
+
+func revisionKey(revision models.PostRevision) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: revision.CreatedAt, ID: revision.RevisionID}
+}
+
+func (r *postRevisionRepositoryImpl) List(postID string, p *pagination.Params) ([]models.PostRevision, *pagination.Page, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	sqlText, args := p.Query(`
+	SELECT
+		id,
+		post_pid,
+		number,
+		title,
+		content,
+		user_uid,
+		restored_from,
+		created_at
+	FROM tb_post_revision
+	WHERE post_pid = $1`, "created_at", "id", []interface{}{postID})
+
+	rows, err := db.Query(sqlText, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]models.PostRevision, 0)
+	for rows.Next() {
+		revision, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+
+	revisions, page := pagination.Slice(p, revisions, revisionKey)
+	return revisions, page, nil
+}
+
+func (r *postRevisionRepositoryImpl) Count(postID string) (int, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			COUNT(*)
+		FROM tb_post_revision
+		WHERE post_pid = $1
+	`
+
+	var count int
+	row := db.QueryRow(sqlText, postID)
+	err = row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *postRevisionRepositoryImpl) Find(postID string, number int) (*models.PostRevision, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			id,
+			post_pid,
+			number,
+			title,
+			content,
+			user_uid,
+			restored_from,
+			created_at
+		FROM tb_post_revision
+		WHERE post_pid = $1 and number = $2
+	`
+
+	rows, err := db.Query(sqlText, postID, number)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		revision, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		return revision, nil
+	}
+
+	return nil, domainErrors.ErrNotFound
+}
+
+func NewPostRevisionRepository() postRevisionRepositoryInterface {
+	return &postRevisionRepositoryImpl{}
+}
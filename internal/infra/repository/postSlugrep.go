@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/slug"
+)
+
+// nextSlug: a free slug made from the title of the post, a slug the post already had is taken back.
+// fresh tells that the slug isn't in the history yet
+func nextSlug(tx *sql.Tx, post *models.Post) (string, bool, error) {
+	base := slug.Make(post.Title)
+
+	// the slugs are made of letters, digits and hyphens, there is no wildcard of like in them
+	rows, err := tx.Query(`
+		SELECT
+			slug,
+			post_pid
+		FROM tb_post_slug
+		WHERE slug = $1 or slug like $1 || '-%'
+	`, base)
+	if err != nil {
+		return "", false, err
+	}
+	defer rows.Close()
+
+	owners := make(map[string]string)
+	for rows.Next() {
+		var s, postID string
+		err = rows.Scan(&s, &postID)
+		if err != nil {
+			return "", false, err
+		}
+		owners[s] = postID
+	}
+	if err = rows.Err(); err != nil {
+		return "", false, err
+	}
+
+	s := slug.Unique(base, func(s string) bool {
+		owner, ok := owners[s]
+		return ok && owner != post.PostID
+	})
+	_, owned := owners[s]
+	return s, !owned, nil
+}
+
+// storeSlug: keeps the slug in the history, the old slugs of a post lead to its current one
+func storeSlug(tx *sql.Tx, s, postID string) error {
+	result, err := tx.Exec(`
+		insert into tb_post_slug
+		(slug, post_pid)
+		values
+		($1, $2)
+	`, s, postID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrStoreFailed
+	}
+
+	return nil
+}
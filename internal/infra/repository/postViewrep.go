@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+)
+
+type postViewRepositoryInterface interface {
+	Store(postID, visitor, day string) (bool, error)
+}
+
+type postViewRepositoryImpl struct{}
+
+// Store: counts the view of the visitor once a day, false when the visitor had already seen the post that day.
+// The day is the one of the visitor's hash, not the database's current_date
+func (r *postViewRepositoryImpl) Store(postID, visitor, day string) (bool, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	// the primary key (post_pid, visitor, day) drops the repeated views, only the new ones update the counter
+	sqlText := `
+		WITH v AS (
+			insert into tb_post_view
+			(post_pid, visitor, day)
+			values
+			($1, $2, $3)
+			on conflict do nothing
+			returning post_pid
+		)
+		UPDATE tb_post p SET views = p.views + 1
+		FROM v
+		WHERE p.id = v.post_pid
+		RETURNING p.views
+	`
+
+	var views int
+	err = db.QueryRow(sqlText, postID, visitor, day).Scan(&views)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func NewPostViewRepository() postViewRepositoryInterface {
+	return &postViewRepositoryImpl{}
+}
@@ -2,40 +2,94 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/markdown"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/lib/pq"
 )
 
 type postRepositoryInterface interface {
-	Store(post *models.Post) error
-	List(offset, limit, page int) ([]models.Post, error)
-	Count() (int, error)
+	Store(post *models.Post, revision *models.PostRevision) error
+	List(filter *models.PostFilter, p *pagination.Params) ([]models.Post, *pagination.Page, error)
+	Count(filter *models.PostFilter) (int, error)
 	Find(id string) (*models.Post, error)
-	ListTitle(title string, offset, limit, page int) ([]models.Post, error)
+	FindSlug(slug string) (*models.Post, error)
+	ListTitle(title string, p *pagination.Params) ([]models.Post, *pagination.Page, error)
 	CountTitle(title string) (int, error)
-	ListCategory(category string, offset, limit, page int) ([]models.Post, error)
-	CountCategory(category string) (int, error)
-	Update(post *models.Post) error
+	ListCategory(category string, descendants bool, p *pagination.Params) ([]models.Post, *pagination.Page, error)
+	CountCategory(category string, descendants bool) (int, error)
+	Update(post *models.Post, revision *models.PostRevision) error
 	Remove(id string) error
+	UpdateStatus(id string, from []string, to string) error
+	Schedule(id string, from []string, publishAt time.Time) error
+	PublishScheduled() ([]string, error)
 }
 
 type postRepositoryImpl struct{}
 
-func (r *postRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Post, error) {
+// scanIterator: the columns of a post, with likes the lists that sort by them select the likes last
+func (r *postRepositoryImpl) scanIterator(rows *sql.Rows, withLikes bool) (*models.Post, error) {
 	postId := sql.NullString{}
 	title := sql.NullString{}
 	content := sql.NullString{}
-
-	err := rows.Scan(
+	contentHTML := sql.NullString{}
+	excerpt := sql.NullString{}
+	summary := sql.NullString{}
+	createdAt := sql.NullTime{}
+	userID := sql.NullString{}
+	authorNick := sql.NullString{}
+	authorName := sql.NullString{}
+	status := sql.NullString{}
+	publishedAt := sql.NullTime{}
+	publishAt := sql.NullTime{}
+	postSlug := sql.NullString{}
+	views := sql.NullInt64{}
+	updatedAt := sql.NullTime{}
+	coverID := sql.NullString{}
+	seo := sql.NullString{}
+	coverKey := sql.NullString{}
+	coverThumbKey := sql.NullString{}
+	coverWidth := sql.NullInt64{}
+	coverHeight := sql.NullInt64{}
+	likes := sql.NullInt64{}
+
+	dest := []interface{}{
 		&postId,
 		&title,
 		&content,
-	)
+		&contentHTML,
+		&excerpt,
+		&summary,
+		&createdAt,
+		&userID,
+		&authorNick,
+		&authorName,
+		&status,
+		&publishedAt,
+		&publishAt,
+		&postSlug,
+		&views,
+		&updatedAt,
+		&coverID,
+		&seo,
+		&coverKey,
+		&coverThumbKey,
+		&coverWidth,
+		&coverHeight,
+	}
+	if withLikes {
+		dest = append(dest, &likes)
+	}
 
+	err := rows.Scan(dest...)
 	if err != nil {
 		return nil, err
 	}
@@ -54,98 +108,360 @@ func (r *postRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Post, error)
 		post.Content = content.String
 	}
 
+	// the posts saved before the markdown have no html, it's rendered on the read
+	if contentHTML.Valid {
+		post.ContentHTML = contentHTML.String
+	} else {
+		post.ContentHTML, err = markdown.Render(post.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if excerpt.Valid {
+		post.Excerpt = excerpt.String
+	}
+
+	// the same for the summary
+	if summary.Valid {
+		err = json.Unmarshal([]byte(summary.String), &post.Summary)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		post.Summary = postSummary(post.Content)
+	}
+
+	if createdAt.Valid {
+		post.CreatedAt = createdAt.Time
+	}
+
+	if userID.Valid {
+		post.UserID = userID.String
+	}
+
+	if authorNick.Valid {
+		post.AuthorNick = authorNick.String
+	}
+
+	if authorName.Valid {
+		post.AuthorName = authorName.String
+	}
+
+	if status.Valid {
+		post.Status = status.String
+	}
+
+	if publishedAt.Valid {
+		post.PublishedAt = &publishedAt.Time
+	}
+
+	if publishAt.Valid {
+		post.PublishAt = &publishAt.Time
+	}
+
+	if postSlug.Valid {
+		post.Slug = postSlug.String
+	}
+
+	if views.Valid {
+		post.Views = int(views.Int64)
+	}
+
+	if updatedAt.Valid {
+		post.UpdatedAt = &updatedAt.Time
+	}
+
+	if coverID.Valid {
+		post.CoverID = coverID.String
+	}
+
+	if seo.Valid {
+		err = json.Unmarshal([]byte(seo.String), &post.SEO)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if coverKey.Valid {
+		post.Cover = &models.Media{
+			MediaID:  post.CoverID,
+			Key:      coverKey.String,
+			ThumbKey: coverThumbKey.String,
+			Width:    int(coverWidth.Int64),
+			Height:   int(coverHeight.Int64),
+		}
+	}
+
+	if likes.Valid {
+		post.Likes = int(likes.Int64)
+	}
+
 	return post, nil
 }
 
-func (r *postRepositoryImpl) Store(post *models.Post) error {
+// postSummary: excerpt, words, reading time and table of contents of the markdown of a post
+func postSummary(content string) models.PostSummary {
+	s := markdown.Summarize(content)
+	summary := models.PostSummary{
+		Excerpt:     s.Excerpt,
+		Words:       s.Words,
+		ReadingTime: s.ReadingTime,
+		TOC:         make([]models.PostHeading, 0, len(s.TOC)),
+	}
+	for _, v := range s.TOC {
+		summary.TOC = append(summary.TOC, models.PostHeading(v))
+	}
+	return summary
+}
+
+// postKey: position of a post in the lists' order
+func postKey(post models.Post) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: post.CreatedAt, ID: post.PostID}
+}
+
+// postAuthor: nick and name of the author of the post p, selected with postAuthorJoin
+const postAuthor = `u.nick, pe.name`
+
+// postAuthorJoin: the posts created before the authorship have no author
+const postAuthorJoin = `LEFT JOIN tb_user u ON u.id = p.user_uid
+	LEFT JOIN tb_person pe ON pe.user_uid = p.user_uid and pe.deleted_at is null`
+
+// postCover: the file of the post's cover, selected with postCoverJoin
+const postCover = `cm.file_key, cm.thumb_key, cm.width, cm.height`
+
+// postCoverJoin: a removed media leaves the post without cover
+const postCoverJoin = `LEFT JOIN tb_media cm ON cm.id = p.cover_mid and cm.deleted_at is null`
+
+// postLikes: likes of the post p, selected by the lists to be sorted
+const postLikes = `(
+		SELECT COUNT(nl.user_uid)
+		FROM tb_number_likes nl
+		WHERE nl.deleted_at is null and nl.post_pid = p.id and nl.value_like = true
+	)`
+
+// postSorts: columns of the orders of the post lists, the default order is created_at
+var postSorts = map[string]string{
+	"likes": postLikes,
+	"title": "p.title",
+	"views": "p.views",
+}
+
+// postSortKey: position of a post in the order of sort
+func postSortKey(sort string) func(models.Post) pagination.Cursor {
+	return func(post models.Post) pagination.Cursor {
+		c := postKey(post)
+		switch sort {
+		case "likes":
+			c.Value = strconv.Itoa(post.Likes)
+		case "title":
+			c.Value = post.Title
+		case "views":
+			c.Value = strconv.Itoa(post.Views)
+		}
+		return c
+	}
+}
+
+// filterQuery: where clause of the post lists, the filters' values are always sent as args
+func (r *postRepositoryImpl) filterQuery(filter *models.PostFilter) (string, []interface{}) {
+	where := "WHERE p.deleted_at is null"
+	args := make([]interface{}, 0)
+
+	// the categories are filtered by their slugs, or by their names as before the slugs
+	if len(filter.Categories) > 0 {
+		args = append(args, pq.Array(filter.Categories))
+		where += fmt.Sprintf(`
+	and exists (
+		SELECT 1
+		FROM tb_post_category pc
+		INNER JOIN tb_category c ON c.id = pc.category_cid
+		WHERE pc.post_pid = p.id and pc.deleted_at is null and c.deleted_at is null
+		and (c.slug = ANY($%d) or c.name = ANY($%d))
+	)`, len(args), len(args))
+	}
+
+	// the tag is filtered by its slug
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		where += fmt.Sprintf(`
+	and exists (
+		SELECT 1
+		FROM tb_post_tag pt
+		INNER JOIN tb_tag t ON t.id = pt.tag_tid
+		WHERE pt.post_pid = p.id and t.deleted_at is null
+		and t.slug = $%d
+	)`, len(args))
+	}
+
+	// the dates are the ones the readers see: of the publication, or of the creation for the posts never published
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		where += fmt.Sprintf("\n\tand coalesce(p.published_at, p.created_at) >= $%d", len(args))
+	}
+
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		where += fmt.Sprintf("\n\tand coalesce(p.published_at, p.created_at) < $%d", len(args))
+	}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += fmt.Sprintf("\n\tand p.status = $%d", len(args))
+	}
+
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		where += fmt.Sprintf("\n\tand p.user_uid = $%d", len(args))
+	}
+
+	if filter.Author != "" {
+		args = append(args, filter.Author)
+		where += fmt.Sprintf("\n\tand p.user_uid in (SELECT id FROM tb_user WHERE deleted_at is null and nick = $%d)", len(args))
+	}
+
+	return where, args
+}
+
+// Store: the post, its slug, its tags and its first revision
+func (r *postRepositoryImpl) Store(post *models.Post, revision *models.PostRevision) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	post.Summary = postSummary(post.Content)
+	summary, err := json.Marshal(post.Summary)
+	if err != nil {
+		return err
+	}
+	seo, err := json.Marshal(post.SEO)
+	if err != nil {
+		return err
+	}
+
+	post.Slug, _, err = nextSlug(tx, post)
+	if err != nil {
+		return err
+	}
+
 	sqlText := `
 		insert into tb_post 
-		(id, title, content)
+		(id, title, content, content_html, excerpt, summary, user_uid, status, slug, cover_mid, seo)
 		values
-		($1,$2,$3)
+		($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
 	`
 
-	stmt, err := db.Prepare(sqlText)
+	result, err := tx.Exec(sqlText, post.PostID, post.Title, post.Content, post.ContentHTML, sql.NullString{String: post.Excerpt, Valid: post.Excerpt != ""}, string(summary), sql.NullString{String: post.UserID, Valid: post.UserID != ""}, post.Status, post.Slug, sql.NullString{String: post.CoverID, Valid: post.CoverID != ""}, string(seo))
 	if err != nil {
 		return err
 	}
 
-	result, err := stmt.Exec(post.PostID, post.Title, post.Content)
-
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
 
-	rowsAffected, err := result.RowsAffected()
+	if rowsAffected != 1 {
+		return domainErrors.ErrStoreFailed
+	}
+
+	err = storeSlug(tx, post.Slug, post.PostID)
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected != 1 {
-		return errors.New(messages.StoreError)
+	if len(post.Tags) > 0 {
+		err = storeTags(tx, post)
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	err = storeRevision(tx, post, revision)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *postRepositoryImpl) List(offset, limit, page int) ([]models.Post, error) {
+func (r *postRepositoryImpl) List(filter *models.PostFilter, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	where, args := r.filterQuery(filter)
+	sqlText, args := p.QuerySorted(`
 	SELECT 
-		id,
-		title,
-		content
-	FROM tb_post
-	WHERE deleted_at is null
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+		p.id,
+		p.title,
+		p.content,
+		p.content_html,
+		p.excerpt,
+		p.summary,
+		p.created_at,
+		p.user_uid,
+		`+postAuthor+`,
+		p.status,
+		p.published_at,
+		p.publish_at,
+		p.slug,
+		p.views,
+		p.updated_at,
+		p.cover_mid,
+		p.seo,
+		`+postCover+`,
+		`+postLikes+`
+	FROM tb_post p
+	`+postAuthorJoin+`
+	`+postCoverJoin+`
+	`+where, postSorts[p.Order.Name], "p.created_at", "p.id", args)
 
-	rows, err := db.Query(sqlText)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
 	posts := make([]models.Post, 0)
 	for rows.Next() {
-		post, err := r.scanIterator(rows)
+		post, err := r.scanIterator(rows, true)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		posts = append(posts, *post)
 	}
 
-	return posts, nil
+	posts, page := pagination.Slice(p, posts, postSortKey(p.Order.Name))
+	return posts, page, nil
 }
 
-func (r *postRepositoryImpl) Count() (int, error) {
+func (r *postRepositoryImpl) Count(filter *models.PostFilter) (int, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
 		return 0, err
 	}
 	defer db.Close()
 
+	where, args := r.filterQuery(filter)
 	sqlText := `
 		SELECT 
 			COUNT(*)
-		FROM tb_post
-		WHERE deleted_at is null
-	`
+		FROM tb_post p
+		` + where
 
 	var count int
-	row := db.QueryRow(sqlText)
+	row := db.QueryRow(sqlText, args...)
 	err = row.Scan(&count)
 	if err != nil {
 		return 0, err
@@ -163,11 +479,28 @@ func (r *postRepositoryImpl) Find(id string) (*models.Post, error) {
 
 	sqlText := `
 		SELECT 
-			id, 
-			title,
-			content
-		FROM tb_post
-		WHERE deleted_at is null and id = $1
+			p.id, 
+			p.title,
+			p.content,
+			p.content_html,
+			p.excerpt,
+			p.summary,
+			p.created_at,
+			p.user_uid,
+			` + postAuthor + `,
+			p.status,
+			p.published_at,
+			p.publish_at,
+			p.slug,
+			p.views,
+			p.updated_at,
+			p.cover_mid,
+			p.seo,
+			` + postCover + `
+		FROM tb_post p
+		` + postAuthorJoin + `
+		` + postCoverJoin + `
+		WHERE p.deleted_at is null and p.id = $1
 	`
 
 	rows, err := db.Query(sqlText, id)
@@ -176,7 +509,7 @@ func (r *postRepositoryImpl) Find(id string) (*models.Post, error) {
 	}
 
 	if rows.Next() {
-		post, err := r.scanIterator(rows)
+		post, err := r.scanIterator(rows, false)
 		if err != nil {
 			return nil, err
 		}
@@ -184,44 +517,113 @@ func (r *postRepositoryImpl) Find(id string) (*models.Post, error) {
 		return post, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
 }
 
-func (r *postRepositoryImpl) ListTitle(title string, offset, limit, page int) ([]models.Post, error) {
+// FindSlug: the post of a current or old slug, the post's Slug is the current one
+func (r *postRepositoryImpl) FindSlug(slug string) (*models.Post, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
 		return nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
-	SELECT 
-		id,
-		title,
-		content
-	FROM tb_post
-	WHERE deleted_at is null and title like $1
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	sqlText := `
+		SELECT 
+			p.id, 
+			p.title,
+			p.content,
+			p.content_html,
+			p.excerpt,
+			p.summary,
+			p.created_at,
+			p.user_uid,
+			` + postAuthor + `,
+			p.status,
+			p.published_at,
+			p.publish_at,
+			p.slug,
+			p.views,
+			p.updated_at,
+			p.cover_mid,
+			p.seo,
+			` + postCover + `
+		FROM tb_post_slug s
+		INNER JOIN tb_post p ON p.id = s.post_pid
+		` + postAuthorJoin + `
+		` + postCoverJoin + `
+		WHERE p.deleted_at is null and s.slug = $1
+	`
+
+	rows, err := db.Query(sqlText, slug)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		post, err := r.scanIterator(rows, false)
+		if err != nil {
+			return nil, err
+		}
+
+		return post, nil
+	}
+
+	return nil, domainErrors.ErrNotFound
+}
+
+func (r *postRepositoryImpl) ListTitle(title string, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
 
 	t := "%" + title + "%"
 
-	rows, err := db.Query(sqlText, t)
+	sqlText, args := p.Query(`
+	SELECT 
+		p.id,
+		p.title,
+		p.content,
+		p.content_html,
+		p.excerpt,
+		p.summary,
+		p.created_at,
+		p.user_uid,
+		`+postAuthor+`,
+		p.status,
+		p.published_at,
+		p.publish_at,
+		p.slug,
+		p.views,
+		p.updated_at,
+		p.cover_mid,
+		p.seo,
+		`+postCover+`
+	FROM tb_post p
+	`+postAuthorJoin+`
+	`+postCoverJoin+`
+	WHERE p.deleted_at is null and p.status = 'published' and p.title like $1`, "p.created_at", "p.id", []interface{}{t})
+
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
 	posts := make([]models.Post, 0)
 	for rows.Next() {
-		post, err := r.scanIterator(rows)
+		post, err := r.scanIterator(rows, false)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		posts = append(posts, *post)
 	}
 
-	return posts, nil
+	posts, page := pagination.Slice(p, posts, postKey)
+	return posts, page, nil
 }
 
 func (r *postRepositoryImpl) CountTitle(title string) (int, error) {
@@ -235,7 +637,7 @@ func (r *postRepositoryImpl) CountTitle(title string) (int, error) {
 		SELECT 
 			COUNT(*)
 		FROM tb_post
-		WHERE deleted_at is null and title like $1
+		WHERE deleted_at is null and status = 'published' and title like $1
 	`
 
 	t := "%" + title + "%"
@@ -250,45 +652,79 @@ func (r *postRepositoryImpl) CountTitle(title string) (int, error) {
 	return count, nil
 }
 
-func (r *postRepositoryImpl) ListCategory(category string, offset, limit, page int) ([]models.Post, error) {
+// postInCategory: the post p is in the category of slug or name $1, or in one of its descendants when they're asked
+func postInCategory(descendants bool) string {
+	set := "SELECT id FROM tb_category WHERE deleted_at is null and (slug = $1 or name = $1)"
+	if descendants {
+		set = `
+			WITH RECURSIVE down AS (
+				SELECT id, 1 AS depth FROM tb_category WHERE deleted_at is null and (slug = $1 or name = $1)
+				UNION ALL
+				SELECT c.id, down.depth + 1
+				FROM tb_category c
+				INNER JOIN down ON c.parent_id = down.id
+				WHERE c.deleted_at is null and down.depth < ` + strconv.Itoa(models.CategoryDepth) + `
+			)
+			SELECT id FROM down`
+	}
+	return `exists (
+		SELECT 1 FROM tb_post_category pc
+		WHERE pc.post_pid = p.id and pc.deleted_at is null and pc.category_cid IN (` + set + `)
+	)`
+}
+
+func (r *postRepositoryImpl) ListCategory(category string, descendants bool, p *pagination.Params) ([]models.Post, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText, args := p.Query(`
 	SELECT 
 		p.id,
 		p.title,
-		p.content
+		p.content,
+		p.content_html,
+		p.excerpt,
+		p.summary,
+		p.created_at,
+		p.user_uid,
+		`+postAuthor+`,
+		p.status,
+		p.published_at,
+		p.publish_at,
+		p.slug,
+		p.views,
+		p.updated_at,
+		p.cover_mid,
+		p.seo,
+		`+postCover+`
 	FROM tb_post p
-	INNER JOIN tb_post_category pc ON pc.post_pid = p.id
-	INNER JOIN tb_category c ON c.id = pc.category_cid
-	WHERE p.deleted_at is null and pc.deleted_at is null and c.deleted_at is null
-	and c.name = $1
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	`+postAuthorJoin+`
+	`+postCoverJoin+`
+	WHERE p.deleted_at is null and p.status = 'published' and `+postInCategory(descendants), "p.created_at", "p.id", []interface{}{category})
 
-	rows, err := db.Query(sqlText, category)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
 	posts := make([]models.Post, 0)
 	for rows.Next() {
-		post, err := r.scanIterator(rows)
+		post, err := r.scanIterator(rows, false)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		posts = append(posts, *post)
 	}
 
-	return posts, nil
+	posts, page := pagination.Slice(p, posts, postKey)
+	return posts, page, nil
 }
 
-func (r *postRepositoryImpl) CountCategory(category string) (int, error) {
+func (r *postRepositoryImpl) CountCategory(category string, descendants bool) (int, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
 		return 0, err
@@ -299,11 +735,7 @@ func (r *postRepositoryImpl) CountCategory(category string) (int, error) {
 	SELECT 
 		COUNT(*)
 	FROM tb_post p
-	INNER JOIN tb_post_category pc ON pc.post_pid = p.id
-	INNER JOIN tb_category c ON c.id = pc.category_cid
-	WHERE p.deleted_at is null and pc.deleted_at is null and c.deleted_at is null
-	 and c.name = $1
-	`
+	WHERE p.deleted_at is null and p.status = 'published' and ` + postInCategory(descendants)
 
 	var count int
 	row := db.QueryRow(sqlText, category)
@@ -315,28 +747,111 @@ func (r *postRepositoryImpl) CountCategory(category string) (int, error) {
 	return count, nil
 }
 
-func (r *postRepositoryImpl) Update(post *models.Post) error {
+// Update: saves the post, its slug and a new revision of it, the revisions are numbered in the order of the updates
+func (r *postRepositoryImpl) Update(post *models.Post, revision *models.PostRevision) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	post.Summary = postSummary(post.Content)
+	summary, err := json.Marshal(post.Summary)
+	if err != nil {
+		return err
+	}
+	seo, err := json.Marshal(post.SEO)
+	if err != nil {
+		return err
+	}
+
+	// the update locks the post's row, the revisions of the same post are numbered one at a time
 	sqlText := `
 		UPDATE tb_post SET
 			title = $2,
 			content = $3,
+			content_html = $4,
+			excerpt = $5,
+			summary = $6,
+			cover_mid = $7,
+			seo = $8,
 			updated_at = now()
 		WHERE deleted_at is null and id = $1
 	`
 
+	result, err := tx.Exec(sqlText, post.PostID, post.Title, post.Content, post.ContentHTML, sql.NullString{String: post.Excerpt, Valid: post.Excerpt != ""}, string(summary), sql.NullString{String: post.CoverID, Valid: post.CoverID != ""}, string(seo))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
+	}
+
+	// a new title gives a new slug, the old one stays in the history
+	postSlug, fresh, err := nextSlug(tx, post)
+	if err != nil {
+		return err
+	}
+	if fresh {
+		err = storeSlug(tx, postSlug, post.PostID)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.Exec("UPDATE tb_post SET slug = $2 WHERE id = $1", post.PostID, postSlug)
+	if err != nil {
+		return err
+	}
+	post.Slug = postSlug
+
+	// without tags the update keeps the post's ones
+	if post.Tags != nil {
+		err = storeTags(tx, post)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = storeRevision(tx, post, revision)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *postRepositoryImpl) Remove(id string) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sqlText := `
+		UPDATE tb_post SET
+			deleted_at = now()
+		WHERE deleted_at is null and id = $1
+	`
+
 	stmt, err := db.Prepare(sqlText)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(post.PostID, post.Title, post.Content)
+	result, err := stmt.Exec(id)
 	if err != nil {
 		return err
 	}
@@ -347,13 +862,15 @@ func (r *postRepositoryImpl) Update(post *models.Post) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
 }
 
-func (r *postRepositoryImpl) Remove(id string) error {
+// UpdateStatus: moves the post to the status to only if it is in one of the statuses from,
+// publishing sets published_at and going back to draft clears it, any step cancels a schedule
+func (r *postRepositoryImpl) UpdateStatus(id string, from []string, to string) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
 		return err
@@ -362,8 +879,15 @@ func (r *postRepositoryImpl) Remove(id string) error {
 
 	sqlText := `
 		UPDATE tb_post SET
-			deleted_at = now()
-		WHERE deleted_at is null and id = $1
+			status = $2,
+			published_at = CASE
+				WHEN $2 = 'published' THEN Now()
+				WHEN $2 = 'draft' THEN null
+				ELSE published_at
+			END,
+			publish_at = null,
+			updated_at = now()
+		WHERE deleted_at is null and id = $1 and status = ANY($3)
 	`
 
 	stmt, err := db.Prepare(sqlText)
@@ -372,7 +896,7 @@ func (r *postRepositoryImpl) Remove(id string) error {
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(id)
+	result, err := stmt.Exec(id, to, pq.Array(from))
 	if err != nil {
 		return err
 	}
@@ -383,12 +907,119 @@ func (r *postRepositoryImpl) Remove(id string) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrInvalidTransition
 	}
 
 	return nil
 }
 
+// Schedule: the post goes live at publishAt, only if it is in one of the statuses from
+func (r *postRepositoryImpl) Schedule(id string, from []string, publishAt time.Time) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sqlText := `
+		UPDATE tb_post SET
+			status = 'scheduled',
+			publish_at = $2,
+			updated_at = now()
+		WHERE deleted_at is null and id = $1 and status = ANY($3)
+	`
+
+	stmt, err := db.Prepare(sqlText)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.Exec(id, publishAt, pq.Array(from))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrInvalidTransition
+	}
+
+	return nil
+}
+
+// publishLock: key of the advisory lock of the scheduled publishing ("post" in ascii),
+// one replica publishes at a time
+const publishLock int64 = 0x706f7374
+
+// PublishScheduled: publishes the scheduled posts whose time has come and returns their ids,
+// when another replica holds the lock nothing is published and the list is empty
+func (r *postRepositoryImpl) PublishScheduled() ([]string, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// the lock is released with the transaction
+	var locked bool
+	err = tx.QueryRow("SELECT pg_try_advisory_xact_lock($1)", publishLock).Scan(&locked)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return []string{}, nil
+	}
+
+	// published_at is the scheduled time, even if the scheduler was stopped when it came
+	sqlText := `
+		UPDATE tb_post SET
+			status = 'published',
+			published_at = publish_at,
+			publish_at = null,
+			updated_at = now()
+		WHERE deleted_at is null and status = 'scheduled' and publish_at <= now()
+		RETURNING id
+	`
+
+	rows, err := tx.Query(sqlText)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 func NewPostRepository() postRepositoryInterface {
 	return &postRepositoryImpl{}
 }
@@ -2,19 +2,20 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
-	"fmt"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/markdown"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 )
 
 type responseCommentRepositoryInterface interface {
 	Store(entity *models.ResponseComment) error
-	List(commentID string, offset, limit, page int) ([]models.ResponseComment, error)
+	List(commentID string, p *pagination.Params) ([]models.ResponseComment, *pagination.Page, error)
 	Count(commentID string) (int, error)
-	ListUser(userID string, offset, limit, page int) ([]models.ResponseComment, error)
+	ListUser(userID string, p *pagination.Params) ([]models.ResponseComment, *pagination.Page, error)
 	CountUser(userID string) (int, error)
 	Find(responseCommentID string) (*models.ResponseComment, error)
 	Update(entity *models.ResponseComment) error
@@ -27,15 +28,19 @@ func (r *responseCommentRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Re
 	ID := sql.NullString{}
 	Title := sql.NullString{}
 	Content := sql.NullString{}
+	ContentHTML := sql.NullString{}
 	CommentID := sql.NullString{}
 	UserID := sql.NullString{}
+	CreatedAt := sql.NullTime{}
 
 	err := rows.Scan(
 		&ID,
 		&Title,
 		&Content,
+		&ContentHTML,
 		&CommentID,
 		&UserID,
+		&CreatedAt,
 	)
 
 	if err != nil {
@@ -56,6 +61,16 @@ func (r *responseCommentRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Re
 		responseCommentEntity.Content = Content.String
 	}
 
+	// the responses saved before the markdown have no html, it's rendered on the read
+	if ContentHTML.Valid {
+		responseCommentEntity.ContentHTML = ContentHTML.String
+	} else {
+		responseCommentEntity.ContentHTML, err = markdown.RenderRestricted(responseCommentEntity.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if CommentID.Valid {
 		responseCommentEntity.CommentID = CommentID.String
 	}
@@ -64,9 +79,18 @@ func (r *responseCommentRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Re
 		responseCommentEntity.UserID = UserID.String
 	}
 
+	if CreatedAt.Valid {
+		responseCommentEntity.CreatedAt = CreatedAt.Time
+	}
+
 	return responseCommentEntity, nil
 }
 
+// responseCommentKey: position of a response in the lists' order
+func responseCommentKey(response models.ResponseComment) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: response.CreatedAt, ID: response.ResponseCommentID}
+}
+
 func (r *responseCommentRepositoryImpl) Store(entity *models.ResponseComment) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
@@ -76,9 +100,9 @@ func (r *responseCommentRepositoryImpl) Store(entity *models.ResponseComment) er
 
 	sqlText := `
 		insert into tb_response_comment
-		(id, title, content, comment_cid, user_uid)
+		(id, title, content, content_html, comment_cid, user_uid)
 		values
-		($1,$2,	$3, $4, $5)
+		($1,$2,	$3, $4, $5, $6)
 	`
 
 	stmt, err := db.Prepare(sqlText)
@@ -86,7 +110,7 @@ func (r *responseCommentRepositoryImpl) Store(entity *models.ResponseComment) er
 		return err
 	}
 
-	result, err := stmt.Exec(entity.ResponseCommentID, entity.Title, entity.Content, entity.CommentID, entity.UserID)
+	result, err := stmt.Exec(entity.ResponseCommentID, entity.Title, entity.Content, entity.ContentHTML, entity.CommentID, entity.UserID)
 	if err != nil {
 		return err
 	}
@@ -98,33 +122,33 @@ func (r *responseCommentRepositoryImpl) Store(entity *models.ResponseComment) er
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
 }
-func (r *responseCommentRepositoryImpl) List(commentID string, offset, limit, page int) ([]models.ResponseComment, error) {
+func (r *responseCommentRepositoryImpl) List(commentID string, p *pagination.Params) ([]models.ResponseComment, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText, args := p.Query(`
 	SELECT 
 		id,
 		title,
 		content,
+		content_html,
 		comment_cid,
-		user_uid
+		user_uid,
+		created_at
 	FROM tb_response_comment
-	WHERE deleted_at is null and comment_cid = $1
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	WHERE deleted_at is null and comment_cid = $1`, "created_at", "id", []interface{}{commentID})
 
-	rows, err := db.Query(sqlText, commentID)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -132,12 +156,13 @@ func (r *responseCommentRepositoryImpl) List(commentID string, offset, limit, pa
 	for rows.Next() {
 		responseComment, err := r.scanIterator(rows)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		responseComments = append(responseComments, *responseComment)
 	}
 
-	return responseComments, nil
+	responseComments, page := pagination.Slice(p, responseComments, responseCommentKey)
+	return responseComments, page, nil
 }
 func (r *responseCommentRepositoryImpl) Count(commentID string) (int, error) {
 	db, err := databaseConn.Connect()
@@ -162,28 +187,28 @@ func (r *responseCommentRepositoryImpl) Count(commentID string) (int, error) {
 
 	return count, nil
 }
-func (r *responseCommentRepositoryImpl) ListUser(userID string, offset, limit, page int) ([]models.ResponseComment, error) {
+func (r *responseCommentRepositoryImpl) ListUser(userID string, p *pagination.Params) ([]models.ResponseComment, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText, args := p.Query(`
 	SELECT 
 		id,
 		title,
 		content,
+		content_html,
 		comment_cid,
-		user_uid
+		user_uid,
+		created_at
 	FROM tb_response_comment
-	WHERE deleted_at is null and user_uid = $1
-	LIMIT %v OFFSET ((%v - 1) * %v) + %v
-`, limit, page, limit, offset)
+	WHERE deleted_at is null and user_uid = $1`, "created_at", "id", []interface{}{userID})
 
-	rows, err := db.Query(sqlText, userID)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -191,12 +216,13 @@ func (r *responseCommentRepositoryImpl) ListUser(userID string, offset, limit, p
 	for rows.Next() {
 		responseComment, err := r.scanIterator(rows)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		responseComments = append(responseComments, *responseComment)
 	}
 
-	return responseComments, nil
+	responseComments, page := pagination.Slice(p, responseComments, responseCommentKey)
+	return responseComments, page, nil
 }
 func (r *responseCommentRepositoryImpl) CountUser(userID string) (int, error) {
 	db, err := databaseConn.Connect()
@@ -234,8 +260,10 @@ func (r *responseCommentRepositoryImpl) Find(responseCommentID string) (*models.
 			id,
 			title,
 			content,
+			content_html,
 			comment_cid,
-			user_uid
+			user_uid,
+			created_at
 		FROM tb_response_comment
 		WHERE deleted_at is null and id = $1
 	`
@@ -254,7 +282,7 @@ func (r *responseCommentRepositoryImpl) Find(responseCommentID string) (*models.
 		return responseComment, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
 }
 
 func (r *responseCommentRepositoryImpl) Update(entity *models.ResponseComment) error {
@@ -268,6 +296,7 @@ func (r *responseCommentRepositoryImpl) Update(entity *models.ResponseComment) e
 		UPDATE tb_response_comment SET
 			title = $2,
 			content = $3,
+			content_html = $4,
 			updated_at = now()
 		WHERE deleted_at is null and id = $1
 	`
@@ -278,7 +307,7 @@ func (r *responseCommentRepositoryImpl) Update(entity *models.ResponseComment) e
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(entity.ResponseCommentID, entity.Title, entity.Content)
+	result, err := stmt.Exec(entity.ResponseCommentID, entity.Title, entity.Content, entity.ContentHTML)
 	if err != nil {
 		return err
 	}
@@ -289,7 +318,7 @@ func (r *responseCommentRepositoryImpl) Update(entity *models.ResponseComment) e
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -324,7 +353,7 @@ func (r *responseCommentRepositoryImpl) Remove(responseCommentID string) error {
 	}
 
 	if rowsAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
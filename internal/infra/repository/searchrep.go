@@ -0,0 +1,393 @@
+package repository
+
+import (
+	"database/sql"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/lib/pq"
+)
+
+type searchRepositoryInterface interface {
+	Posts(query string, p *pagination.Params) ([]models.SearchPost, *pagination.Page, error)
+	CountPosts(query string) (int, error)
+	Comments(query string, p *pagination.Params) ([]models.SearchComment, *pagination.Page, error)
+	CountComments(query string) (int, error)
+	PostComments(query string, postIDs []string, perPost int) ([]models.SearchComment, error)
+}
+
+type searchRepositoryImpl struct{}
+
+const (
+	// searchQuery: the words of the search stemmed in portuguese and in english, $1 is the text typed by the user
+	searchQuery = `(SELECT websearch_to_tsquery('portuguese', $1) || websearch_to_tsquery('english', $1) AS query) q`
+
+	// headlineStart and headlineStop: marks of the matched words, control chars can't be typed in a post,
+	// so the text can be escaped before the marks become html
+	headlineStart = "\x01"
+	headlineStop  = "\x02"
+
+	titleHeadline   = "HighlightAll=true, StartSel=" + headlineStart + ", StopSel=" + headlineStop
+	contentHeadline = "MaxFragments=2, MaxWords=35, MinWords=15, FragmentDelimiter=\" … \", StartSel=" + headlineStart + ", StopSel=" + headlineStop
+)
+
+var headlineMarks = strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>")
+
+// markHeadline: escapes the headline and marks the matched words with <mark>
+func markHeadline(headline string) string {
+	return headlineMarks.Replace(html.EscapeString(headline))
+}
+
+// searchKey: position of a result in the order of the rank
+func searchKey(rank float64, c pagination.Cursor) pagination.Cursor {
+	c.Value = strconv.FormatFloat(rank, 'g', -1, 64)
+	return c
+}
+
+func (r *searchRepositoryImpl) scanPostIterator(rows *sql.Rows) (*models.SearchPost, error) {
+	postId := sql.NullString{}
+	title := sql.NullString{}
+	postSlug := sql.NullString{}
+	content := sql.NullString{}
+	createdAt := sql.NullTime{}
+	authorNick := sql.NullString{}
+	authorName := sql.NullString{}
+	likes := sql.NullInt64{}
+	rank := sql.NullFloat64{}
+	titleHeadline := sql.NullString{}
+	contentHeadline := sql.NullString{}
+
+	err := rows.Scan(
+		&postId,
+		&title,
+		&postSlug,
+		&content,
+		&createdAt,
+		&authorNick,
+		&authorName,
+		&likes,
+		&rank,
+		&titleHeadline,
+		&contentHeadline,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	post := new(models.SearchPost)
+
+	if postId.Valid {
+		post.PostID = postId.String
+	}
+
+	if title.Valid {
+		post.Title = title.String
+	}
+
+	if postSlug.Valid {
+		post.Slug = postSlug.String
+	}
+
+	if content.Valid {
+		post.Content = content.String
+	}
+
+	if createdAt.Valid {
+		post.CreatedAt = createdAt.Time
+	}
+
+	if authorNick.Valid {
+		post.AuthorNick = authorNick.String
+	}
+
+	if authorName.Valid {
+		post.AuthorName = authorName.String
+	}
+
+	if likes.Valid {
+		post.Likes = int(likes.Int64)
+	}
+
+	if rank.Valid {
+		post.Rank = rank.Float64
+	}
+
+	if titleHeadline.Valid {
+		post.TitleHeadline = markHeadline(titleHeadline.String)
+	}
+
+	if contentHeadline.Valid {
+		post.ContentHeadline = markHeadline(contentHeadline.String)
+	}
+
+	return post, nil
+}
+
+func (r *searchRepositoryImpl) scanCommentIterator(rows *sql.Rows) (*models.SearchComment, error) {
+	commentID := sql.NullString{}
+	title := sql.NullString{}
+	content := sql.NullString{}
+	userID := sql.NullString{}
+	postID := sql.NullString{}
+	createdAt := sql.NullTime{}
+	rank := sql.NullFloat64{}
+	contentHeadline := sql.NullString{}
+
+	err := rows.Scan(
+		&commentID,
+		&title,
+		&content,
+		&userID,
+		&postID,
+		&createdAt,
+		&rank,
+		&contentHeadline,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comment := new(models.SearchComment)
+
+	if commentID.Valid {
+		comment.CommentID = commentID.String
+	}
+
+	if title.Valid {
+		comment.Title = title.String
+	}
+
+	if content.Valid {
+		comment.Content = content.String
+	}
+
+	if userID.Valid {
+		comment.UserID = userID.String
+	}
+
+	if postID.Valid {
+		comment.PostID = postID.String
+	}
+
+	if createdAt.Valid {
+		comment.CreatedAt = createdAt.Time
+	}
+
+	if rank.Valid {
+		comment.Rank = rank.Float64
+	}
+
+	if contentHeadline.Valid {
+		comment.ContentHeadline = markHeadline(contentHeadline.String)
+	}
+
+	return comment, nil
+}
+
+func (r *searchRepositoryImpl) Posts(query string, p *pagination.Params) ([]models.SearchPost, *pagination.Page, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	// the title weighs more than the content (setweight A and B of the search column)
+	rank := "ts_rank(p.search, q.query)::float8"
+	sqlText, args := p.QuerySorted(`
+	SELECT
+		p.id,
+		p.title,
+		p.slug,
+		p.content,
+		p.created_at,
+		`+postAuthor+`,
+		`+postLikes+`,
+		`+rank+`,
+		ts_headline('portuguese', p.title, q.query, $2),
+		ts_headline('portuguese', p.content, q.query, $3)
+	FROM tb_post p
+	`+postAuthorJoin+`, `+searchQuery+`
+	WHERE p.deleted_at is null and p.status = 'published' and p.search @@ q.query`, rank, "p.created_at", "p.id", []interface{}{query, titleHeadline, contentHeadline})
+
+	rows, err := db.Query(sqlText, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	posts := make([]models.SearchPost, 0)
+	for rows.Next() {
+		post, err := r.scanPostIterator(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		posts = append(posts, *post)
+	}
+
+	posts, page := pagination.Slice(p, posts, func(post models.SearchPost) pagination.Cursor {
+		return searchKey(post.Rank, postKey(post.Post))
+	})
+	return posts, page, nil
+}
+
+func (r *searchRepositoryImpl) CountPosts(query string) (int, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			COUNT(*)
+		FROM tb_post p, ` + searchQuery + `
+		WHERE p.deleted_at is null and p.status = 'published' and p.search @@ q.query
+	`
+
+	var count int
+	row := db.QueryRow(sqlText, query)
+	err = row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// Comments: the comments of the published posts that match the query, whether their posts match it or not
+func (r *searchRepositoryImpl) Comments(query string, p *pagination.Params) ([]models.SearchComment, *pagination.Page, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	rank := "ts_rank(c.search, q.query)::float8"
+	sqlText, args := p.QuerySorted(`
+	SELECT
+		c.id,
+		c.title,
+		c.content,
+		c.user_uid,
+		c.post_pid,
+		c.created_at,
+		`+rank+`,
+		ts_headline('portuguese', c.content, q.query, $2)
+	FROM tb_comment c
+	INNER JOIN tb_post p ON p.id = c.post_pid, `+searchQuery+`
+	WHERE c.deleted_at is null and p.deleted_at is null and p.status = 'published' and c.search @@ q.query`, rank, "c.created_at", "c.id", []interface{}{query, contentHeadline})
+
+	rows, err := db.Query(sqlText, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]models.SearchComment, 0)
+	for rows.Next() {
+		comment, err := r.scanCommentIterator(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		comments = append(comments, *comment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	comments, page := pagination.Slice(p, comments, func(comment models.SearchComment) pagination.Cursor {
+		return searchKey(comment.Rank, commentKey(comment.Comment))
+	})
+	return comments, page, nil
+}
+
+func (r *searchRepositoryImpl) CountComments(query string) (int, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			COUNT(*)
+		FROM tb_comment c
+		INNER JOIN tb_post p ON p.id = c.post_pid, ` + searchQuery + `
+		WHERE c.deleted_at is null and p.deleted_at is null and p.status = 'published' and c.search @@ q.query
+	`
+
+	var count int
+	row := db.QueryRow(sqlText, query)
+	err = row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// PostComments: the comments of the posts that match the query, at most perPost by post, the best first
+func (r *searchRepositoryImpl) PostComments(query string, postIDs []string, perPost int) ([]models.SearchComment, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			c.id,
+			c.title,
+			c.content,
+			c.user_uid,
+			c.post_pid,
+			c.created_at,
+			c.rank,
+			ts_headline('portuguese', c.content, q.query, $2)
+		FROM (
+			SELECT
+				c.id,
+				c.title,
+				c.content,
+				c.user_uid,
+				c.post_pid,
+				c.created_at,
+				ts_rank(c.search, q.query)::float8 AS rank,
+				row_number() OVER (PARTITION BY c.post_pid ORDER BY ts_rank(c.search, q.query) DESC, c.created_at DESC, c.id) AS n
+			FROM tb_comment c, ` + searchQuery + `
+			WHERE c.deleted_at is null and c.post_pid = ANY($3) and c.search @@ q.query
+		) c, ` + searchQuery + `
+		WHERE c.n <= $4
+		ORDER BY c.post_pid, c.n
+	`
+
+	rows, err := db.Query(sqlText, query, contentHeadline, pq.Array(postIDs), perPost)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]models.SearchComment, 0)
+	for rows.Next() {
+		comment, err := r.scanCommentIterator(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, *comment)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func NewSearchRepository() searchRepositoryInterface {
+	return &searchRepositoryImpl{}
+}
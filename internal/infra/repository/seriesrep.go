@@ -0,0 +1,575 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/slug"
+	"github.com/lib/pq"
+)
+
+type seriesRepositoryInterface interface {
+	Store(entity *models.Series) error
+	List(p *pagination.Params) ([]models.Series, *pagination.Page, error)
+	Count() (int, error)
+	Find(seriesID string) (*models.Series, error)
+	FindSlug(slug string) (*models.Series, error)
+	FreeSlug(base, seriesID string) (string, error)
+	Update(entity *models.Series) error
+	Remove(seriesID string) error
+	Parts(seriesID string) ([]models.SeriesPart, error)
+	SetParts(seriesID string, postIDs []string) error
+	Owners(postIDs []string) (map[string]string, error)
+	Post(postID string) (*models.PostSeries, error)
+}
+
+type seriesRepositoryImpl struct{}
+
+// seriesPosts: published parts of the series s
+const seriesPosts = `(
+		SELECT COUNT(*)
+		FROM tb_series_post sp
+		INNER JOIN tb_post p ON p.id = sp.post_pid
+		WHERE sp.series_sid = s.id and p.deleted_at is null and p.status = 'published'
+	)`
+
+// seriesColumns: the columns read by scanIterator, selected from tb_series s
+const seriesColumns = `
+		s.id,
+		s.title,
+		s.slug,
+		s.description,
+		s.user_uid,
+		s.created_at,
+		` + seriesPosts
+
+// seriesParts: the published parts with their position among the published ones
+const seriesParts = `
+		SELECT
+			sp.series_sid,
+			p.id,
+			p.title,
+			p.slug,
+			p.published_at,
+			row_number() OVER (PARTITION BY sp.series_sid ORDER BY sp.position) AS position
+		FROM tb_series_post sp
+		INNER JOIN tb_post p ON p.id = sp.post_pid
+		WHERE p.deleted_at is null and p.status = 'published'`
+
+func (r *seriesRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Series, error) {
+	seriesID := sql.NullString{}
+	title := sql.NullString{}
+	seriesSlug := sql.NullString{}
+	description := sql.NullString{}
+	userID := sql.NullString{}
+	createdAt := sql.NullTime{}
+	posts := sql.NullInt64{}
+
+	err := rows.Scan(
+		&seriesID,
+		&title,
+		&seriesSlug,
+		&description,
+		&userID,
+		&createdAt,
+		&posts,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	series := new(models.Series)
+
+	if seriesID.Valid {
+		series.SeriesID = seriesID.String
+	}
+
+	if title.Valid {
+		series.Title = title.String
+	}
+
+	if seriesSlug.Valid {
+		series.Slug = seriesSlug.String
+	}
+
+	if description.Valid {
+		series.Description = description.String
+	}
+
+	if userID.Valid {
+		series.UserID = userID.String
+	}
+
+	if createdAt.Valid {
+		series.CreatedAt = createdAt.Time
+	}
+
+	if posts.Valid {
+		series.Posts = int(posts.Int64)
+	}
+
+	return series, nil
+}
+
+// seriesKey: position of a series in the lists' order
+func seriesKey(series models.Series) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: series.CreatedAt, ID: series.SeriesID}
+}
+
+func (r *seriesRepositoryImpl) Store(entity *models.Series) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sqlText := `
+		insert into tb_series
+		(id, title, slug, description, user_uid)
+		values
+		($1, $2, $3, $4, $5)
+	`
+
+	result, err := db.Exec(sqlText, entity.SeriesID, entity.Title, entity.Slug, sql.NullString{String: entity.Description, Valid: entity.Description != ""}, entity.UserID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrStoreFailed
+	}
+
+	return nil
+}
+
+// List: the series with published parts, the empty ones don't exist for the readers
+func (r *seriesRepositoryImpl) List(p *pagination.Params) ([]models.Series, *pagination.Page, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer db.Close()
+
+	sqlText, args := p.Query(`
+	SELECT `+seriesColumns+`
+	FROM tb_series s
+	WHERE s.deleted_at is null and `+seriesPosts+` > 0`, "s.created_at", "s.id", nil)
+
+	rows, err := db.Query(sqlText, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	list := make([]models.Series, 0)
+	for rows.Next() {
+		series, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		list = append(list, *series)
+	}
+
+	list, page := pagination.Slice(p, list, seriesKey)
+	return list, page, nil
+}
+
+func (r *seriesRepositoryImpl) Count() (int, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			COUNT(*)
+		FROM tb_series s
+		WHERE s.deleted_at is null and ` + seriesPosts + ` > 0
+	`
+
+	var count int
+	row := db.QueryRow(sqlText)
+	err = row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *seriesRepositoryImpl) find(where string, arg string) (*models.Series, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT ` + seriesColumns + `
+		FROM tb_series s
+		WHERE s.deleted_at is null and ` + where
+
+	rows, err := db.Query(sqlText, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		series, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		return series, nil
+	}
+
+	return nil, domainErrors.ErrNotFound
+}
+
+func (r *seriesRepositoryImpl) Find(seriesID string) (*models.Series, error) {
+	return r.find("s.id = $1", seriesID)
+}
+
+func (r *seriesRepositoryImpl) FindSlug(slug string) (*models.Series, error) {
+	return r.find("s.slug = $1", slug)
+}
+
+// FreeSlug: base, or base with the first free number, not used by another series
+func (r *seriesRepositoryImpl) FreeSlug(base, seriesID string) (string, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	// the slugs are made of letters, digits and hyphens, there is no wildcard of like in them
+	rows, err := db.Query(`
+		SELECT
+			slug
+		FROM tb_series
+		WHERE deleted_at is null and id <> $2 and (slug = $1 or slug like $1 || '-%')
+	`, base, seriesID)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var s string
+		err = rows.Scan(&s)
+		if err != nil {
+			return "", err
+		}
+		taken[s] = true
+	}
+	if err = rows.Err(); err != nil {
+		return "", err
+	}
+
+	return slug.Unique(base, func(s string) bool {
+		return taken[s]
+	}), nil
+}
+
+func (r *seriesRepositoryImpl) Update(entity *models.Series) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sqlText := `
+		UPDATE tb_series SET
+			title = $2,
+			description = $3,
+			updated_at = now()
+		WHERE deleted_at is null and id = $1
+	`
+
+	result, err := db.Exec(sqlText, entity.SeriesID, entity.Title, sql.NullString{String: entity.Description, Valid: entity.Description != ""})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
+	}
+
+	return nil
+}
+
+// Remove: the posts of the series are kept, out of any series
+func (r *seriesRepositoryImpl) Remove(seriesID string) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE tb_series SET deleted_at = now() WHERE deleted_at is null and id = $1", seriesID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
+	}
+
+	_, err = tx.Exec("DELETE FROM tb_series_post WHERE series_sid = $1", seriesID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Parts: the published parts of the series in their order
+func (r *seriesRepositoryImpl) Parts(seriesID string) ([]models.SeriesPart, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT id, title, slug, published_at, position
+		FROM (` + seriesParts + ` and sp.series_sid = $1
+		) parts
+		ORDER BY position
+	`
+
+	rows, err := db.Query(sqlText, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parts := make([]models.SeriesPart, 0)
+	for rows.Next() {
+		part, err := scanPart(rows)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, *part)
+	}
+
+	return parts, rows.Err()
+}
+
+// scanPart: post id, title, slug, published_at and position
+func scanPart(rows *sql.Rows) (*models.SeriesPart, error) {
+	postID := sql.NullString{}
+	title := sql.NullString{}
+	postSlug := sql.NullString{}
+	publishedAt := sql.NullTime{}
+	position := sql.NullInt64{}
+
+	err := rows.Scan(&postID, &title, &postSlug, &publishedAt, &position)
+	if err != nil {
+		return nil, err
+	}
+
+	part := &models.SeriesPart{
+		PostID:   postID.String,
+		Title:    title.String,
+		Slug:     postSlug.String,
+		Position: int(position.Int64),
+	}
+	if publishedAt.Valid {
+		part.PublishedAt = &publishedAt.Time
+	}
+	return part, nil
+}
+
+// SetParts: the posts become the parts of the series in their order, all at once
+func (r *seriesRepositoryImpl) SetParts(seriesID string, postIDs []string) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM tb_series_post WHERE series_sid = $1", seriesID)
+	if err != nil {
+		return err
+	}
+
+	for i, v := range postIDs {
+		_, err = tx.Exec(`
+			insert into tb_series_post
+			(series_sid, post_pid, position)
+			values
+			($1, $2, $3)
+		`, seriesID, v, i+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec("UPDATE tb_series SET updated_at = now() WHERE id = $1", seriesID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Owners: the series of each of the posts that is in one, by post id
+func (r *seriesRepositoryImpl) Owners(postIDs []string) (map[string]string, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`
+		SELECT
+			sp.post_pid,
+			sp.series_sid
+		FROM tb_series_post sp
+		INNER JOIN tb_series s ON s.id = sp.series_sid
+		WHERE s.deleted_at is null and sp.post_pid = ANY($1)
+	`, pq.Array(postIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	owners := make(map[string]string)
+	for rows.Next() {
+		var postID, seriesID string
+		err = rows.Scan(&postID, &seriesID)
+		if err != nil {
+			return nil, err
+		}
+		owners[postID] = seriesID
+	}
+
+	return owners, rows.Err()
+}
+
+// Post: the series of a published post with its neighbours among the published parts
+func (r *seriesRepositoryImpl) Post(postID string) (*models.PostSeries, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			s.id,
+			s.title,
+			s.slug,
+			parts.id,
+			parts.title,
+			parts.slug,
+			parts.published_at,
+			parts.position
+		FROM (` + seriesParts + `
+		) parts
+		INNER JOIN tb_series s ON s.id = parts.series_sid
+		WHERE s.deleted_at is null and s.id = (SELECT series_sid FROM tb_series_post WHERE post_pid = $1)
+		ORDER BY parts.position
+	`
+
+	rows, err := db.Query(sqlText, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var series *models.PostSeries
+	parts := make([]models.SeriesPart, 0)
+	for rows.Next() {
+		seriesID := sql.NullString{}
+		title := sql.NullString{}
+		seriesSlug := sql.NullString{}
+		partID := sql.NullString{}
+		partTitle := sql.NullString{}
+		partSlug := sql.NullString{}
+		publishedAt := sql.NullTime{}
+		position := sql.NullInt64{}
+
+		err = rows.Scan(&seriesID, &title, &seriesSlug, &partID, &partTitle, &partSlug, &publishedAt, &position)
+		if err != nil {
+			return nil, err
+		}
+
+		series = &models.PostSeries{
+			SeriesID: seriesID.String,
+			Title:    title.String,
+			Slug:     seriesSlug.String,
+		}
+		part := models.SeriesPart{
+			PostID:   partID.String,
+			Title:    partTitle.String,
+			Slug:     partSlug.String,
+			Position: int(position.Int64),
+		}
+		if publishedAt.Valid {
+			part.PublishedAt = &publishedAt.Time
+		}
+		parts = append(parts, part)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, v := range parts {
+		if v.PostID != postID {
+			continue
+		}
+		series.Position = v.Position
+		series.Parts = len(parts)
+		if i > 0 {
+			series.Previous = &parts[i-1]
+		}
+		if i < len(parts)-1 {
+			series.Next = &parts[i+1]
+		}
+		return series, nil
+	}
+
+	// the post isn't in a series or isn't published
+	return nil, domainErrors.ErrNotFound
+}
+
+func NewSeriesRepository() seriesRepositoryInterface {
+	return &seriesRepositoryImpl{}
+}
@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/lib/pq"
+)
+
+type tagRepositoryInterface interface {
+	Cloud(limit int) ([]models.Tag, error)
+	Post(postID string) ([]models.Tag, error)
+	Find(tagID string) (*models.Tag, error)
+	FindSlug(slug string) (*models.Tag, error)
+	Update(entity *models.Tag) error
+	Merge(tagID string, from []string) error
+}
+
+type tagRepositoryImpl struct{}
+
+// tagPosts: published posts of the tag t
+const tagPosts = `(
+		SELECT COUNT(*)
+		FROM tb_post_tag pt
+		INNER JOIN tb_post p ON p.id = pt.post_pid
+		WHERE pt.tag_tid = t.id and p.deleted_at is null and p.status = 'published'
+	)`
+
+func (r *tagRepositoryImpl) scanIterator(rows *sql.Rows) (*models.Tag, error) {
+	tagID := sql.NullString{}
+	name := sql.NullString{}
+	slug := sql.NullString{}
+	createdAt := sql.NullTime{}
+	posts := sql.NullInt64{}
+
+	err := rows.Scan(
+		&tagID,
+		&name,
+		&slug,
+		&createdAt,
+		&posts,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tag := new(models.Tag)
+
+	if tagID.Valid {
+		tag.TagID = tagID.String
+	}
+
+	if name.Valid {
+		tag.Name = name.String
+	}
+
+	if slug.Valid {
+		tag.Slug = slug.String
+	}
+
+	if createdAt.Valid {
+		tag.CreatedAt = createdAt.Time
+	}
+
+	if posts.Valid {
+		tag.Posts = int(posts.Int64)
+	}
+
+	return tag, nil
+}
+
+// storeTags: links the post to its tags, creating the new ones, the links to the other tags are removed
+func storeTags(tx *sql.Tx, post *models.Post) error {
+	ids := make([]string, 0, len(post.Tags))
+	for i := range post.Tags {
+		// a tag with the slug keeps its id and the first name it was given
+		err := tx.QueryRow(`
+			insert into tb_tag
+			(id, name, slug)
+			values
+			($1, $2, $3)
+			on conflict (slug) where deleted_at is null do update set slug = excluded.slug
+			returning id, name
+		`, post.Tags[i].TagID, post.Tags[i].Name, post.Tags[i].Slug).Scan(&post.Tags[i].TagID, &post.Tags[i].Name)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, post.Tags[i].TagID)
+	}
+
+	_, err := tx.Exec("DELETE FROM tb_post_tag WHERE post_pid = $1 and not (tag_tid = ANY($2))", post.PostID, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		_, err = tx.Exec(`
+			insert into tb_post_tag
+			(post_pid, tag_tid)
+			values
+			($1, $2)
+			on conflict do nothing
+		`, post.PostID, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Cloud: the tags with published posts, the most used first
+func (r *tagRepositoryImpl) Cloud(limit int) ([]models.Tag, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT * FROM (
+			SELECT
+				t.id,
+				t.name,
+				t.slug,
+				t.created_at,
+				` + tagPosts + ` AS posts
+			FROM tb_tag t
+			WHERE t.deleted_at is null
+		) t
+		WHERE t.posts > 0
+		ORDER BY t.posts desc, t.slug
+		LIMIT $1
+	`
+
+	rows, err := db.Query(sqlText, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]models.Tag, 0)
+	for rows.Next() {
+		tag, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// Post: the tags of the post in alphabetical order
+func (r *tagRepositoryImpl) Post(postID string) ([]models.Tag, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			t.id,
+			t.name,
+			t.slug,
+			t.created_at,
+			` + tagPosts + `
+		FROM tb_tag t
+		INNER JOIN tb_post_tag ptt ON ptt.tag_tid = t.id
+		WHERE t.deleted_at is null and ptt.post_pid = $1
+		ORDER BY t.slug
+	`
+
+	rows, err := db.Query(sqlText, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]models.Tag, 0)
+	for rows.Next() {
+		tag, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func (r *tagRepositoryImpl) find(where string, arg string) (*models.Tag, error) {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	sqlText := `
+		SELECT
+			t.id,
+			t.name,
+			t.slug,
+			t.created_at,
+			` + tagPosts + `
+		FROM tb_tag t
+		WHERE t.deleted_at is null and ` + where
+
+	rows, err := db.Query(sqlText, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		tag, err := r.scanIterator(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		return tag, nil
+	}
+
+	return nil, domainErrors.ErrNotFound
+}
+
+func (r *tagRepositoryImpl) Find(tagID string) (*models.Tag, error) {
+	return r.find("t.id = $1", tagID)
+}
+
+func (r *tagRepositoryImpl) FindSlug(slug string) (*models.Tag, error) {
+	return r.find("t.slug = $1", slug)
+}
+
+// Update: the new name and slug, the slug must be free
+func (r *tagRepositoryImpl) Update(entity *models.Tag) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	sqlText := `
+		UPDATE tb_tag SET
+			name = $2,
+			slug = $3,
+			updated_at = now()
+		WHERE deleted_at is null and id = $1
+	`
+
+	result, err := db.Exec(sqlText, entity.TagID, entity.Name, entity.Slug)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
+	}
+
+	return nil
+}
+
+// Merge: the posts of the tags from go to the tag, and the tags from are removed
+func (r *tagRepositoryImpl) Merge(tagID string, from []string) error {
+	db, err := databaseConn.Connect()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE tb_tag SET deleted_at = now() WHERE deleted_at is null and id = ANY($1)", pq.Array(from))
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != int64(len(from)) {
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
+	}
+
+	_, err = tx.Exec(`
+		insert into tb_post_tag
+		(post_pid, tag_tid)
+		SELECT post_pid, $1 FROM tb_post_tag WHERE tag_tid = ANY($2)
+		on conflict do nothing
+	`, tagID, pq.Array(from))
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM tb_post_tag WHERE tag_tid = ANY($1)", pq.Array(from))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func NewTagRepository() tagRepositoryInterface {
+	return &tagRepositoryImpl{}
+}
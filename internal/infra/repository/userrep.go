@@ -2,19 +2,19 @@ package repository
 
 import (
 	"database/sql"
-	"errors"
-	"fmt"
 
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/databaseConn"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 )
 
 type userRepositoryInterface interface {
 	Store(entity *models.User) error
-	List(offset, limit, page int) ([]models.User, error)
+	List(p *pagination.Params) ([]models.User, *pagination.Page, error)
 	Count() (int, error)
-	ListName(name string, offset, limit, page int) ([]models.User, error)
+	ListName(name string, p *pagination.Params) ([]models.User, *pagination.Page, error)
 	CountListName(name string) (int, error)
 	Find(id string) (*models.User, error)
 	Update(user *models.User) error
@@ -24,7 +24,7 @@ type userRepositoryInterface interface {
 	FindByEmailOrNick(emailOrNick string) (*models.User, error)
 	UpdatePassword(newPassword, userID string) error
 	FindPassword(userID string) (string, error)
-	ListUserAdm(kind string, offset, limit, page int) ([]models.User, error)
+	ListUserAdm(kind string, p *pagination.Params) ([]models.User, *pagination.Page, error)
 }
 
 type userRepositoryImpl struct{}
@@ -37,6 +37,8 @@ func (r *userRepositoryImpl) scanIterator(rows *sql.Rows, secretIsReq bool) (*mo
 	nick := sql.NullString{}
 	email := sql.NullString{}
 	kind := sql.NullString{}
+	language := sql.NullString{}
+	createdAt := sql.NullTime{}
 	secret := sql.NullString{}
 
 	var err error
@@ -49,6 +51,8 @@ func (r *userRepositoryImpl) scanIterator(rows *sql.Rows, secretIsReq bool) (*mo
 			&nick,
 			&email,
 			&kind,
+			&language,
+			&createdAt,
 			&secret,
 		)
 	} else {
@@ -60,6 +64,8 @@ func (r *userRepositoryImpl) scanIterator(rows *sql.Rows, secretIsReq bool) (*mo
 			&nick,
 			&email,
 			&kind,
+			&language,
+			&createdAt,
 		)
 	}
 
@@ -97,6 +103,14 @@ func (r *userRepositoryImpl) scanIterator(rows *sql.Rows, secretIsReq bool) (*mo
 		userEntity.Kind = kind.String
 	}
 
+	if language.Valid {
+		userEntity.Language = language.String
+	}
+
+	if createdAt.Valid {
+		userEntity.CreatedAt = createdAt.Time
+	}
+
 	if secretIsReq {
 		if secret.Valid {
 			userEntity.Secret = secret.String
@@ -106,6 +120,11 @@ func (r *userRepositoryImpl) scanIterator(rows *sql.Rows, secretIsReq bool) (*mo
 	return userEntity, nil
 }
 
+// userKey: position of a user in the lists' order
+func userKey(user models.User) pagination.Cursor {
+	return pagination.Cursor{CreatedAt: user.CreatedAt, ID: user.UserID}
+}
+
 func (r *userRepositoryImpl) CheckEmail(email string) error {
 	db, err := databaseConn.Connect()
 	if err != nil {
@@ -127,7 +146,7 @@ func (r *userRepositoryImpl) CheckEmail(email string) error {
 	defer row.Close()
 
 	if row.Next() {
-		return errors.New(messages.EmailIsRegister)
+		return domainErrors.ErrEmailTaken
 	}
 
 	return nil
@@ -154,7 +173,7 @@ func (r *userRepositoryImpl) CheckNick(nick string) error {
 	defer row.Close()
 
 	if row.Next() {
-		return errors.New(messages.NickIsRegister)
+		return domainErrors.ErrNickTaken
 	}
 
 	return nil
@@ -167,15 +186,15 @@ func (r *userRepositoryImpl) Store(entity *models.User) error {
 	}
 	defer db.Close()
 	sqlText := `INSERT INTO tb_user 
-		(id, nick, email, secret, kind)
+		(id, nick, email, secret, kind, language)
 		VALUES
-		($1, $2, $3, $4, $5)
+		($1, $2, $3, $4, $5, $6)
 	 `
 	statement, err := db.Prepare(sqlText)
 	if err != nil {
 		return err
 	}
-	result, err := statement.Exec(entity.UserID, entity.Nick, entity.Email, entity.Secret, entity.Kind)
+	result, err := statement.Exec(entity.UserID, entity.Nick, entity.Email, entity.Secret, entity.Kind, entity.Language)
 	if err != nil {
 		return err
 	}
@@ -186,20 +205,20 @@ func (r *userRepositoryImpl) Store(entity *models.User) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.StoreError)
+		return domainErrors.ErrStoreFailed
 	}
 
 	return nil
 }
 
-func (r *userRepositoryImpl) List(offset, limit, page int) ([]models.User, error) {
+func (r *userRepositoryImpl) List(p *pagination.Params) ([]models.User, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText, args := p.Query(`
 			select
 				u.id,
 				p.id,
@@ -207,15 +226,16 @@ func (r *userRepositoryImpl) List(offset, limit, page int) ([]models.User, error
 				p.telephone,
 				u.nick,
 				u.email,
-				u.kind
+				u.kind,
+				u.language,
+				u.created_at
 			from tb_person p
 			INNER JOIN tb_user u ON u.id = p.user_uid
-			where p.deleted_at is null and u.deleted_at is null
-			LIMIT %v OFFSET ((%v - 1) * (%v)) + %v`, limit, page, limit, offset)
+			where p.deleted_at is null and u.deleted_at is null`, "u.created_at", "u.id", nil)
 
-	rows, err := db.Query(sqlText)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -223,12 +243,13 @@ func (r *userRepositoryImpl) List(offset, limit, page int) ([]models.User, error
 	for rows.Next() {
 		e, err := r.scanIterator(rows, false)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		entities = append(entities, *e)
 	}
 
-	return entities, nil
+	entities, page := pagination.Slice(p, entities, userKey)
+	return entities, page, nil
 }
 
 func (r *userRepositoryImpl) Count() (int, error) {
@@ -258,14 +279,16 @@ func (r *userRepositoryImpl) Count() (int, error) {
 	return countNumber, nil
 }
 
-func (r *userRepositoryImpl) ListName(name string, offset, limit, page int) ([]models.User, error) {
+func (r *userRepositoryImpl) ListName(name string, p *pagination.Params) ([]models.User, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	v := "%" + name + "%"
+
+	sqlText, args := p.Query(`
 			select
 				u.id,
 				p.id,
@@ -273,17 +296,16 @@ func (r *userRepositoryImpl) ListName(name string, offset, limit, page int) ([]m
 				p.telephone,
 				u.nick,
 				u.email,
-				u.kind
+				u.kind,
+				u.language,
+				u.created_at
 			from tb_person p
 			INNER JOIN tb_user u ON u.id = p.user_uid
-			where p.deleted_at is null and u.deleted_at is null and p.name like $1
-			LIMIT %v OFFSET ((%v - 1) * (%v)) + %v`, limit, page, limit, offset)
+			where p.deleted_at is null and u.deleted_at is null and p.name like $1`, "u.created_at", "u.id", []interface{}{v})
 
-	v := "%" + name + "%"
-
-	rows, err := db.Query(sqlText, v)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -291,12 +313,13 @@ func (r *userRepositoryImpl) ListName(name string, offset, limit, page int) ([]m
 	for rows.Next() {
 		e, err := r.scanIterator(rows, false)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		entities = append(entities, *e)
 	}
 
-	return entities, nil
+	entities, page := pagination.Slice(p, entities, userKey)
+	return entities, page, nil
 }
 
 func (r *userRepositoryImpl) CountListName(name string) (int, error) {
@@ -344,7 +367,9 @@ func (r *userRepositoryImpl) Find(id string) (*models.User, error) {
 			p.telephone,
 			u.nick,
 			u.email,
-			u.kind
+			u.kind,
+			u.language,
+			u.created_at
 		FROM tb_person p
 		INNER JOIN tb_user u ON u.id = p.user_uid
 		WHERE p.deleted_at is null and u.deleted_at is null
@@ -365,7 +390,7 @@ func (r *userRepositoryImpl) Find(id string) (*models.User, error) {
 		return user, nil
 	}
 
-	return nil, errors.New(messages.FindError)
+	return nil, domainErrors.ErrNotFound
 }
 
 func (r *userRepositoryImpl) Update(user *models.User) error {
@@ -380,6 +405,7 @@ func (r *userRepositoryImpl) Update(user *models.User) error {
 		nick = $2,
 		email = $3,
 		kind = $4,
+		language = $5,
 		updated_at = now()
 	where deleted_at is null and id = $1
 	`
@@ -388,7 +414,7 @@ func (r *userRepositoryImpl) Update(user *models.User) error {
 	if err != nil {
 		return err
 	}
-	result, err := statement.Exec(user.UserID, user.Nick, user.Email, user.Kind)
+	result, err := statement.Exec(user.UserID, user.Nick, user.Email, user.Kind, user.Language)
 	if err != nil {
 		return err
 	}
@@ -399,7 +425,7 @@ func (r *userRepositoryImpl) Update(user *models.User) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -431,7 +457,7 @@ func (r *userRepositoryImpl) Remove(id string) error {
 		return err
 	}
 	if rowAffected != 1 {
-		return errors.New(messages.RemoveError)
+		return domainErrors.ErrNotFound.WithMessage(messages.RemoveError)
 	}
 
 	return nil
@@ -453,6 +479,8 @@ func (r *userRepositoryImpl) FindByEmailOrNick(emailOrNick string) (*models.User
 		u.nick,
 		u.email,
 		u.kind,
+		u.language,
+		u.created_at,
 		u.secret
 	FROM tb_person p
 	INNER JOIN tb_user u ON u.id = p.user_uid
@@ -474,7 +502,7 @@ func (r *userRepositoryImpl) FindByEmailOrNick(emailOrNick string) (*models.User
 		return user, nil
 	}
 
-	return nil, errors.New(messages.UserNotExists)
+	return nil, domainErrors.ErrUserNotFound
 }
 
 func (r *userRepositoryImpl) UpdatePassword(newPassword, userID string) error {
@@ -510,7 +538,7 @@ func (r *userRepositoryImpl) UpdatePassword(newPassword, userID string) error {
 	}
 
 	if rowAffected != 1 {
-		return errors.New(messages.UpdateError)
+		return domainErrors.ErrNotFound.WithMessage(messages.UpdateError)
 	}
 
 	return nil
@@ -545,14 +573,14 @@ func (r *userRepositoryImpl) FindPassword(userID string) (string, error) {
 	return secret, nil
 }
 
-func (r *userRepositoryImpl) ListUserAdm(kind string, offset, limit, page int) ([]models.User, error) {
+func (r *userRepositoryImpl) ListUserAdm(kind string, p *pagination.Params) ([]models.User, *pagination.Page, error) {
 	db, err := databaseConn.Connect()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer db.Close()
 
-	sqlText := fmt.Sprintf(`
+	sqlText, args := p.Query(`
 			select
 				u.id,
 				p.id,
@@ -560,15 +588,16 @@ func (r *userRepositoryImpl) ListUserAdm(kind string, offset, limit, page int) (
 				p.telephone,
 				u.nick,
 				u.email,
-				u.kind
+				u.kind,
+				u.language,
+				u.created_at
 			from tb_person p
 			INNER JOIN tb_user u ON u.id = p.user_uid
-			where p.deleted_at is null and u.deleted_at is null and u.kind = $1
-			LIMIT %v OFFSET ((%v - 1) * (%v)) + %v`, limit, page, limit, offset)
+			where p.deleted_at is null and u.deleted_at is null and u.kind = $1`, "u.created_at", "u.id", []interface{}{kind})
 
-	rows, err := db.Query(sqlText, kind)
+	rows, err := db.Query(sqlText, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer rows.Close()
 
@@ -576,12 +605,13 @@ func (r *userRepositoryImpl) ListUserAdm(kind string, offset, limit, page int) (
 	for rows.Next() {
 		e, err := r.scanIterator(rows, false)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		entities = append(entities, *e)
 	}
 
-	return entities, nil
+	entities, page := pagination.Slice(p, entities, userKey)
+	return entities, page, nil
 }
 
 func NewUserRepository() userRepositoryInterface {
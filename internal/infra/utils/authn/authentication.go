@@ -4,28 +4,11 @@ import (
 	"net/http"
 
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
 )
 
-func HeaderMethods(next http.HandlerFunc, method string) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, PUT, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept, X-PINGOTHER, X-Auth-Token")
-		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Content-Type", "application/json")
-
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		} else if r.Method == method {
-			next(w, r)
-		} else {
-			w.WriteHeader(http.StatusMethodNotAllowed)
-		}
-	}
-}
-
 func Authenticate(nextFunction http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
@@ -37,23 +20,17 @@ func Authenticate(nextFunction http.HandlerFunc) http.HandlerFunc {
 			if err.Error() == "Token is expired" {
 				userID, err := tokenFunc.ExtractInvalideToken(r)
 				if err != nil {
-					w.WriteHeader(http.StatusUnauthorized)
-					response := responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 01, err, "ti")
-					responseAPI.EncodeResponse(nil, w, response)
+					responseAPI.WriteError(w, r, domainErrors.Unauthorized(err), "ti")
 					return
 				}
 				atoken, err := tokenFunc.GenerateNewToken(userID)
 				if err != nil {
-					w.WriteHeader(http.StatusUnauthorized)
-					response := responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 02, err, "ti")
-					responseAPI.EncodeResponse(nil, w, response)
+					responseAPI.WriteError(w, r, domainErrors.Unauthorized(err), "ti")
 					return
 				}
 				r.Header.Set("Authorization", atoken)
 			} else {
-				w.WriteHeader(http.StatusUnauthorized)
-				response := responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 03, err, "ti")
-				responseAPI.EncodeResponse(nil, w, response)
+				responseAPI.WriteError(w, r, domainErrors.Unauthorized(err), "ti")
 				return
 			}
 
@@ -62,3 +39,30 @@ func Authenticate(nextFunction http.HandlerFunc) http.HandlerFunc {
 		nextFunction(w, r)
 	}
 }
+
+// Localize: stores the language of the request in the context, the user's preference comes before the Accept-Language
+func Localize(nextFunction http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		language := messages.DefaultLanguage
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(r)
+		if err == nil && userToken.Language != "" {
+			language = userToken.Language
+		} else if lang, ok := messages.Negotiate(r.Header.Get("Accept-Language")); ok {
+			language = lang
+		}
+
+		w.Header().Add("Vary", "Accept-Language")
+		nextFunction(w, r.WithContext(messages.WithLanguage(r.Context(), language)))
+	}
+}
+
+// UserKey: the user of a valid token, used to key per user limits
+func UserKey(r *http.Request) (string, bool) {
+	tokenFunc := service.NewAccessService()
+	userToken, err := tokenFunc.ExtractTokenInfo(r)
+	if err != nil {
+		return "", false
+	}
+	return userToken.UserID, true
+}
@@ -24,6 +24,34 @@ type config struct {
 		Email  string `yaml:"email"`
 		Secret string `yaml:"secret"`
 	} `yaml:"contact"`
+	Cors struct {
+		AllowedOrigins   []string `yaml:"allowed_origins"`
+		AllowedMethods   []string `yaml:"allowed_methods"`
+		AllowedHeaders   []string `yaml:"allowed_headers"`
+		ExposedHeaders   []string `yaml:"exposed_headers"`
+		AllowCredentials bool     `yaml:"allow_credentials"`
+		MaxAge           int      `yaml:"max_age"`
+	} `yaml:"cors"`
+	RateLimit struct {
+		TrustedProxies []string `yaml:"trusted_proxies"`
+	} `yaml:"rate_limit"`
+	Scheduler struct {
+		Interval int `yaml:"interval"`
+	} `yaml:"scheduler"`
+	Media struct {
+		Storage        string `yaml:"storage"`
+		Dir            string `yaml:"dir"`
+		MaxSize        int64  `yaml:"max_size"`
+		ThumbnailWidth int    `yaml:"thumbnail_width"`
+	} `yaml:"media"`
+	Site struct {
+		Name     string `yaml:"name"`
+		URL      string `yaml:"url"`
+		APIURL   string `yaml:"api_url"`
+		PostPath string `yaml:"post_path"`
+		Locale   string `yaml:"locale"`
+		Twitter  string `yaml:"twitter"`
+	} `yaml:"site"`
 }
 
 type projectConfig struct {
@@ -44,10 +72,52 @@ type contactConfig struct {
 	Secret string
 }
 
+type corsConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int
+}
+
+type rateLimitConfig struct {
+	TrustedProxies []string
+}
+
+// schedulerConfig: Interval in seconds between the runs of the jobs
+type schedulerConfig struct {
+	Interval int
+}
+
+// mediaConfig: Storage is the backend of the files ("local"), MaxSize in bytes and ThumbnailWidth in pixels
+type mediaConfig struct {
+	Storage        string
+	Dir            string
+	MaxSize        int64
+	ThumbnailWidth int
+}
+
+// siteConfig: the public site, URL is where the frontend shows a post (URL + PostPath + slug)
+// and APIURL where the api serves the media, Twitter is the site's @account
+type siteConfig struct {
+	Name     string
+	URL      string
+	APIURL   string
+	PostPath string
+	Locale   string
+	Twitter  string
+}
+
 type ServiceConfig interface {
 	ProjectConfigs() (*projectConfig, error)
 	DatabaseConfigs() (*databaseConfig, error)
 	ContactConfig() (*contactConfig, error)
+	CorsConfigs() (*corsConfig, error)
+	RateLimitConfigs() (*rateLimitConfig, error)
+	SchedulerConfigs() (*schedulerConfig, error)
+	MediaConfigs() (*mediaConfig, error)
+	SiteConfigs() (*siteConfig, error)
 }
 
 type configsImpl struct{}
@@ -107,6 +177,69 @@ func (c *configsImpl) ContactConfig() (*contactConfig, error) {
 	}, nil
 }
 
+func (c *configsImpl) CorsConfigs() (*corsConfig, error) {
+	config, err := c.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &corsConfig{
+		AllowedOrigins:   config.Cors.AllowedOrigins,
+		AllowedMethods:   config.Cors.AllowedMethods,
+		AllowedHeaders:   config.Cors.AllowedHeaders,
+		ExposedHeaders:   config.Cors.ExposedHeaders,
+		AllowCredentials: config.Cors.AllowCredentials,
+		MaxAge:           config.Cors.MaxAge,
+	}, nil
+}
+
+func (c *configsImpl) RateLimitConfigs() (*rateLimitConfig, error) {
+	config, err := c.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitConfig{
+		TrustedProxies: config.RateLimit.TrustedProxies,
+	}, nil
+}
+
+func (c *configsImpl) SchedulerConfigs() (*schedulerConfig, error) {
+	config, err := c.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &schedulerConfig{
+		Interval: config.Scheduler.Interval,
+	}, nil
+}
+
+func (c *configsImpl) MediaConfigs() (*mediaConfig, error) {
+	config, err := c.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &mediaConfig{
+		Storage:        config.Media.Storage,
+		Dir:            config.Media.Dir,
+		MaxSize:        config.Media.MaxSize,
+		ThumbnailWidth: config.Media.ThumbnailWidth,
+	}, nil
+}
+
+func (c *configsImpl) SiteConfigs() (*siteConfig, error) {
+	config, err := c.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &siteConfig{
+		Name:     config.Site.Name,
+		URL:      config.Site.URL,
+		APIURL:   config.Site.APIURL,
+		PostPath: config.Site.PostPath,
+		Locale:   config.Site.Locale,
+		Twitter:  config.Site.Twitter,
+	}, nil
+}
+
 func NewConfigs() ServiceConfig {
 	return &configsImpl{}
 }
@@ -0,0 +1,154 @@
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/configsAPI"
+)
+
+// Policy: cross-origin rules read from the "cors" section of config.yaml
+type Policy struct {
+	allowedOrigins   map[string]bool
+	anyOrigin        bool
+	allowedMethods   map[string]bool
+	allowedHeaders   map[string]bool
+	headers          string
+	exposedHeaders   string
+	allowCredentials bool
+	maxAge           int
+}
+
+// NewPolicy: loads the cors configs and builds the policy
+func NewPolicy() (*Policy, error) {
+	config := configsAPI.NewConfigs()
+	corsConfig, err := config.CorsConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Policy{
+		allowedOrigins:   make(map[string]bool),
+		allowedMethods:   make(map[string]bool),
+		allowedHeaders:   make(map[string]bool),
+		headers:          strings.Join(corsConfig.AllowedHeaders, ", "),
+		exposedHeaders:   strings.Join(corsConfig.ExposedHeaders, ", "),
+		allowCredentials: corsConfig.AllowCredentials,
+		maxAge:           corsConfig.MaxAge,
+	}
+
+	for _, v := range corsConfig.AllowedOrigins {
+		if v == "*" {
+			p.anyOrigin = true
+			continue
+		}
+		p.allowedOrigins[strings.ToLower(strings.TrimSuffix(v, "/"))] = true
+	}
+	for _, v := range corsConfig.AllowedMethods {
+		p.allowedMethods[strings.ToUpper(v)] = true
+	}
+	for _, v := range corsConfig.AllowedHeaders {
+		p.allowedHeaders[http.CanonicalHeaderKey(v)] = true
+	}
+
+	return p, nil
+}
+
+func (p *Policy) originAllowed(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	return p.anyOrigin || p.allowedOrigins[strings.ToLower(origin)]
+}
+
+func (p *Policy) headersAllowed(requested string) bool {
+	if requested == "" {
+		return true
+	}
+	for _, v := range strings.Split(requested, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" && !p.allowedHeaders[http.CanonicalHeaderKey(v)] {
+			return false
+		}
+	}
+	return true
+}
+
+// setOrigin: a wildcard origin can't be sent with credentials, so the caller's origin is echoed back instead
+func (p *Policy) setOrigin(w http.ResponseWriter, origin string) {
+	if p.anyOrigin && !p.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if p.allowCredentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (p *Policy) preflight(w http.ResponseWriter, r *http.Request, methods []string) {
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	origin := r.Header.Get("Origin")
+	requestedMethod := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+
+	routeMethods := make([]string, 0)
+	methodAllowed := false
+	for _, v := range methods {
+		if !p.allowedMethods[v] {
+			continue
+		}
+		routeMethods = append(routeMethods, v)
+		if v == requestedMethod {
+			methodAllowed = true
+		}
+	}
+
+	if p.originAllowed(origin) && methodAllowed && p.headersAllowed(r.Header.Get("Access-Control-Request-Headers")) {
+		p.setOrigin(w, origin)
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(routeMethods, ", "))
+		if p.headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", p.headers)
+		}
+		if p.maxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(p.maxAge))
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Preflight: answers the OPTIONS of a path, methods are all the ones served by the path's routes,
+// a single route per path is needed since the router sends the OPTIONS to the first route that matches
+func (p *Policy) Preflight(methods ...string) http.HandlerFunc {
+	allow := strings.Join(append(methods[:len(methods):len(methods)], http.MethodOptions), ", ")
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+
+		if r.Header.Get("Access-Control-Request-Method") != "" {
+			p.preflight(w, r, methods)
+			return
+		}
+		w.Header().Set("Allow", allow)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Handler: decorates the actual request with the cors headers, its preflight is answered by Preflight
+func (p *Policy) Handler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Origin")
+
+		origin := r.Header.Get("Origin")
+		if p.originAllowed(origin) {
+			p.setOrigin(w, origin)
+			if p.exposedHeaders != "" {
+				w.Header().Set("Access-Control-Expose-Headers", p.exposedHeaders)
+			}
+		}
+
+		next(w, r)
+	}
+}
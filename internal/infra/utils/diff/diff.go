@@ -0,0 +1,138 @@
+package diff
+
+import "strings"
+
+// Op: what happened to a line from the old text to the new one
+type Op string
+
+const (
+	Equal  Op = "equal"
+	Insert Op = "insert"
+	Delete Op = "delete"
+)
+
+// Line: a line of the diff, the deleted lines come from the old text and the inserted from the new one
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// MaxEdits: past this many changed lines the texts are too different to be compared line by line,
+// the diff is then the old text deleted and the new one inserted
+const MaxEdits = 2000
+
+// Lines: line level diff from a to b, the shortest one (Myers' algorithm)
+func Lines(a, b string) []Line {
+	return lines(split(a), split(b))
+}
+
+// split: the lines of the text, an empty text has no lines
+func split(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func lines(a, b []string) []Line {
+	// the equal lines at the start and the end don't need the algorithm
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]Line, 0, len(a)+len(b))
+	result = appendLines(result, Equal, a[:prefix])
+	result = append(result, middle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	result = appendLines(result, Equal, a[len(a)-suffix:])
+	return result
+}
+
+func appendLines(result []Line, op Op, texts []string) []Line {
+	for _, v := range texts {
+		result = append(result, Line{Op: op, Text: v})
+	}
+	return result
+}
+
+// middle: the diff of the lines between the common prefix and suffix
+func middle(a, b []string) []Line {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	// v: the furthest x reached on each diagonal k = x - y
+	v := make([]int, 2*max+3)
+	// trace: v before each round d, only the diagonals -d-1..d+1 the round reads
+	trace := make([][]int, 0)
+
+	for d := 0; d <= max && d <= MaxEdits; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			x := 0
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+
+	result := appendLines(make([]Line, 0, max), Delete, a)
+	return appendLines(result, Insert, b)
+}
+
+// backtrack: walks the trace from the end of both texts back to the start
+func backtrack(a, b []string, trace [][]int) []Line {
+	result := make([]Line, 0, len(a)+len(b))
+	x, y := len(a), len(b)
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		at := func(k int) int { return v[k+d+1] }
+
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			result = append(result, Line{Op: Equal, Text: a[x-1]})
+			x--
+			y--
+		}
+
+		if d > 0 {
+			if x == prevX {
+				result = append(result, Line{Op: Insert, Text: b[y-1]})
+			} else {
+				result = append(result, Line{Op: Delete, Text: a[x-1]})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+// format: the diff as a unified diff without headers
+func format(result []Line) string {
+	marks := map[Op]string{Equal: " ", Insert: "+", Delete: "-"}
+	var b strings.Builder
+	for _, v := range result {
+		b.WriteString(marks[v.Op] + v.Text + "\n")
+	}
+	return b.String()
+}
+
+func TestLines(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		a := "a\nb\nc\na\nb\nb\na"
+		b := "c\nb\na\nb\na\nc"
+		result := Lines(a, b)
+
+		edits := 0
+		var old, new []string
+		for _, v := range result {
+			switch v.Op {
+			case Equal:
+				old, new = append(old, v.Text), append(new, v.Text)
+			case Delete:
+				old = append(old, v.Text)
+				edits++
+			case Insert:
+				new = append(new, v.Text)
+				edits++
+			}
+		}
+		if strings.Join(old, "\n") != a || strings.Join(new, "\n") != b {
+			t.Fatalf("the diff doesn't rebuild the texts:\n%s", format(result))
+		}
+		// the shortest edit script of the classic example has 5 edits
+		if edits != 5 {
+			t.Errorf("edits: %d\n%s", edits, format(result))
+		}
+
+		got := format(Lines("title\nold line\nend\n", "title\r\nnew line\r\nend"))
+		if got != " title\n-old line\n+new line\n end\n" {
+			t.Errorf("diff:\n%s", got)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		if len(Lines("", "")) != 0 {
+			t.Error("empty texts have no lines")
+		}
+		if got := format(Lines("", "a")); got != "+a\n" {
+			t.Errorf("diff: %q", got)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/slug"
+)
+
+// full: CommonMark with the GFM tables, code fences, strikethrough, task lists and autolinks, for the posts.
+// The raw html isn't rendered and the links with dangerous schemes (javascript:, vbscript:, ...) are emptied.
+// The headings get ids, the anchors of the table of contents
+var full = goldmark.New(
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	goldmark.WithExtensions(extension.GFM),
+)
+
+// restricted: paragraphs, emphasis, links, code, lists and quotes, for the comments and responses.
+// Headings, rules, images and html stay as text and the links are flagged as user content
+var restricted = goldmark.New(
+	goldmark.WithParser(parser.NewParser(
+		parser.WithBlockParsers(
+			util.Prioritized(parser.NewListParser(), 300),
+			util.Prioritized(parser.NewListItemParser(), 400),
+			util.Prioritized(parser.NewCodeBlockParser(), 500),
+			util.Prioritized(parser.NewFencedCodeBlockParser(), 700),
+			util.Prioritized(parser.NewBlockquoteParser(), 800),
+			util.Prioritized(parser.NewParagraphParser(), 1000),
+		),
+		parser.WithInlineParsers(
+			util.Prioritized(parser.NewCodeSpanParser(), 100),
+			util.Prioritized(parser.NewLinkParser(), 200),
+			util.Prioritized(parser.NewAutoLinkParser(), 300),
+			util.Prioritized(parser.NewEmphasisParser(), 500),
+		),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+		parser.WithASTTransformers(util.Prioritized(userLinks{}, 100)),
+	)),
+	goldmark.WithRendererOptions(renderer.WithNodeRenderers(util.Prioritized(imageText{}, 100))),
+	goldmark.WithExtensions(extension.Strikethrough, extension.Linkify),
+)
+
+// userLinks: the search engines don't follow the links of the readers
+type userLinks struct{}
+
+func (userLinks) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering && (n.Kind() == ast.KindLink || n.Kind() == ast.KindAutoLink) {
+			n.SetAttributeString("rel", []byte("nofollow ugc noopener"))
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// imageText: an image of a reader is shown as its alt text
+type imageText struct{}
+
+func (imageText) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindImage, func(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		return ast.WalkContinue, nil
+	})
+}
+
+// headingIDs: the ids of the headings are their slugs, "Instalação" is "instalacao" and a repeated one is "instalacao-2"
+type headingIDs struct {
+	taken map[string]bool
+}
+
+func newHeadingIDs() *headingIDs {
+	return &headingIDs{taken: make(map[string]bool)}
+}
+
+func (ids *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	id := slug.Unique(slug.Make(string(value)), func(s string) bool {
+		return ids.taken[s]
+	})
+	ids.taken[id] = true
+	return []byte(id)
+}
+
+func (ids *headingIDs) Put(value []byte) {
+	ids.taken[string(value)] = true
+}
+
+func convert(md goldmark.Markdown, source string) (string, error) {
+	var buf bytes.Buffer
+	err := md.Convert([]byte(source), &buf, parser.WithContext(parser.NewContext(parser.WithIDs(newHeadingIDs()))))
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Render: the html of the markdown of a post
+func Render(source string) (string, error) {
+	return convert(full, source)
+}
+
+// RenderRestricted: the html of the markdown subset of the comments and responses
+func RenderRestricted(source string) (string, error) {
+	return convert(restricted, source)
+}
@@ -0,0 +1,106 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		source := "# Título\n\n| a | b |\n| - | - |\n| 1 | 2 |\n\n```go\nfmt.Println(\"oi\")\n```\n\n~~velho~~ [site](https://go.dev)\n"
+		html, err := Render(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`<h1 id="titulo">Título</h1>`, "<table>", "<td>1</td>", `<code class="language-go">`, "<del>velho</del>", `<a href="https://go.dev">site</a>`} {
+			if !strings.Contains(html, want) {
+				t.Errorf("%q not in %s", want, html)
+			}
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		source := "<script>alert(1)</script>\n\n[x](javascript:alert(1)) <img src=x onerror=alert(1)> <a href=\"#\" onclick=\"alert(1)\">y</a>\n"
+		html, err := Render(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, bad := range []string{"<script", "javascript:", "onerror", "onclick", "<img"} {
+			if strings.Contains(html, bad) {
+				t.Errorf("%q in %s", bad, html)
+			}
+		}
+	})
+}
+
+func TestRenderRestricted(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		html, err := RenderRestricted("**ótimo** post, veja `go vet` e https://go.dev\n\n- um\n- dois\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"<strong>ótimo</strong>", "<code>go vet</code>", `rel="nofollow ugc noopener"`, "<li>um</li>"} {
+			if !strings.Contains(html, want) {
+				t.Errorf("%q not in %s", want, html)
+			}
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		html, err := RenderRestricted("# grito\n\n![foto](https://x.com/a.png)\n\n<b>negrito</b>\n\n| a |\n| - |\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, bad := range []string{"<h1>", "<img", "<b>", "<table>"} {
+			if strings.Contains(html, bad) {
+				t.Errorf("%q in %s", bad, html)
+			}
+		}
+		if !strings.Contains(html, "foto") {
+			t.Errorf("the alt text of the image is lost: %s", html)
+		}
+	})
+}
+
+func TestSummarize(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		source := "# Introdução\n\nUm texto **curto** sobre\n[Go](https://go.dev).\n\n## Instalação\n\n- passo um\n\n```sh\ngo install\n```\n\n## Instalação\n"
+		summary := Summarize(source)
+		if summary.Excerpt != "Um texto curto sobre Go." {
+			t.Errorf("excerpt = %q", summary.Excerpt)
+		}
+		if summary.Words != 12 || summary.ReadingTime != 1 {
+			t.Errorf("words = %d, reading time = %d", summary.Words, summary.ReadingTime)
+		}
+		want := []Heading{{1, "Introdução", "introducao"}, {2, "Instalação", "instalacao"}, {2, "Instalação", "instalacao-2"}}
+		if len(summary.TOC) != len(want) {
+			t.Fatalf("toc = %v", summary.TOC)
+		}
+		for i := range want {
+			if summary.TOC[i] != want[i] {
+				t.Errorf("toc[%d] = %v, want %v", i, summary.TOC[i], want[i])
+			}
+		}
+
+		html, err := Render(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(html, `<h2 id="instalacao-2">`) {
+			t.Errorf("the anchors of the toc aren't in the html: %s", html)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		summary := Summarize(strings.Repeat("palavra ", 500))
+		if !strings.HasSuffix(summary.Excerpt, "…") || len([]rune(summary.Excerpt)) > ExcerptLength+1 {
+			t.Errorf("excerpt = %q", summary.Excerpt)
+		}
+		if summary.ReadingTime != 3 {
+			t.Errorf("reading time = %d", summary.ReadingTime)
+		}
+		if empty := Summarize(""); empty.Words != 0 || empty.ReadingTime != 0 || empty.Excerpt != "" {
+			t.Errorf("summary of nothing = %+v", empty)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package markdown
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// ExcerptLength: maximum of runes of an automatic excerpt, the cut is at the end of a word
+const ExcerptLength = 280
+
+// WordsPerMinute: reading speed of the reading time
+const WordsPerMinute = 200
+
+// Heading: an entry of the table of contents, ID is the anchor of the heading in the html of Render
+type Heading struct {
+	Level int
+	Text  string
+	ID    string
+}
+
+// Summary: what is read from the markdown of a post
+type Summary struct {
+	Excerpt     string
+	Words       int
+	ReadingTime int
+	TOC         []Heading
+}
+
+// plain: the text of the inline nodes of n, without the markup
+func plain(n ast.Node, source []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch v := c.(type) {
+		case *ast.Text:
+			b.Write(v.Segment.Value(source))
+			if v.SoftLineBreak() || v.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(v.Value)
+		case *ast.AutoLink:
+			b.Write(v.Label(source))
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
+
+// lines: the raw lines of a code block
+func lines(n ast.Node, source []byte) string {
+	var b strings.Builder
+	for i := 0; i < n.Lines().Len(); i++ {
+		segment := n.Lines().At(i)
+		b.Write(segment.Value(source))
+	}
+	return b.String()
+}
+
+// cut: the text up to max runes, at the end of a word
+func cut(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	s = string(runes[:max])
+	if i := strings.LastIndexAny(s, " \t\n"); i > 0 {
+		s = s[:i]
+	}
+	return strings.TrimRight(s, " ,.;:!?-") + "…"
+}
+
+// Summarize: excerpt of the first paragraphs, words, reading time in minutes and the headings of a post
+func Summarize(source string) Summary {
+	src := []byte(source)
+	doc := full.Parser().Parse(text.NewReader(src), parser.WithContext(parser.NewContext(parser.WithIDs(newHeadingIDs()))))
+
+	summary := Summary{TOC: make([]Heading, 0)}
+	var excerpt, all []string
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch v := n.(type) {
+		case *ast.Heading:
+			heading := Heading{Level: v.Level, Text: strings.TrimSpace(plain(v, src))}
+			if id, ok := v.AttributeString("id"); ok {
+				if b, ok := id.([]byte); ok {
+					heading.ID = string(b)
+				}
+			}
+			summary.TOC = append(summary.TOC, heading)
+			all = append(all, heading.Text)
+			return ast.WalkSkipChildren, nil
+		case *ast.Paragraph, *ast.TextBlock:
+			p := plain(v, src)
+			// the excerpt is made of the paragraphs of the text, not of the lists and tables
+			if v.Parent() != nil && v.Parent().Kind() == ast.KindDocument {
+				excerpt = append(excerpt, p)
+			}
+			all = append(all, p)
+			return ast.WalkSkipChildren, nil
+		case *east.TableCell:
+			// the cells of the tables aren't paragraphs
+			all = append(all, plain(v, src))
+			return ast.WalkSkipChildren, nil
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			all = append(all, lines(v, src))
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	summary.Words = len(strings.Fields(strings.Join(all, " ")))
+	summary.Excerpt = cut(strings.Join(strings.Fields(strings.Join(excerpt, " ")), " "), ExcerptLength)
+	if summary.Words > 0 {
+		summary.ReadingTime = (summary.Words + WordsPerMinute - 1) / WordsPerMinute
+	}
+	return summary
+}
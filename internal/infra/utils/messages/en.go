@@ -0,0 +1,128 @@
+package messages
+
+var en = map[Key]string{
+	AdmMessage:         "This feature is only allowed to the site's admin",
+	AnotherUser:        "You can't change another user's data",
+	UserBlocked:        "Your user was blocked! You won't be able to sign in",
+	LikePost:           "A post can only be liked once",
+	DeslikePost:        "You can't unlike a post you haven't liked",
+	TokenBlocked:       "The refresh token is blocked",
+	InvalideToken:      "This token isn't valid",
+	StoreError:         "Couldn't create",
+	ListError:          "Couldn't list",
+	FindError:          "Couldn't find",
+	UpdateError:        "Couldn't update",
+	RemoveError:        "Couldn't remove",
+	BlockError:         "Couldn't block",
+	CountError:         "Couldn't count",
+	EmailIsRegister:    "This email is already registered to another account",
+	NickIsRegister:     "This nick is already registered to another account",
+	UserNotExists:      "Wrong email or nick",
+	TooManyRequests:    "Too many requests, try again later",
+	InvalidRequest:     "Invalid request",
+	ValidationFailed:   "One or more fields are invalid",
+	CodeExpired:        "Your code has expired!",
+	InvalidCredentials: "Wrong email, nick or password",
+	AlreadyLinked:      "This link already exists!",
+	MethodNotAllowed:   "Method not allowed",
+	InternalError:      "Internal error",
+	InvalidTransition:  "The post can't move from its current status to the requested one",
+	RoleRequired:       "The user's role doesn't allow this feature",
+	MediaTooLarge:      "The file exceeds the allowed size",
+	MediaType:          "The file type isn't accepted, send a JPEG, PNG, GIF or WebP image",
+	TagTaken:           "A tag with this name already exists, merge them",
+	CategoryCycle:      "The parent category can't be the category itself nor one of its descendants",
+	CategorySlugTaken:  "A category with this slug already exists",
+	PostInSeries:       "The post is already in another series",
+
+	FieldRequired: "%s is required and can't be blank",
+	FieldTooLong:  "%s is longer than allowed",
+	FieldEmail:    "The %s is invalid. A valid email looks like `example@gmail.com`",
+	FieldInvalid:  "%s is invalid",
+
+	EmailRecoverySubject:  "Your verification code!",
+	EmailRecoveryTitle:    "Recovery code!",
+	EmailRecoveryBody:     "Hi %s, here is your password recovery code: <mark>%s</mark>",
+	EmailUpdatesSubject:   "Blog updates",
+	EmailUpdatesGreeting:  "Hi Administrator! Here is what's new on your blog!",
+	EmailAuthorGreeting:   "Hi %s! Here is news about your posts!",
+	EmailCommentHeading:   "A user commented on one of your posts!",
+	EmailCommentBody:      "The user %s commented on the post %s",
+	EmailResponseHeading:  "A user replied to a comment on one of your posts!",
+	EmailResponseBody:     "The user %s replied to %s's comment on the post %s",
+	EmailPublishedHeading: "Your post was published!",
+	EmailPublishedBody:    "The post %s is now available to the blog readers",
+
+	"title.invalid_request":        "Invalid request",
+	"title.validation_failed":      "Invalid data",
+	"title.recovery_code_expired":  "Expired code",
+	"title.invalid_token":          "Invalid token",
+	"title.token_blocked":          "Blocked token",
+	"title.invalid_credentials":    "Invalid credentials",
+	"title.admin_only":             "Restricted access",
+	"title.another_user":           "Access denied",
+	"title.user_blocked":           "Blocked user",
+	"title.not_found":              "Not found",
+	"title.user_not_found":         "User not found",
+	"title.method_not_allowed":     "Method not allowed",
+	"title.email_taken":            "Email already registered",
+	"title.nick_taken":             "Nick already registered",
+	"title.already_liked":          "Post already liked",
+	"title.not_liked":              "Post not liked",
+	"title.already_linked":         "Link already exists",
+	"title.too_many_requests":      "Too many requests",
+	"title.store_failed":           "Creation failed",
+	"title.internal":               "Internal error",
+	"title.invalid_transition":     "Invalid status change",
+	"title.role_required":          "Role not allowed",
+	"title.media_too_large":        "File too large",
+	"title.media_type_unsupported": "Unsupported file type",
+	"title.tag_taken":              "Tag already exists",
+	"title.category_cycle":         "Category cycle",
+	"title.category_slug_taken":    "Category slug already exists",
+	"title.post_in_series":         "Post in another series",
+
+	"field.nome":               "name",
+	"field.telefone":           "telephone",
+	"field.nick":               "nick",
+	"field.email":              "email",
+	"field.senha":              "password",
+	"field.kind":               "kind",
+	"field.idioma":             "language",
+	"field.email ou nick":      "email or nick",
+	"field.código":             "code",
+	"field.id":                 "id",
+	"field.post id":            "post id",
+	"field.id da postagem":     "post id",
+	"field.id da categoria":    "category id",
+	"field.id do comentario":   "comment id",
+	"field.id de config":       "config id",
+	"field.titulo":             "title",
+	"field.conteudo":           "content",
+	"field.categoria":          "category",
+	"field.nome da categoria":  "category name",
+	"field.banner url":         "banner url",
+	"field.cor do site":        "site color",
+	"field.link do site":       "site link",
+	"field.menuA do site":      "site menu",
+	"field.busca":              "search",
+	"field.data de publicação": "publish date",
+	"field.revisão":            "revision",
+	"field.slug":               "slug",
+	"field.arquivo":            "file",
+	"field.resumo":             "excerpt",
+	"field.capa":               "cover",
+	"field.meta descrição":     "meta description",
+	"field.url canônica":       "canonical url",
+	"field.og título":          "open graph title",
+	"field.og descrição":       "open graph description",
+	"field.og imagem":          "open graph image",
+	"field.twitter card":       "twitter card",
+	"field.tag":                "tag",
+	"field.tags":               "tags",
+	"field.categoria pai":      "parent category",
+	"field.descrição":          "description",
+	"field.categorias":         "categories",
+	"field.postagens":          "posts",
+	"field.id da série":        "series id",
+}
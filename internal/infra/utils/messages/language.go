@@ -0,0 +1,84 @@
+package messages
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// languages with a bundle
+const (
+	PtBR            = "pt-BR"
+	En              = "en"
+	DefaultLanguage = PtBR
+)
+
+type languageKey struct{}
+
+// WithLanguage: stores the language of the request in the context
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// Language: language of the request, the default language when there isn't one
+func Language(ctx context.Context) string {
+	lang, ok := ctx.Value(languageKey{}).(string)
+	if !ok || lang == "" {
+		return DefaultLanguage
+	}
+	return lang
+}
+
+// Supported: the bundle of a language tag, "pt", "pt-PT" and "pt-br" all use pt-BR
+func Supported(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	primary := strings.SplitN(tag, "-", 2)[0]
+	switch primary {
+	case "pt":
+		return PtBR, true
+	case "en":
+		return En, true
+	}
+	return "", false
+}
+
+// Negotiate: the supported language with the highest weight in an Accept-Language header
+func Negotiate(acceptLanguage string) (string, bool) {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	tags := make([]weighted, 0)
+	for _, v := range strings.Split(acceptLanguage, ",") {
+		parts := strings.Split(strings.TrimSpace(v), ";")
+		if parts[0] == "" {
+			continue
+		}
+		q := 1.0
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				value, err := strconv.ParseFloat(strings.TrimPrefix(p, "q="), 64)
+				if err == nil {
+					q = value
+				}
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag: parts[0], q: q})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	for _, v := range tags {
+		if lang, ok := Supported(v.tag); ok {
+			return lang, true
+		}
+	}
+	return "", false
+}
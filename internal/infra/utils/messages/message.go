@@ -1,21 +0,0 @@
-package messages
-
-var (
-	AdmMessage      = "Essa funcionalidade só permitida ao admin do site"
-	AnotherUser     = "Não pode manipular dados de outro usuário"
-	UserBlocked     = "Seu usuario foi bloqueado! Não será possivel se autenticar no site"
-	LikePost        = "Só pode curtir uma vez por publicação"
-	DeslikePost     = "Não pode descutir uma publicação que não foi curtida por você"
-	TokenBlocked    = "Token de reflash está bloqueado"
-	InvalideToken   = "Esse token não é valido"
-	StoreError      = "Não foi possivel criar"
-	ListError       = "Não foi possivel listar"
-	FindError       = "Não foi possivel achar"
-	UpdateError     = "Não foi possivel atualizar"
-	RemoveError     = "Não foi possivel remover"
-	BlockError      = "Não foi possivel bloquear"
-	CountError      = "Não foi possivel contar"
-	EmailIsRegister = "Esse email já foi registrado em outra conta"
-	NickIsRegister  = "Esse nick já foi registrado em outra conta"
-	UserNotExists   = "Email ou Nick icorretos"
-)
@@ -0,0 +1,100 @@
+package messages
+
+import "fmt"
+
+// Key: identifies a message in the bundles of every language
+type Key string
+
+const (
+	AdmMessage         Key = "adm_message"
+	AnotherUser        Key = "another_user"
+	UserBlocked        Key = "user_blocked"
+	LikePost           Key = "like_post"
+	DeslikePost        Key = "deslike_post"
+	TokenBlocked       Key = "token_blocked"
+	InvalideToken      Key = "invalide_token"
+	StoreError         Key = "store_error"
+	ListError          Key = "list_error"
+	FindError          Key = "find_error"
+	UpdateError        Key = "update_error"
+	RemoveError        Key = "remove_error"
+	BlockError         Key = "block_error"
+	CountError         Key = "count_error"
+	EmailIsRegister    Key = "email_is_register"
+	NickIsRegister     Key = "nick_is_register"
+	UserNotExists      Key = "user_not_exists"
+	TooManyRequests    Key = "too_many_requests"
+	InvalidRequest     Key = "invalid_request"
+	ValidationFailed   Key = "validation_failed"
+	CodeExpired        Key = "code_expired"
+	InvalidCredentials Key = "invalid_credentials"
+	AlreadyLinked      Key = "already_linked"
+	MethodNotAllowed   Key = "method_not_allowed"
+	InternalError      Key = "internal_error"
+	InvalidTransition  Key = "invalid_transition"
+	RoleRequired       Key = "role_required"
+	MediaTooLarge      Key = "media_too_large"
+	MediaType          Key = "media_type"
+	TagTaken           Key = "tag_taken"
+	CategoryCycle      Key = "category_cycle"
+	CategorySlugTaken  Key = "category_slug_taken"
+	PostInSeries       Key = "post_in_series"
+
+	// validation of the fields, the argument is the name of the field
+	FieldRequired Key = "field_required"
+	FieldTooLong  Key = "field_too_long"
+	FieldEmail    Key = "field_email"
+	FieldInvalid  Key = "field_invalid"
+
+	// emails
+	EmailRecoverySubject  Key = "email_recovery_subject"
+	EmailRecoveryTitle    Key = "email_recovery_title"
+	EmailRecoveryBody     Key = "email_recovery_body"
+	EmailUpdatesSubject   Key = "email_updates_subject"
+	EmailUpdatesGreeting  Key = "email_updates_greeting"
+	EmailAuthorGreeting   Key = "email_author_greeting"
+	EmailCommentHeading   Key = "email_comment_heading"
+	EmailCommentBody      Key = "email_comment_body"
+	EmailResponseHeading  Key = "email_response_heading"
+	EmailResponseBody     Key = "email_response_body"
+	EmailPublishedHeading Key = "email_published_heading"
+	EmailPublishedBody    Key = "email_published_body"
+)
+
+// Title: key of the title of an error code
+func Title(code string) Key {
+	return Key("title." + code)
+}
+
+// Field: key of the name of a validated field
+func Field(name string) Key {
+	return Key("field." + name)
+}
+
+var bundles = map[string]map[Key]string{
+	PtBR: ptBR,
+	En:   en,
+}
+
+// Translate: the message of the key in the language, falls back to the default language and then to the key
+func Translate(lang string, key Key, args ...interface{}) string {
+	text, ok := bundles[lang][key]
+	if !ok {
+		text, ok = bundles[DefaultLanguage][key]
+	}
+	if !ok {
+		text = string(key)
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// TranslateField: the name of a field in the language, unknown fields keep their name
+func TranslateField(lang, name string) string {
+	if _, ok := bundles[DefaultLanguage][Field(name)]; !ok {
+		return name
+	}
+	return Translate(lang, Field(name))
+}
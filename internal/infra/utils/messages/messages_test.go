@@ -0,0 +1,47 @@
+package messages
+
+import "testing"
+
+func TestBundles(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		for key := range ptBR {
+			if _, ok := en[key]; !ok {
+				t.Errorf("chave %s sem tradução em en", key)
+			}
+		}
+		for key := range en {
+			if _, ok := ptBR[key]; !ok {
+				t.Errorf("chave %s sem tradução em pt-BR", key)
+			}
+		}
+
+		if Translate(En, FieldRequired, TranslateField(En, "titulo")) != "title is required and can't be blank" {
+			t.Error("tradução errada do campo")
+		}
+		if Translate("fr", FindError) != ptBR[FindError] {
+			t.Error("idioma sem pacote deveria usar o padrão")
+		}
+	})
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		header string
+		lang   string
+		ok     bool
+	}{
+		{"en-US,en;q=0.9,pt-BR;q=0.8", En, true},
+		{"fr-CA, pt;q=0.5, en;q=0.4", PtBR, true},
+		{"pt-PT;q=0.2, en-GB", En, true},
+		{"en;q=0, pt-BR", PtBR, true},
+		{"fr, de", "", false},
+		{"", "", false},
+	}
+
+	for _, c := range cases {
+		lang, ok := Negotiate(c.header)
+		if lang != c.lang || ok != c.ok {
+			t.Errorf("Negotiate(%q) = %q, %v; esperado %q, %v", c.header, lang, ok, c.lang, c.ok)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package messages
+
+var ptBR = map[Key]string{
+	AdmMessage:         "Essa funcionalidade só permitida ao admin do site",
+	AnotherUser:        "Não pode manipular dados de outro usuário",
+	UserBlocked:        "Seu usuario foi bloqueado! Não será possivel se autenticar no site",
+	LikePost:           "Só pode curtir uma vez por publicação",
+	DeslikePost:        "Não pode descutir uma publicação que não foi curtida por você",
+	TokenBlocked:       "Token de reflash está bloqueado",
+	InvalideToken:      "Esse token não é valido",
+	StoreError:         "Não foi possivel criar",
+	ListError:          "Não foi possivel listar",
+	FindError:          "Não foi possivel achar",
+	UpdateError:        "Não foi possivel atualizar",
+	RemoveError:        "Não foi possivel remover",
+	BlockError:         "Não foi possivel bloquear",
+	CountError:         "Não foi possivel contar",
+	EmailIsRegister:    "Esse email já foi registrado em outra conta",
+	NickIsRegister:     "Esse nick já foi registrado em outra conta",
+	UserNotExists:      "Email ou Nick icorretos",
+	TooManyRequests:    "Muitas requisições, tente novamente mais tarde",
+	InvalidRequest:     "Requisição inválida",
+	ValidationFailed:   "Um ou mais campos são inválidos",
+	CodeExpired:        "Seu código está expirado!",
+	InvalidCredentials: "Email, Nick ou senha incorretos",
+	AlreadyLinked:      "esse cadastro já foi realizado!",
+	MethodNotAllowed:   "Método não permitido",
+	InternalError:      "Erro interno",
+	InvalidTransition:  "A postagem não pode passar do seu status atual para o status pedido",
+	RoleRequired:       "O papel do usuário não permite essa funcionalidade",
+	MediaTooLarge:      "O arquivo excede o tamanho permitido",
+	MediaType:          "O tipo do arquivo não é aceito, envie uma imagem JPEG, PNG, GIF ou WebP",
+	TagTaken:           "Já existe uma tag com esse nome, junte as duas",
+	CategoryCycle:      "A categoria pai não pode ser a própria categoria nem uma das suas descendentes",
+	CategorySlugTaken:  "Já existe uma categoria com esse slug",
+	PostInSeries:       "A postagem já está em outra série",
+
+	FieldRequired: "%s é obrigatório e não pode está em branco",
+	FieldTooLong:  "%s excede o tamanho permitido",
+	FieldEmail:    "O %s inserido é invalido. Para o valor ser valido é preciso está nesse padrão `exemplo@gmail.com`",
+	FieldInvalid:  "%s é invalido",
+
+	EmailRecoverySubject:  "Seu código de Verificação!",
+	EmailRecoveryTitle:    "Código de Recuperação!",
+	EmailRecoveryBody:     "Olá %s, aqui está o seu código de recuperação de senha: <mark>%s</mark>",
+	EmailUpdatesSubject:   "Atualizações do Blog",
+	EmailUpdatesGreeting:  "Olá Adminstrador! Aqui está as novidades do seu blog!",
+	EmailAuthorGreeting:   "Olá %s! Aqui está uma novidade das suas postagens!",
+	EmailCommentHeading:   "Um Usuário comentou em um dos Seus Posts!",
+	EmailCommentBody:      "O usuario %s comentou no Post %s",
+	EmailResponseHeading:  "Um Usuário respondeu ao um comentario de um dos Seus Posts!",
+	EmailResponseBody:     "O usuario %s respondeu ao comentario de %s no Post %s",
+	EmailPublishedHeading: "Sua postagem foi publicada!",
+	EmailPublishedBody:    "A postagem %s já está disponível para os leitores do blog",
+
+	"title.invalid_request":        "Requisição inválida",
+	"title.validation_failed":      "Dados inválidos",
+	"title.recovery_code_expired":  "Código expirado",
+	"title.invalid_token":          "Token inválido",
+	"title.token_blocked":          "Token bloqueado",
+	"title.invalid_credentials":    "Credenciais inválidas",
+	"title.admin_only":             "Acesso restrito",
+	"title.another_user":           "Acesso negado",
+	"title.user_blocked":           "Usuário bloqueado",
+	"title.not_found":              "Não encontrado",
+	"title.user_not_found":         "Usuário não encontrado",
+	"title.method_not_allowed":     "Método não permitido",
+	"title.email_taken":            "Email já cadastrado",
+	"title.nick_taken":             "Nick já cadastrado",
+	"title.already_liked":          "Postagem já curtida",
+	"title.not_liked":              "Postagem não curtida",
+	"title.already_linked":         "Vínculo já existe",
+	"title.too_many_requests":      "Muitas requisições",
+	"title.store_failed":           "Falha ao criar",
+	"title.internal":               "Erro interno",
+	"title.invalid_transition":     "Mudança de status inválida",
+	"title.role_required":          "Papel não permitido",
+	"title.media_too_large":        "Arquivo grande demais",
+	"title.media_type_unsupported": "Tipo de arquivo não aceito",
+	"title.tag_taken":              "Tag já existe",
+	"title.category_cycle":         "Ciclo de categorias",
+	"title.category_slug_taken":    "Slug de categoria já existe",
+	"title.post_in_series":         "Postagem em outra série",
+
+	"field.nome":               "nome",
+	"field.telefone":           "telefone",
+	"field.nick":               "nick",
+	"field.email":              "email",
+	"field.senha":              "senha",
+	"field.kind":               "tipo",
+	"field.idioma":             "idioma",
+	"field.email ou nick":      "email ou nick",
+	"field.código":             "código",
+	"field.id":                 "id",
+	"field.post id":            "id da postagem",
+	"field.id da postagem":     "id da postagem",
+	"field.id da categoria":    "id da categoria",
+	"field.id do comentario":   "id do comentário",
+	"field.id de config":       "id da configuração",
+	"field.titulo":             "título",
+	"field.conteudo":           "conteúdo",
+	"field.categoria":          "categoria",
+	"field.nome da categoria":  "nome da categoria",
+	"field.banner url":         "url do banner",
+	"field.cor do site":        "cor do site",
+	"field.link do site":       "link do site",
+	"field.menuA do site":      "menu do site",
+	"field.busca":              "busca",
+	"field.data de publicação": "data de publicação",
+	"field.revisão":            "revisão",
+	"field.slug":               "slug",
+	"field.arquivo":            "arquivo",
+	"field.resumo":             "resumo",
+	"field.capa":               "capa",
+	"field.meta descrição":     "meta descrição",
+	"field.url canônica":       "url canônica",
+	"field.og título":          "título do open graph",
+	"field.og descrição":       "descrição do open graph",
+	"field.og imagem":          "imagem do open graph",
+	"field.twitter card":       "twitter card",
+	"field.tag":                "tag",
+	"field.tags":               "tags",
+	"field.categoria pai":      "categoria pai",
+	"field.descrição":          "descrição",
+	"field.categorias":         "categorias",
+	"field.postagens":          "postagens",
+	"field.id da série":        "id da série",
+}
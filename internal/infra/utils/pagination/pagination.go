@@ -0,0 +1,239 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// Cursor: position of a row in the order of the lists (the sort's value, created_at and id)
+type Cursor struct {
+	Before    bool      `json:"b,omitempty"`
+	Sort      string    `json:"s,omitempty"`
+	Value     string    `json:"v,omitempty"`
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+}
+
+// Encode: the cursor as an opaque string for the clients
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	c := new(Cursor)
+	err = json.Unmarshal(data, c)
+	if err != nil {
+		return nil, err
+	}
+	if c.ID == "" {
+		return nil, fmt.Errorf("cursor without id")
+	}
+	return c, nil
+}
+
+// Order: sort of a list, the rows with the same value are untied by created_at and id.
+// An empty name is the default order, the newest first.
+type Order struct {
+	Name string
+	Asc  bool
+}
+
+// key: the order written in the cursors, a cursor only walks the order that built it
+func (o Order) key() string {
+	if o.Name == "" && !o.Asc {
+		return ""
+	}
+	name := o.Name
+	if name == "" {
+		name = "created_at"
+	}
+	if o.Asc {
+		return name + ":asc"
+	}
+	return name + ":desc"
+}
+
+// Params: a page asked by offset (page and offset, kept for the old clients) or by cursor
+type Params struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor
+	Order  Order
+}
+
+// NewParams: validates the paging of a list, the cursor wins over page and offset
+func NewParams(offset, limit, page int, cursor string) (*Params, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if page < 1 {
+		page = 1
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	p := &Params{
+		Limit: limit,
+	}
+
+	if cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, domainErrors.Validation("cursor", messages.FieldInvalid, err)
+		}
+		p.Cursor = c
+		return p, nil
+	}
+
+	p.Offset = (page-1)*limit + offset
+	return p, nil
+}
+
+// ByCursor: the page was asked by cursor, the lists don't count the rows then
+func (p *Params) ByCursor() bool {
+	return p.Cursor != nil
+}
+
+// SortBy: sets the order of the list, the cursor must have been built by the same order
+func (p *Params) SortBy(name string, asc bool) error {
+	p.Order = Order{Name: name, Asc: asc}
+	if p.Cursor != nil && p.Cursor.Sort != p.Order.key() {
+		return domainErrors.Validation("cursor", messages.FieldInvalid, fmt.Errorf("cursor of the order %q", p.Cursor.Sort))
+	}
+	return nil
+}
+
+// Query: completes a select that ends in its where clause with the keyset, the order and the limit.
+// created and id are the columns of the order, args are the args already used by the select.
+func (p *Params) Query(sqlText, created, id string, args []interface{}) (string, []interface{}) {
+	return p.QuerySorted(sqlText, "", created, id, args)
+}
+
+// QuerySorted: Query ordered first by the sort column, the column of the Order's name
+func (p *Params) QuerySorted(sqlText, sort, created, id string, args []interface{}) (string, []interface{}) {
+	asc := p.Order.Asc
+	if p.Cursor != nil && p.Cursor.Before {
+		// walks backwards and the rows are reversed by Slice
+		asc = !asc
+	}
+	direction, operator := "DESC", "<"
+	if asc {
+		direction, operator = "ASC", ">"
+	}
+
+	columns := []string{created, id}
+	if sort != "" {
+		columns = append([]string{sort}, columns...)
+	}
+
+	if p.Cursor != nil {
+		values := []interface{}{p.Cursor.CreatedAt, p.Cursor.ID}
+		if sort != "" {
+			values = append([]interface{}{p.Cursor.Value}, values...)
+		}
+		params := make([]string, 0, len(values))
+		for _, v := range values {
+			args = append(args, v)
+			params = append(params, fmt.Sprintf("$%d", len(args)))
+		}
+		sqlText += fmt.Sprintf("\n\tand (%s) %s (%s)", strings.Join(columns, ", "), operator, strings.Join(params, ", "))
+	}
+
+	order := make([]string, 0, len(columns))
+	for _, v := range columns {
+		order = append(order, v+" "+direction)
+	}
+	sqlText += "\n\tORDER BY " + strings.Join(order, ", ")
+
+	// one more row tells if there is a next page
+	args = append(args, p.Limit+1)
+	sqlText += fmt.Sprintf("\n\tLIMIT $%d", len(args))
+	if p.Cursor == nil {
+		args = append(args, p.Offset)
+		sqlText += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	return sqlText, args
+}
+
+// Page: cursors of the pages around a list
+type Page struct {
+	NextCursor string
+	PrevCursor string
+	// Total: rows of the whole list, only counted for the pages asked by offset
+	Total *int
+}
+
+// SetTotal: counts the list when the page was asked by offset, count isn't called for the cursors
+func (p *Params) SetTotal(page *Page, count func() (int, error)) error {
+	if p.ByCursor() {
+		return nil
+	}
+	total, err := count()
+	if err != nil {
+		return err
+	}
+	page.Total = &total
+	return nil
+}
+
+// Slice: trims the extra row of the query, puts the rows back in the list's order and builds the cursors.
+// key gives the position of a row: the sort's value, created_at and id.
+func Slice[T any](p *Params, rows []T, key func(T) Cursor) ([]T, *Page) {
+	more := len(rows) > p.Limit
+	if more {
+		rows = rows[:p.Limit]
+	}
+
+	backwards := p.Cursor != nil && p.Cursor.Before
+	if backwards {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	page := new(Page)
+	if len(rows) == 0 {
+		return rows, page
+	}
+
+	hasNext := more
+	hasPrev := p.Offset > 0
+	if p.Cursor != nil {
+		hasNext = more || backwards
+		hasPrev = more || !backwards
+	}
+
+	if hasNext {
+		c := key(rows[len(rows)-1])
+		c.Sort = p.Order.key()
+		page.NextCursor = c.Encode()
+	}
+	if hasPrev {
+		c := key(rows[0])
+		c.Before, c.Sort = true, p.Order.key()
+		page.PrevCursor = c.Encode()
+	}
+
+	return rows, page
+}
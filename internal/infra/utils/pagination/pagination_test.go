@@ -0,0 +1,121 @@
+package pagination
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type row struct {
+	created time.Time
+	id      string
+}
+
+func rowKey(r row) Cursor {
+	return Cursor{CreatedAt: r.created, ID: r.id}
+}
+
+func TestNewParams(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		p, err := NewParams(2, 5, 3, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Offset != 12 || p.Limit != 5 || p.ByCursor() {
+			t.Errorf("paginação por offset errada: %+v", p)
+		}
+
+		p, err = NewParams(0, 500, 1, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Limit != MaxLimit {
+			t.Errorf("limite deveria ser %d, veio %d", MaxLimit, p.Limit)
+		}
+
+		c := &Cursor{CreatedAt: time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC), ID: "b"}
+		p, err = NewParams(4, 10, 7, c.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.ByCursor() || p.Offset != 0 || p.Cursor.ID != "b" || !p.Cursor.CreatedAt.Equal(c.CreatedAt) {
+			t.Errorf("cursor lido errado: %+v", p.Cursor)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		_, err := NewParams(0, 10, 1, "não-é-um-cursor")
+		if err == nil {
+			t.Error("cursor inválido deveria falhar")
+		}
+	})
+}
+
+func TestQuery(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		p := &Params{Limit: 10, Offset: 20}
+		sqlText, args := p.Query("SELECT id FROM tb_post WHERE deleted_at is null", "created_at", "id", nil)
+		if !strings.HasSuffix(sqlText, "ORDER BY created_at DESC, id DESC\n\tLIMIT $1 OFFSET $2") {
+			t.Errorf("query por offset errada: %s", sqlText)
+		}
+		if len(args) != 2 || args[0] != 11 || args[1] != 20 {
+			t.Errorf("args errados: %v", args)
+		}
+
+		p = &Params{Limit: 10, Cursor: &Cursor{Before: true, ID: "a"}}
+		sqlText, args = p.Query("SELECT id FROM tb_comment WHERE post_pid = $1", "created_at", "id", []interface{}{"p"})
+		if !strings.Contains(sqlText, "and (created_at, id) > ($2, $3)") || !strings.HasSuffix(sqlText, "ORDER BY created_at ASC, id ASC\n\tLIMIT $4") {
+			t.Errorf("query por cursor errada: %s", sqlText)
+		}
+		if len(args) != 4 {
+			t.Errorf("args errados: %v", args)
+		}
+
+		p = &Params{Limit: 10, Cursor: &Cursor{Sort: "likes:asc", Value: "3", ID: "a"}}
+		err := p.SortBy("likes", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sqlText, args = p.QuerySorted("SELECT id FROM tb_post p WHERE p.deleted_at is null", "likes", "p.created_at", "p.id", nil)
+		if !strings.Contains(sqlText, "and (likes, p.created_at, p.id) > ($1, $2, $3)") || !strings.HasSuffix(sqlText, "ORDER BY likes ASC, p.created_at ASC, p.id ASC\n\tLIMIT $4") {
+			t.Errorf("query ordenada errada: %s", sqlText)
+		}
+		if args[0] != "3" {
+			t.Errorf("args errados: %v", args)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		p := &Params{Limit: 10, Cursor: &Cursor{ID: "a"}}
+		err := p.SortBy("title", false)
+		if err == nil {
+			t.Error("cursor de outra ordem deveria falhar")
+		}
+	})
+}
+
+func TestSlice(t *testing.T) {
+	now := time.Now()
+	rows := []row{{now, "c"}, {now.Add(-time.Minute), "b"}, {now.Add(-2 * time.Minute), "a"}}
+
+	t.Run("teste positivo", func(t *testing.T) {
+		// first page: the extra row means there is a next page
+		p := &Params{Limit: 2}
+		list, page := Slice(p, append([]row{}, rows...), rowKey)
+		if len(list) != 2 || page.NextCursor == "" || page.PrevCursor != "" {
+			t.Errorf("primeira página errada: %v %+v", list, page)
+		}
+
+		next, err := decodeCursor(page.NextCursor)
+		if err != nil || next.ID != "b" || next.Before {
+			t.Errorf("next_cursor errado: %+v", next)
+		}
+
+		// backwards, the query brings the rows in ascending order
+		p = &Params{Limit: 2, Cursor: &Cursor{Before: true, ID: "a"}}
+		list, page = Slice(p, []row{rows[1], rows[0]}, rowKey)
+		if list[0].id != "c" || list[1].id != "b" || page.PrevCursor != "" || page.NextCursor == "" {
+			t.Errorf("página anterior errada: %v %+v", list, page)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package rateLimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/configsAPI"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
+)
+
+// UserKeyFunc: returns the authenticated user of the request, if there is one
+type UserKeyFunc func(r *http.Request) (string, bool)
+
+// Limiter: applies the rules of the routes over a store
+type Limiter struct {
+	store          Store
+	trustedProxies []*net.IPNet
+	userKey        UserKeyFunc
+}
+
+// NewLimiter: loads the trusted proxies from the configs
+func NewLimiter(store Store, userKey UserKeyFunc) (*Limiter, error) {
+	config := configsAPI.NewConfigs()
+	rateLimitConfig, err := config.RateLimitConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Limiter{
+		store:   store,
+		userKey: userKey,
+	}
+
+	for _, v := range rateLimitConfig.TrustedProxies {
+		if !strings.Contains(v, "/") {
+			if strings.Contains(v, ":") {
+				v += "/128"
+			} else {
+				v += "/32"
+			}
+		}
+		_, network, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, err
+		}
+		l.trustedProxies = append(l.trustedProxies, network)
+	}
+
+	return l, nil
+}
+
+func (l *Limiter) trusted(ip net.IP) bool {
+	for _, v := range l.trustedProxies {
+		if v.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP: the X-Forwarded-For chain is only followed while the hops are trusted proxies,
+// from the closest hop to the farthest, so a client can't spoof its address
+func (l *Limiter) ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !l.trusted(ip) {
+		return host
+	}
+
+	hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		ip = hop
+		if !l.trusted(hop) {
+			break
+		}
+	}
+
+	return ip.String()
+}
+
+type clientIPKey struct{}
+
+// Identify: stores the client ip of the request in the context, read by RequestIP
+func (l *Limiter) Identify(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, l.ClientIP(r))))
+	}
+}
+
+// RequestIP: the client ip stored by Identify, empty when the request didn't pass by it
+func RequestIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+func (l *Limiter) key(r *http.Request, name string, rule Rule) string {
+	if rule.ByUser && l.userKey != nil {
+		if userID, ok := l.userKey(r); ok {
+			return name + "|user:" + userID
+		}
+	}
+	return name + "|ip:" + l.ClientIP(r)
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// Handler: limits the requests of a route, name identifies the route's buckets
+func (l *Limiter) Handler(name string, rule Rule, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result := l.store.Take(l.key(r, name, rule), rule, time.Now())
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("X-RateLimit-Reset", seconds(result.Reset))
+
+		if !result.Allowed {
+			w.Header().Set("Retry-After", seconds(result.RetryAfter))
+			responseAPI.WriteError(w, r, domainErrors.ErrTooManyRequests, "rl")
+			return
+		}
+
+		next(w, r)
+	}
+}
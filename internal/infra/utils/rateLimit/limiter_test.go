@@ -0,0 +1,57 @@
+package rateLimit
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTake(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		store := NewMemoryStore()
+		rule := Rule{Requests: 2, Per: time.Minute}
+		now := time.Now()
+
+		for i := 0; i < 2; i++ {
+			if !store.Take("k", rule, now).Allowed {
+				t.Fatalf("request %d should be allowed", i+1)
+			}
+		}
+
+		result := store.Take("k", rule, now)
+		if result.Allowed {
+			t.Fatal("third request should be limited")
+		}
+		if result.RetryAfter != 30*time.Second {
+			t.Errorf("retry after: %s", result.RetryAfter)
+		}
+
+		if !store.Take("k", rule, now.Add(30*time.Second)).Allowed {
+			t.Error("a token should be refilled after 30s")
+		}
+	})
+}
+
+func TestClientIP(t *testing.T) {
+	_, proxy, _ := net.ParseCIDR("10.0.0.0/8")
+	l := &Limiter{trustedProxies: []*net.IPNet{proxy}}
+
+	t.Run("teste positivo", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "10.0.0.2:5000"
+		r.Header.Set("X-Forwarded-For", "1.1.1.1, 2.2.2.2, 10.0.0.3")
+		if ip := l.ClientIP(r); ip != "2.2.2.2" {
+			t.Errorf("client ip: %s", ip)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "3.3.3.3:5000"
+		r.Header.Set("X-Forwarded-For", "1.1.1.1")
+		if ip := l.ClientIP(r); ip != "3.3.3.3" {
+			t.Errorf("client ip: %s", ip)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package rateLimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Rule: token bucket that refills Requests tokens every Per, holding at most Burst tokens
+type Rule struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+	ByUser   bool
+}
+
+func (r Rule) capacity() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return float64(r.Requests)
+}
+
+func (r Rule) ratePerSecond() float64 {
+	return float64(r.Requests) / r.Per.Seconds()
+}
+
+// Result: state of the bucket after a take
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store: keeps the buckets. The in-memory store serves a single instance;
+// a shared store (redis, postgres...) can implement it for several replicas.
+type Store interface {
+	Take(key string, rule Rule, now time.Time) Result
+}
+
+type bucket struct {
+	tokens   float64
+	updated  time.Time
+	idleTime time.Duration
+}
+
+type memoryStoreImpl struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+const sweepInterval = time.Minute
+
+func (s *memoryStoreImpl) Take(key string, rule Rule, now time.Time) Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	capacity := rule.capacity()
+	rate := rule.ratePerSecond()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens:   capacity,
+			updated:  now,
+			idleTime: time.Duration(capacity / rate * float64(time.Second)),
+		}
+		s.buckets[key] = b
+	}
+
+	// refill the tokens since the last request
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+		b.updated = now
+	}
+
+	result := Result{
+		Limit: int(capacity),
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = time.Duration((capacity - b.tokens) / rate * float64(time.Second))
+
+	return result
+}
+
+// sweep: drops the buckets that are full again, they behave the same as a new one
+func (s *memoryStoreImpl) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for k, v := range s.buckets {
+		if now.Sub(v.updated) > v.idleTime {
+			delete(s.buckets, k)
+		}
+	}
+}
+
+func NewMemoryStore() Store {
+	return &memoryStoreImpl{
+		buckets: make(map[string]*bucket),
+	}
+}
@@ -3,54 +3,135 @@ package responseAPI
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
 
 	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
 )
 
+// ErrorsDocURL: page generated from the errors catalogue, the code is the anchor
+const ErrorsDocURL = "https://github.com/johnHPX/blog-hard-backend/blob/main/docs/errors.md"
+
+// problem: error body following the rfc 7807 (problem details)
+type problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	MID      string         `json:"mid"`
+	Errors   []fieldProblem `json:"errors,omitempty"`
+}
+
+type fieldProblem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
 type errorResponse struct {
-	Status int    `json:"status"`
-	Code   int    `json:"code"`
-	Msg    string `json:"message"`
-	MID    string `json:"mid"`
+	Err error
+	MID string
 }
 
 func (e *errorResponse) Error() string {
-	return fmt.Sprintf("code: %d, msg: %s, mid: %s",
-		e.Code, e.Msg, e.MID,
-	)
+	return fmt.Sprintf("err: %v, mid: %s", e.Err, e.MID)
+}
+
+func (e *errorResponse) Unwrap() error {
+	return e.Err
+}
+
+// domainError: finds the catalogue's error, errors the api doesn't know become internal
+func domainError(err error) *domainErrors.Error {
+	var dErr *domainErrors.Error
+	if errors.As(err, &dErr) {
+		return dErr
+	}
+
+	// errors of the decoders, the body or the params couldn't be read
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var numErr *strconv.NumError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.As(err, &numErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return domainErrors.ErrInvalidRequest.Wrap(err)
+	}
+
+	log.Println(err)
+	return domainErrors.ErrInternal.Wrap(err)
 }
 
 func ErrorEncoder() httptransport.ErrorEncoder {
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
-		// check if http error type
-		rErr, ok := err.(*errorResponse)
-		if !ok {
-			rErr = &errorResponse{
-				Status: 500,
-				Code:   0,
-				Msg:    err.Error(),
-				MID:    "ServerError",
-			}
+		mid := "na"
+		if rErr, ok := err.(*errorResponse); ok {
+			mid = rErr.MID
+		}
+
+		lang := messages.Language(ctx)
+		dErr := domainError(err)
+		p := &problem{
+			Type:   ErrorsDocURL + "#" + dErr.Code,
+			Title:  messages.Translate(lang, messages.Title(dErr.Code)),
+			Status: dErr.Status(),
+			Detail: messages.Translate(lang, dErr.Message),
+			Code:   dErr.Code,
+			MID:    mid,
 		}
+		for _, v := range dErr.Fields {
+			field := messages.TranslateField(lang, v.Field)
+			p.Errors = append(p.Errors, fieldProblem{
+				Field:   field,
+				Message: messages.Translate(lang, v.Rule, field),
+			})
+		}
+		if r, ok := ctx.Value(httptransport.ContextKeyRequestURI).(string); ok {
+			p.Instance = r
+		}
+
 		// write status
-		w.WriteHeader(rErr.Status)
+		w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+		w.Header().Set("Content-Language", lang)
+		w.WriteHeader(p.Status)
 		// encode and write error response
 		encoder := json.NewEncoder(w)
-		encoder.Encode(rErr)
+		encoder.Encode(p)
 	}
 }
 
-func CreateHttpErrorResponse(status, code int, err error, mid string) *errorResponse {
+// CreateHttpErrorResponse: tags the error with the method id, the status comes from the catalogue
+func CreateHttpErrorResponse(err error, mid string) *errorResponse {
 	return &errorResponse{
-		Status: status,
-		Code:   code,
-		Msg:    err.Error(),
-		MID:    mid,
+		Err: err,
+		MID: mid,
 	}
 }
 
+// Redirect: answer of a resource that moved, sent with its status and the Location header
+type Redirect struct {
+	Status   int    `json:"-"`
+	Location string `json:"location"`
+	MID      string `json:"mid"`
+}
+
 func EncodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if r, ok := response.(*Redirect); ok {
+		w.Header().Set("Location", r.Location)
+		w.WriteHeader(r.Status)
+	}
 	return json.NewEncoder(w).Encode(response)
 }
+
+// WriteError: writes the error of a middleware, outside of the go-kit servers
+func WriteError(w http.ResponseWriter, r *http.Request, err error, mid string) {
+	ctx := httptransport.PopulateRequestContext(r.Context(), r)
+	ErrorEncoder()(ctx, CreateHttpErrorResponse(err, mid), w)
+}
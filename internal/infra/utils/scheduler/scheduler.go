@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job: task run by the scheduler at every interval, the errors are only logged
+type Job struct {
+	Name  string
+	Every time.Duration
+	Run   func() error
+}
+
+// Start: runs each job in its own goroutine until the context is done.
+// A job runs once at the start, so the work missed while the api was down is done right away.
+// Running in several replicas is up to the job, see the advisory lock of the scheduled posts.
+func Start(ctx context.Context, jobs ...Job) {
+	for _, job := range jobs {
+		go run(ctx, job)
+	}
+}
+
+func run(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Every)
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(); err != nil {
+			log.Printf("scheduler: %s: %v", job.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
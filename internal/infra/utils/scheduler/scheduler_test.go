@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStart(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		runs := make(chan struct{}, 10)
+		Start(ctx, Job{
+			Name:  "test",
+			Every: 10 * time.Millisecond,
+			Run: func() error {
+				runs <- struct{}{}
+				// a failed run doesn't stop the next ones
+				return errors.New("failed")
+			},
+		})
+
+		for i := 0; i < 3; i++ {
+			select {
+			case <-runs:
+			case <-time.After(time.Second):
+				t.Fatalf("run %d didn't happen", i+1)
+			}
+		}
+	})
+}
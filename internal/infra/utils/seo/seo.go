@@ -0,0 +1,220 @@
+package seo
+
+import (
+	"encoding/json"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeadlineLength: the longest headline of a BlogPosting accepted by the search engines
+const HeadlineLength = 110
+
+// Page: what the head of a post's page describes, the urls are absolute
+type Page struct {
+	SiteName      string
+	Locale        string
+	Twitter       string
+	Title         string
+	Description   string
+	URL           string
+	OGTitle       string
+	OGDescription string
+	Image         string
+	ImageWidth    int
+	ImageHeight   int
+	Card          string
+	Author        string
+	Published     *time.Time
+	Modified      *time.Time
+	Keywords      []string
+	Words         int
+}
+
+// Meta: a meta tag, Name for the standard and twitter tags and Property for the open graph ones
+type Meta struct {
+	Name     string `json:"name,omitempty"`
+	Property string `json:"property,omitempty"`
+	Content  string `json:"content"`
+}
+
+// Link: a link tag
+type Link struct {
+	Rel  string `json:"rel"`
+	Href string `json:"href"`
+}
+
+// Head: the tags of the page, ready to be embedded by HTML
+type Head struct {
+	Title  string
+	Meta   []Meta
+	Links  []Link
+	JSONLD *BlogPosting
+}
+
+type thing struct {
+	Type string `json:"@type"`
+	Name string `json:"name,omitempty"`
+	ID   string `json:"@id,omitempty"`
+}
+
+// BlogPosting: the schema.org type of a post, sent as JSON-LD
+type BlogPosting struct {
+	Context          string   `json:"@context"`
+	Type             string   `json:"@type"`
+	Headline         string   `json:"headline"`
+	Description      string   `json:"description,omitempty"`
+	URL              string   `json:"url,omitempty"`
+	MainEntityOfPage *thing   `json:"mainEntityOfPage,omitempty"`
+	Image            []string `json:"image,omitempty"`
+	Author           *thing   `json:"author,omitempty"`
+	Publisher        *thing   `json:"publisher,omitempty"`
+	DatePublished    string   `json:"datePublished,omitempty"`
+	DateModified     string   `json:"dateModified,omitempty"`
+	Keywords         string   `json:"keywords,omitempty"`
+	WordCount        int      `json:"wordCount,omitempty"`
+	InLanguage       string   `json:"inLanguage,omitempty"`
+}
+
+// Build: the tags of the page, the open graph ones fall back to the title and the description
+func Build(page Page) *Head {
+	head := &Head{Title: page.Title}
+
+	ogTitle := first(page.OGTitle, page.Title)
+	ogDescription := first(page.OGDescription, page.Description)
+	card := page.Card
+	if card == "" {
+		card = "summary"
+		if page.Image != "" {
+			card = "summary_large_image"
+		}
+	}
+
+	head.name("description", page.Description)
+	head.link("canonical", page.URL)
+
+	head.property("og:type", "article")
+	head.property("og:site_name", page.SiteName)
+	head.property("og:locale", page.Locale)
+	head.property("og:title", ogTitle)
+	head.property("og:description", ogDescription)
+	head.property("og:url", page.URL)
+	head.property("og:image", page.Image)
+	if page.Image != "" && page.ImageWidth > 0 && page.ImageHeight > 0 {
+		head.property("og:image:width", strconv.Itoa(page.ImageWidth))
+		head.property("og:image:height", strconv.Itoa(page.ImageHeight))
+	}
+	head.property("article:author", page.Author)
+	head.property("article:published_time", date(page.Published))
+	head.property("article:modified_time", date(page.Modified))
+	for _, v := range page.Keywords {
+		head.property("article:tag", v)
+	}
+
+	head.name("twitter:card", card)
+	head.name("twitter:site", page.Twitter)
+	head.name("twitter:title", ogTitle)
+	head.name("twitter:description", ogDescription)
+	head.name("twitter:image", page.Image)
+
+	head.JSONLD = &BlogPosting{
+		Context:       "https://schema.org",
+		Type:          "BlogPosting",
+		Headline:      cut(page.Title, HeadlineLength),
+		Description:   page.Description,
+		URL:           page.URL,
+		DatePublished: date(page.Published),
+		DateModified:  date(page.Modified),
+		Keywords:      strings.Join(page.Keywords, ", "),
+		WordCount:     page.Words,
+		InLanguage:    strings.ReplaceAll(page.Locale, "_", "-"),
+	}
+	if page.URL != "" {
+		head.JSONLD.MainEntityOfPage = &thing{Type: "WebPage", ID: page.URL}
+	}
+	if page.Image != "" {
+		head.JSONLD.Image = []string{page.Image}
+	}
+	if page.Author != "" {
+		head.JSONLD.Author = &thing{Type: "Person", Name: page.Author}
+	}
+	if page.SiteName != "" {
+		head.JSONLD.Publisher = &thing{Type: "Organization", Name: page.SiteName}
+	}
+
+	return head
+}
+
+// HTML: the tags to be put in the <head>, the values are escaped and the JSON-LD can't close its script
+func (h *Head) HTML() (string, error) {
+	b := new(strings.Builder)
+	b.WriteString("<title>" + html.EscapeString(h.Title) + "</title>\n")
+	for _, v := range h.Meta {
+		if v.Property != "" {
+			b.WriteString(`<meta property="` + html.EscapeString(v.Property) + `" content="` + html.EscapeString(v.Content) + "\">\n")
+		} else {
+			b.WriteString(`<meta name="` + html.EscapeString(v.Name) + `" content="` + html.EscapeString(v.Content) + "\">\n")
+		}
+	}
+	for _, v := range h.Links {
+		b.WriteString(`<link rel="` + html.EscapeString(v.Rel) + `" href="` + html.EscapeString(v.Href) + "\">\n")
+	}
+
+	// encoding/json escapes <, > and &, a "</script>" in the text can't end the script
+	jsonLD, err := json.Marshal(h.JSONLD)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(`<script type="application/ld+json">` + string(jsonLD) + "</script>\n")
+
+	return b.String(), nil
+}
+
+// name, property and link: the empty values aren't written
+func (h *Head) name(name, content string) {
+	if content != "" {
+		h.Meta = append(h.Meta, Meta{Name: name, Content: content})
+	}
+}
+
+func (h *Head) property(property, content string) {
+	if content != "" {
+		h.Meta = append(h.Meta, Meta{Property: property, Content: content})
+	}
+}
+
+func (h *Head) link(rel, href string) {
+	if href != "" {
+		h.Links = append(h.Links, Link{Rel: rel, Href: href})
+	}
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func date(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// cut: s with at most size runes, cut at the end of a word
+func cut(s string, size int) string {
+	runes := []rune(s)
+	if len(runes) <= size {
+		return s
+	}
+	text := string(runes[:size-1])
+	if i := strings.LastIndex(text, " "); i > 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text) + "…"
+}
@@ -0,0 +1,83 @@
+package seo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuild(t *testing.T) {
+	published := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("teste positivo", func(t *testing.T) {
+		head := Build(Page{
+			SiteName:    "BlogHard",
+			Locale:      "pt_BR",
+			Title:       "Go na prática",
+			Description: "Um resumo",
+			URL:         "https://blog.com/post/go-na-pratica",
+			Image:       "https://api.blog.com/media/a.jpg",
+			ImageWidth:  1200,
+			ImageHeight: 630,
+			Author:      "Jonatas",
+			Published:   &published,
+			Keywords:    []string{"go", "backend"},
+		})
+
+		content := make(map[string]string)
+		for _, v := range head.Meta {
+			content[v.Name+v.Property] = v.Content
+		}
+		if content["og:title"] != "Go na prática" || content["twitter:description"] != "Um resumo" {
+			t.Errorf("open graph without the fallbacks: %v", content)
+		}
+		if content["twitter:card"] != "summary_large_image" {
+			t.Errorf("twitter:card %q", content["twitter:card"])
+		}
+		if content["article:published_time"] != "2024-05-10T12:00:00Z" {
+			t.Errorf("article:published_time %q", content["article:published_time"])
+		}
+		if head.JSONLD.InLanguage != "pt-BR" || head.JSONLD.Keywords != "go, backend" || head.JSONLD.Author.Name != "Jonatas" {
+			t.Errorf("json-ld %+v", head.JSONLD)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		head := Build(Page{Title: strings.Repeat("palavra ", 30)})
+		for _, v := range head.Meta {
+			if v.Content == "" {
+				t.Errorf("empty tag %+v", v)
+			}
+			if v.Name == "twitter:card" && v.Content != "summary" {
+				t.Errorf("a page without image has the card %q", v.Content)
+			}
+		}
+		if n := len([]rune(head.JSONLD.Headline)); n > HeadlineLength {
+			t.Errorf("headline with %d runes", n)
+		}
+	})
+}
+
+func TestHTML(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		head := Build(Page{Title: "A & B", URL: "https://blog.com/post/a-b"})
+		text, err := head.HTML()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(text, "<title>A &amp; B</title>") || !strings.Contains(text, `<link rel="canonical" href="https://blog.com/post/a-b">`) {
+			t.Errorf("html:\n%s", text)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		head := Build(Page{Title: `</script><script>alert(1)</script>"`, Description: `" onload="x`})
+		text, err := head.HTML()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(text, "</script>") != 1 || strings.Contains(text, `" onload="`) {
+			t.Errorf("html not escaped:\n%s", text)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Fallback: slug of a title without any letter or digit
+const Fallback = "post"
+
+// MaxLength: the slugs are cut at this size, the column keeps room for the number of Unique
+const MaxLength = 255
+
+// accents: the latin letters with diacritics folded to ascii, the others letters are dropped.
+// fn_slug, of the migration 19, folds the same letters for the slugs made by the migrations
+var accents = map[rune]string{
+	'á': "a", 'à': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'í': "i", 'ì': "i", 'î': "i", 'ï': "i",
+	'ó': "o", 'ò': "o", 'ô': "o", 'õ': "o", 'ö': "o",
+	'ú': "u", 'ù': "u", 'û': "u", 'ü': "u",
+	'ç': "c", 'ñ': "n", 'ý': "y", 'ÿ': "y",
+	'æ': "ae", 'œ': "oe", 'ß': "ss", 'ø': "o",
+}
+
+// Make: the title in lower case ascii with the words joined by hyphens, "Programação em Go" is "programacao-em-go"
+func Make(title string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		case accents[r] != "":
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteString(accents[r])
+		default:
+			hyphen = true
+		}
+	}
+
+	if b.Len() == 0 {
+		return Fallback
+	}
+	if b.Len() > MaxLength {
+		return strings.TrimRight(b.String()[:MaxLength], "-")
+	}
+	return b.String()
+}
+
+// Unique: the slug, or the slug with the first free number, that isn't taken
+func Unique(s string, taken func(string) bool) string {
+	candidate := s
+	for n := 2; taken(candidate); n++ {
+		candidate = s + "-" + strconv.Itoa(n)
+	}
+	return candidate
+}
@@ -0,0 +1,37 @@
+package slug
+
+import "testing"
+
+func TestMake(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		cases := map[string]string{
+			"Programação em Go":            "programacao-em-go",
+			"  Ação, Reação & Coração!  ":  "acao-reacao-coracao",
+			"Über Straße: 10 dicas (2024)": "uber-strasse-10-dicas-2024",
+			"go--go__go":                   "go-go-go",
+		}
+		for title, want := range cases {
+			if got := Make(title); got != want {
+				t.Errorf("Make(%q) = %q, want %q", title, got, want)
+			}
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		if got := Make("!!! 日本語 ???"); got != Fallback {
+			t.Errorf("Make without letters = %q", got)
+		}
+	})
+}
+
+func TestUnique(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		taken := map[string]bool{"go": true, "go-2": true}
+		if got := Unique("go", func(s string) bool { return taken[s] }); got != "go-3" {
+			t.Errorf("Unique = %q", got)
+		}
+		if got := Unique("rust", func(s string) bool { return taken[s] }); got != "rust" {
+			t.Errorf("Unique = %q", got)
+		}
+	})
+}
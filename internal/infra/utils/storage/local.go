@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// localImpl: the files in a directory of the server's disk
+type localImpl struct {
+	dir string
+}
+
+// NewLocal: creates the directory when it doesn't exist
+func NewLocal(dir string) (Storage, error) {
+	if dir == "" {
+		dir = "media"
+	}
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+	return &localImpl{dir: dir}, nil
+}
+
+// Put: the file is written in a temporary one and renamed, a reader never sees half a file
+func (s *localImpl) Put(key string, r io.Reader) error {
+	err := checkKey(key)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, key))
+}
+
+func (s *localImpl) Open(key string) (io.ReadSeekCloser, error) {
+	err := checkKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filepath.Join(s.dir, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Remove: removing a file that doesn't exist isn't an error
+func (s *localImpl) Remove(key string) error {
+	err := checkKey(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(s.dir, key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocal(t *testing.T) {
+	s, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("teste positivo", func(t *testing.T) {
+		err := s.Put("abc.png", strings.NewReader("imagem"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		file, err := s.Open("abc.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil || string(data) != "imagem" {
+			t.Errorf("data = %q, err = %v", data, err)
+		}
+
+		err = s.Remove("abc.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.Open("abc.png"); !errors.Is(err, ErrNotExist) {
+			t.Errorf("removed file opened: %v", err)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		for _, key := range []string{"", "..", "../config.yaml", "a/b.png", `a\b.png`} {
+			if err := s.Put(key, strings.NewReader("x")); err == nil {
+				t.Errorf("key %q accepted", key)
+			}
+		}
+	})
+}
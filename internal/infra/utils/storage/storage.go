@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/configsAPI"
+)
+
+// ErrNotExist: there is no file with the key
+var ErrNotExist = errors.New("storage: file does not exist")
+
+// Storage: where the media files are kept, the keys are names without directories
+type Storage interface {
+	Put(key string, r io.Reader) error
+	Open(key string) (io.ReadSeekCloser, error)
+	Remove(key string) error
+}
+
+// checkKey: a key can't leave the storage's root
+func checkKey(key string) error {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return fmt.Errorf("storage: invalid key %q", key)
+	}
+	return nil
+}
+
+// NewStorage: the backend of media.storage in the configs, an S3 compatible one has only to implement Storage
+func NewStorage() (Storage, error) {
+	config := configsAPI.NewConfigs()
+	mediaConfig, err := config.MediaConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	switch mediaConfig.Storage {
+	case "", "local":
+		return NewLocal(mediaConfig.Dir)
+	default:
+		return nil, fmt.Errorf("storage: unknown backend %q", mediaConfig.Storage)
+	}
+}
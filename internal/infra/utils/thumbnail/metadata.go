@@ -0,0 +1,229 @@
+package thumbnail
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// ErrMalformed: the structure of the file doesn't follow its format
+var ErrMalformed = errors.New("thumbnail: malformed image")
+
+// Strip: the image without its metadata (EXIF, GPS, XMP, comments), the pixels aren't re-encoded.
+// A turned JPEG keeps an EXIF with only the orientation, so it's still shown as it was taken
+func Strip(data []byte, mime string) ([]byte, error) {
+	switch mime {
+	case "image/jpeg":
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data)
+	case "image/gif":
+		return stripGIF(data)
+	case "image/webp":
+		return stripWebP(data)
+	}
+	return data, nil
+}
+
+// keepJPEGSegment: APP0 (JFIF), the ICC profile, the Adobe color transform and the segments of the image
+func keepJPEGSegment(marker byte, segment []byte) bool {
+	switch {
+	case marker == 0xe0:
+		return true
+	case marker == 0xe2:
+		return bytes.HasPrefix(segment, []byte("ICC_PROFILE\x00"))
+	case marker == 0xee:
+		return bytes.HasPrefix(segment, []byte("Adobe"))
+	case marker > 0xe0 && marker <= 0xef, marker == 0xfe:
+		return false
+	}
+	return true
+}
+
+// orientationSegment: an EXIF segment holding only the orientation
+func orientationSegment(o int) []byte {
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1, 0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, byte(o), 0, 0, 0, 0, 0, 0, 0, 0}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	size := len(payload) + 2
+	return append([]byte{0xff, 0xe1, byte(size >> 8), byte(size)}, payload...)
+}
+
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil, ErrMalformed
+	}
+
+	o := orientation(data)
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xff, 0xd8)
+	turned := o == 1
+
+	// the segments of the header, up to the start of the image data
+	for i := 2; ; {
+		if i+2 > len(data) || data[i] != 0xff {
+			return nil, ErrMalformed
+		}
+		marker := data[i+1]
+		if marker == 0xff {
+			i++
+			continue
+		}
+		// the EXIF goes after the JFIF segment
+		if !turned && marker != 0xe0 {
+			out = append(out, orientationSegment(o)...)
+			turned = true
+		}
+		if marker == 0xda || marker == 0xd9 {
+			return append(out, data[i:]...), nil
+		}
+		if i+4 > len(data) {
+			return nil, ErrMalformed
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if size < 2 || i+2+size > len(data) {
+			return nil, ErrMalformed
+		}
+		if keepJPEGSegment(marker, data[i+4:i+2+size]) {
+			out = append(out, data[i:i+2+size]...)
+		}
+		i += 2 + size
+	}
+}
+
+// pngMetadata: the chunks of text, EXIF and time
+var pngMetadata = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+func stripPNG(data []byte) ([]byte, error) {
+	if len(data) < 8 || !bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")) {
+		return nil, ErrMalformed
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:8]...)
+	for i := 8; ; {
+		if i+8 > len(data) {
+			return nil, ErrMalformed
+		}
+		size := int(binary.BigEndian.Uint32(data[i : i+4]))
+		kind := string(data[i+4 : i+8])
+		end := i + 12 + size
+		if size < 0 || end > len(data) {
+			return nil, ErrMalformed
+		}
+		if !pngMetadata[kind] {
+			out = append(out, data[i:end]...)
+		}
+		if kind == "IEND" {
+			return out, nil
+		}
+		i = end
+	}
+}
+
+// gifSubBlocks: the position after the sub-blocks that start at i, -1 when they don't end
+func gifSubBlocks(data []byte, i int) int {
+	for i < len(data) {
+		n := int(data[i])
+		i++
+		if n == 0 {
+			return i
+		}
+		i += n
+	}
+	return -1
+}
+
+// gifColorTable: the size of the color table of the flags, 0 when there is none
+func gifColorTable(flags byte) int {
+	if flags&0x80 == 0 {
+		return 0
+	}
+	return 3 * (1 << (flags&0x07 + 1))
+}
+
+func stripGIF(data []byte) ([]byte, error) {
+	if len(data) < 13 || !(bytes.HasPrefix(data, []byte("GIF87a")) || bytes.HasPrefix(data, []byte("GIF89a"))) {
+		return nil, ErrMalformed
+	}
+
+	i := 13 + gifColorTable(data[10])
+	if i > len(data) {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:i]...)
+
+	for i < len(data) {
+		var end int
+		keep := true
+		switch data[i] {
+		case 0x21:
+			if i+2 > len(data) {
+				return nil, ErrMalformed
+			}
+			label := data[i+1]
+			// the comments and the application data, but the loop of the animations
+			switch label {
+			case 0xfe:
+				keep = false
+			case 0xff:
+				app := data[i+2:]
+				keep = bytes.HasPrefix(app, []byte("\x0bNETSCAPE2.0")) || bytes.HasPrefix(app, []byte("\x0bANIMEXTS1.0"))
+			}
+			end = gifSubBlocks(data, i+2)
+		case 0x2c:
+			if i+11 > len(data) {
+				return nil, ErrMalformed
+			}
+			end = gifSubBlocks(data, i+10+gifColorTable(data[i+9])+1)
+		case 0x3b:
+			return append(out, 0x3b), nil
+		default:
+			return nil, ErrMalformed
+		}
+		if end < 0 || end > len(data) {
+			return nil, ErrMalformed
+		}
+		if keep {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return nil, ErrMalformed
+}
+
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, ErrMalformed
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:12]...)
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, ErrMalformed
+		}
+		kind := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4 : i+8]))
+		end := i + 8 + size + size%2
+		if size < 0 || end > len(data) {
+			return nil, ErrMalformed
+		}
+		switch kind {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte{}, data[i:end]...)
+			if size > 0 {
+				// the flags of the EXIF and of the XMP
+				chunk[8] &^= 0x08 | 0x04
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, nil
+}
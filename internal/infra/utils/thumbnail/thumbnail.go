@@ -0,0 +1,153 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	// the GIF decoder, the JPEG and PNG ones come with their encoders
+	_ "image/gif"
+)
+
+// Quality: of the JPEG thumbnails
+const Quality = 85
+
+// MaxPixels: a bigger image isn't decoded, a small file can declare a huge image
+const MaxPixels = 40_000_000
+
+// ErrTooManyPixels: the image exceeds MaxPixels
+var ErrTooManyPixels = errors.New("thumbnail: image exceeds the maximum of pixels")
+
+// Thumbnail: a reduced copy of an image, re-encoded so nothing of the original metadata (EXIF, GPS) is kept
+type Thumbnail struct {
+	Data   []byte
+	MIME   string
+	Width  int
+	Height int
+}
+
+// Size: width and height of the image as it's shown, the EXIF orientation included
+func Size(data []byte) (int, int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, err
+	}
+	if swapsSides(orientation(data)) {
+		return config.Height, config.Width, nil
+	}
+	return config.Width, config.Height, nil
+}
+
+// Make: the JPEG, PNG or GIF image reduced to width, a smaller image keeps its size.
+// The thumbnail is a PNG when the image has transparency and a JPEG otherwise
+func Make(data []byte, width int) (*Thumbnail, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	o := orientation(data)
+	b := src.Bounds()
+	shownWidth, shownHeight := b.Dx(), b.Dy()
+	if swapsSides(o) {
+		shownWidth, shownHeight = shownHeight, shownWidth
+	}
+	if width <= 0 || width > shownWidth {
+		width = shownWidth
+	}
+	height := shownHeight * width / shownWidth
+	if height < 1 {
+		height = 1
+	}
+
+	// the reduction happens before the turn, over the sides of the stored image
+	w, h := width, height
+	if swapsSides(o) {
+		w, h = h, w
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, b.Min, draw.Src)
+	thumb := orient(resize(rgba, w, h), o)
+
+	var buf bytes.Buffer
+	mime := "image/jpeg"
+	if format != "jpeg" && !opaque(thumb) {
+		mime = "image/png"
+		err = png.Encode(&buf, thumb)
+	} else {
+		err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: Quality})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Thumbnail{
+		Data:   buf.Bytes(),
+		MIME:   mime,
+		Width:  thumb.Bounds().Dx(),
+		Height: thumb.Bounds().Dy(),
+	}, nil
+}
+
+// resize: box filter, each pixel is the average of the pixels of the source that it covers
+func resize(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw == w && sh == h {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, (y+1)*sh/h
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, (x+1)*sw/w
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(src.Pix[i])
+					g += uint32(src.Pix[i+1])
+					bl += uint32(src.Pix[i+2])
+					a += uint32(src.Pix[i+3])
+					n++
+					i += 4
+				}
+			}
+
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8(r / n)
+			dst.Pix[j+1] = uint8(g / n)
+			dst.Pix[j+2] = uint8(bl / n)
+			dst.Pix[j+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+func opaque(img *image.RGBA) bool {
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 0xff {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// withOrientation: the JPEG with an EXIF segment holding only the orientation
+func withOrientation(data []byte, o byte) []byte {
+	tiff := []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1, 0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, o, 0, 0, 0, 0, 0, 0, 0, 0}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	size := len(payload) + 2
+	segment := append([]byte{0xff, 0xe1, byte(size >> 8), byte(size)}, payload...)
+	return append(append(append([]byte{}, data[:2]...), segment...), data[2:]...)
+}
+
+func TestMake(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 400, 200))
+		for y := 0; y < 200; y++ {
+			for x := 0; x < 400; x++ {
+				img.Set(x, y, color.NRGBA{R: 200, A: uint8(x % 256)})
+			}
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatal(err)
+		}
+
+		thumb, err := Make(buf.Bytes(), 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thumb.MIME != "image/png" || thumb.Width != 100 || thumb.Height != 50 {
+			t.Errorf("thumbnail %s %dx%d", thumb.MIME, thumb.Width, thumb.Height)
+		}
+	})
+
+	t.Run("teste positivo com orientação", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, nil); err != nil {
+			t.Fatal(err)
+		}
+		data := withOrientation(buf.Bytes(), 6)
+
+		w, h, err := Size(data)
+		if err != nil || w != 20 || h != 40 {
+			t.Errorf("size %dx%d, err %v", w, h, err)
+		}
+
+		thumb, err := Make(data, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if thumb.MIME != "image/jpeg" || thumb.Width != 10 || thumb.Height != 20 {
+			t.Errorf("thumbnail %s %dx%d", thumb.MIME, thumb.Width, thumb.Height)
+		}
+		if bytes.Contains(thumb.Data, []byte("Exif")) {
+			t.Error("the thumbnail kept the EXIF")
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		if _, err := Make([]byte("não é uma imagem"), 100); err == nil {
+			t.Error("thumbnail of a text")
+		}
+	})
+}
+
+func TestStrip(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, nil); err != nil {
+			t.Fatal(err)
+		}
+		// a turned photo with a comment and a GPS tag
+		comment := []byte{0xff, 0xfe, 0, 10, 'l', 'a', 't', ' ', '-', '2', '3', '.'}
+		xmp := append([]byte{0xff, 0xe1, 0, 2 + 29 + 3}, []byte("http://ns.adobe.com/xap/1.0/\x00GPS")...)
+		data := withOrientation(buf.Bytes(), 6)
+		data = append(append(append(append([]byte{}, data[:2]...), comment...), xmp...), data[2:]...)
+
+		stripped, err := Strip(data, "image/jpeg")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(stripped, []byte("lat -23.")) || bytes.Contains(stripped, []byte("GPS")) {
+			t.Error("the metadata was kept")
+		}
+		w, h, err := Size(stripped)
+		if err != nil || w != 20 || h != 40 {
+			t.Errorf("size %dx%d, err %v", w, h, err)
+		}
+
+		buf.Reset()
+		if err := png.Encode(&buf, img); err != nil {
+			t.Fatal(err)
+		}
+		text := []byte{0, 0, 0, 7, 't', 'E', 'X', 't', 'G', 'P', 'S', 0, '4', '2', '!', 0, 0, 0, 0}
+		data = append(append(append([]byte{}, buf.Bytes()[:33]...), text...), buf.Bytes()[33:]...)
+		stripped, err = Strip(data, "image/png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(stripped, buf.Bytes()) {
+			t.Error("the PNG text was kept")
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		if _, err := Strip([]byte("não é uma imagem"), "image/jpeg"); err == nil {
+			t.Error("stripped a text")
+		}
+		if _, err := Strip([]byte("\x89PNG\r\n\x1a\n"), "image/png"); err == nil {
+			t.Error("stripped a PNG without chunks")
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package views
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// botMarks: pieces of the user agents of crawlers, link previews and scripts, in lower case
+var botMarks = []string{
+	"bot", "crawl", "spider", "slurp", "preview", "facebookexternalhit",
+	"headless", "lighthouse", "curl", "wget", "python", "go-http-client", "java/", "okhttp",
+}
+
+// IsBot: the views of a bot aren't counted, a request without user agent is taken as a bot too
+func IsBot(userAgent string) bool {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	if ua == "" {
+		return true
+	}
+	for _, v := range botMarks {
+		if strings.Contains(ua, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Visit: the reader of a post on a day, Visitor is empty for the bots
+type Visit struct {
+	Visitor string
+	Day     string
+}
+
+// Day: the UTC day of t, the one in the visitor's hash and the one its view is stored with
+func Day(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// Visitor: identifies the reader of the day without keeping its data, the user when logged in,
+// the ip and the user agent otherwise. The day is in the hash so the visitors of different days can't be linked
+func Visitor(userID, ip, userAgent string, day time.Time) string {
+	key := "ip:" + ip + "|" + userAgent
+	if userID != "" {
+		key = "user:" + userID
+	}
+	sum := sha256.Sum256([]byte(Day(day) + "|" + key))
+	return hex.EncodeToString(sum[:])
+}
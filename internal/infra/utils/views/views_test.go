@@ -0,0 +1,56 @@
+package views
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsBot(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		agents := []string{
+			"",
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			"facebookexternalhit/1.1",
+			"curl/8.4.0",
+			"Mozilla/5.0 (X11; Linux x86_64) HeadlessChrome/120.0.0.0",
+		}
+		for _, v := range agents {
+			if !IsBot(v) {
+				t.Errorf("IsBot(%q) = false", v)
+			}
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		ua := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+		if IsBot(ua) {
+			t.Errorf("IsBot(%q) = true", ua)
+		}
+	})
+}
+
+func TestVisitor(t *testing.T) {
+	day := time.Date(2024, 5, 10, 23, 0, 0, 0, time.UTC)
+
+	t.Run("teste positivo", func(t *testing.T) {
+		a := Visitor("", "1.1.1.1", "firefox", day)
+		if len(a) != 64 {
+			t.Errorf("visitor size %d", len(a))
+		}
+		if Visitor("", "1.1.1.1", "firefox", day.Add(-time.Hour)) != a {
+			t.Errorf("visitor changed in the same day")
+		}
+		if Visitor("u1", "1.1.1.1", "firefox", day) != Visitor("u1", "2.2.2.2", "chrome", day) {
+			t.Errorf("the user is the visitor in any device")
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		if Visitor("", "1.1.1.1", "firefox", day) == Visitor("", "1.1.1.1", "firefox", day.AddDate(0, 0, 1)) {
+			t.Errorf("visitors of different days are the same")
+		}
+		if Visitor("", "1.1.1.1", "firefox", day) == Visitor("", "2.2.2.2", "firefox", day) {
+			t.Errorf("different ips are the same visitor")
+		}
+	})
+}
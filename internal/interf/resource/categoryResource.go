@@ -3,7 +3,6 @@ package resource
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -11,18 +10,84 @@ import (
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
 )
 
+// categoryEntity: posts is the number of published posts of the category
 type categoryEntity struct {
+	CategoryID  string       `json:"categoryID"`
+	Name        string       `json:"name"`
+	Slug        string       `json:"slug"`
+	Description string       `json:"description,omitempty"`
+	ParentID    string       `json:"parentID,omitempty"`
+	Cover       *coverEntity `json:"cover,omitempty"`
+	Posts       int          `json:"posts"`
+}
+
+func newCategoryEntity(category *models.Category) categoryEntity {
+	return categoryEntity{
+		CategoryID:  category.CategoryID,
+		Name:        category.Name,
+		Slug:        category.Slug,
+		Description: category.Description,
+		ParentID:    category.ParentID,
+		Cover:       newCoverEntity(category.Cover),
+		Posts:       category.Posts,
+	}
+}
+
+// categoryLinkEntity: a step of the breadcrumbs
+type categoryLinkEntity struct {
 	CategoryID string `json:"categoryID"`
 	Name       string `json:"name"`
+	Slug       string `json:"slug"`
+}
+
+// newCategoryPaths: the breadcrumbs of a post, each path goes from a root to a category of the post
+func newCategoryPaths(paths [][]models.Category) [][]categoryLinkEntity {
+	entities := make([][]categoryLinkEntity, 0, len(paths))
+	for _, path := range paths {
+		entity := make([]categoryLinkEntity, 0, len(path))
+		for _, v := range path {
+			entity = append(entity, categoryLinkEntity{
+				CategoryID: v.CategoryID,
+				Name:       v.Name,
+				Slug:       v.Slug,
+			})
+		}
+		entities = append(entities, entity)
+	}
+	return entities
+}
+
+// categoryNodeEntity: a category of the tree, the leaves have no children
+type categoryNodeEntity struct {
+	categoryEntity
+	Children []categoryNodeEntity `json:"children,omitempty"`
+}
+
+func newCategoryNodeEntities(nodes []models.CategoryNode) []categoryNodeEntity {
+	entities := make([]categoryNodeEntity, 0, len(nodes))
+	for i := range nodes {
+		entities = append(entities, categoryNodeEntity{
+			categoryEntity: newCategoryEntity(&nodes[i].Category),
+			Children:       newCategoryNodeEntities(nodes[i].Children),
+		})
+	}
+	return entities
 }
 
 type categoryStoreRequest struct {
-	Name    string `json:"name"`
-	MID     string `json:"mid"`
-	Request *http.Request
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	CoverID     string `json:"coverID"`
+	ParentID    string `json:"parentID"`
+	MID         string `json:"mid"`
+	Request     *http.Request
 }
 
 type categoryStoreResponse struct {
@@ -45,19 +110,19 @@ func makeCategoryStoreEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*categoryStoreRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCategoryService(userToken.UserID, userToken.Kind)
-		err = service.CreateCategory(req.Name)
+		err = service.CreateCategory(req.Name, req.Slug, req.Description, req.CoverID, req.ParentID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postStoreResponse{
@@ -71,20 +136,30 @@ func CategoryStoreHandler() http.Handler {
 		makeCategoryStoreEndPoint(),
 		decodeCategoryStoreRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CategoryStoreDoc() Doc {
+	return Doc{
+		Summary:  "Cria uma categoria, dentro da categoria parentID quando ele vem. Sem slug ele é feito do nome",
+		Request:  categoryStoreRequest{},
+		Response: postStoreResponse{},
+	}
+}
+
 type categoryListRequest struct {
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
 type categoryListResponse struct {
-	Count     int              `json:"count"`
+	pageEntity
 	Categorys []categoryEntity `json:"categorys"`
 	MID       string           `json:"mid"`
 }
@@ -107,6 +182,7 @@ func decodeCategoryListRequest(ctx context.Context, r *http.Request) (interface{
 		Offset:  int(offset),
 		Limit:   int(limit),
 		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
 		MID:     mid,
 		Request: r,
 	}
@@ -118,33 +194,34 @@ func makeCategoryListEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*categoryListRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCategoryService(userToken.UserID, userToken.Kind)
-		category, count, err := service.ListCategory(req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		category, page, err := service.ListCategory(p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []categoryEntity
-		for _, v := range category {
-			entities = append(entities, categoryEntity{
-				CategoryID: v.CategoryID,
-				Name:       v.Name,
-			})
+		for i := range category {
+			entities = append(entities, newCategoryEntity(&category[i]))
 		}
 
 		return &categoryListResponse{
-			Count:     count,
-			Categorys: entities,
-			MID:       req.MID,
+			pageEntity: newPageEntity(page),
+			Categorys:  entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -154,21 +231,81 @@ func CategoryListHandler() http.Handler {
 		makeCategoryListEndPoint(),
 		decodeCategoryListRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func CategoryListDoc() Doc {
+	return Doc{
+		Summary:  "Lista as categorias com o número de postagens publicadas de cada uma",
+		Request:  categoryListRequest{},
+		Response: categoryListResponse{},
+	}
+}
+
+func makeCategoryListPublicEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*categoryListRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewCategoryService("", "")
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		category, page, err := service.ListCategory(p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		var entities []categoryEntity
+		for i := range category {
+			entities = append(entities, newCategoryEntity(&category[i]))
+		}
+
+		return &categoryListResponse{
+			pageEntity: newPageEntity(page),
+			Categorys:  entities,
+			MID:        req.MID,
+		}, nil
+	}
+}
+
+// CategoryListPublicHandler: the list of the categories for the readers, without token
+func CategoryListPublicHandler() http.Handler {
+	return httptransport.NewServer(
+		makeCategoryListPublicEndPoint(),
+		decodeCategoryListRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CategoryListPublicDoc() Doc {
+	return Doc{
+		Summary:  "Lista as categorias para os leitores, sem token, com o número de postagens publicadas de cada uma",
+		Request:  categoryListRequest{},
+		Response: categoryListResponse{},
+	}
+}
+
 type categoryListPostRequest struct {
 	PostID  string
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
 type categoryListPostResponse struct {
-	Count     int              `json:"count"`
+	pageEntity
 	Categorys []categoryEntity `json:"categorys"`
 	MID       string           `json:"mid"`
 }
@@ -194,6 +331,7 @@ func decodeCategoryListPostRequest(ctx context.Context, r *http.Request) (interf
 		Offset:  int(offset),
 		Limit:   int(limit),
 		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
 		MID:     mid,
 		Request: r,
 	}
@@ -205,33 +343,34 @@ func makeCategoryListPostEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*categoryListPostRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCategoryService(userToken.UserID, userToken.Kind)
-		category, count, err := service.ListCategoryByPost(req.PostID, req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		category, page, err := service.ListCategoryByPost(req.PostID, p)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []categoryEntity
-		for _, v := range category {
-			entities = append(entities, categoryEntity{
-				CategoryID: v.CategoryID,
-				Name:       v.Name,
-			})
+		for i := range category {
+			entities = append(entities, newCategoryEntity(&category[i]))
 		}
 
 		return &categoryListResponse{
-			Count:     count,
-			Categorys: entities,
-			MID:       req.MID,
+			pageEntity: newPageEntity(page),
+			Categorys:  entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -241,13 +380,22 @@ func CategoryListPostHandler() http.Handler {
 		makeCategoryListPostEndPoint(),
 		decodeCategoryListPostRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CategoryListPostDoc() Doc {
+	return Doc{
+		Summary:  "Lista as categorias de uma postagem",
+		Request:  categoryListPostRequest{},
+		Response: categoryListResponse{},
+	}
+}
+
 type categoryFindRequest struct {
 	categoryID string
-	MID        string
+	MID        string `query:"mid"`
 	Request    *http.Request
 }
 
@@ -273,28 +421,25 @@ func makeCategoryFindEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*categoryFindRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1006, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1007, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCategoryService(userToken.UserID, userToken.Kind)
 		category, err := service.FindCategory(req.categoryID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1008, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &categoryFindResponse{
-			categoryEntity: categoryEntity{
-				CategoryID: category.CategoryID,
-				Name:       category.Name,
-			},
-			MID: req.MID,
+			categoryEntity: newCategoryEntity(category),
+			MID:            req.MID,
 		}, nil
 	}
 }
@@ -304,15 +449,28 @@ func CategoryFindHandler() http.Handler {
 		makeCategoryFindEndPoint(),
 		decodeCategoryFindRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CategoryFindDoc() Doc {
+	return Doc{
+		Summary:  "Busca uma categoria pelo id",
+		Request:  categoryFindRequest{},
+		Response: categoryFindResponse{},
+	}
+}
+
 type categoryUpdateRequest struct {
-	categoryID string
-	Name       string `json:"name"`
-	MID        string `json:"mid"`
-	Request    *http.Request
+	categoryID  string
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	CoverID     string `json:"coverID"`
+	ParentID    string `json:"parentID"`
+	MID         string `json:"mid"`
+	Request     *http.Request
 }
 
 type categoryUpdateResponse struct {
@@ -338,19 +496,19 @@ func makeCategoryUpdateEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*categoryUpdateRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1009, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1010, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCategoryService(userToken.UserID, userToken.Kind)
-		err = service.UpdateCategory(req.categoryID, req.Name)
+		err = service.UpdateCategory(req.categoryID, req.Name, req.Slug, req.Description, req.CoverID, req.ParentID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1011, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &commentUpdateResponse{
@@ -364,13 +522,22 @@ func CategoryUpdateHandler() http.Handler {
 		makeCategoryUpdateEndPoint(),
 		decodeCategoryUpdateRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CategoryUpdateDoc() Doc {
+	return Doc{
+		Summary:  "Atualiza uma categoria, sem parentID ela vira uma raiz e sem slug mantém o seu. Um pai descendente ou um slug de outra categoria respondem 409",
+		Request:  categoryUpdateRequest{},
+		Response: commentUpdateResponse{},
+	}
+}
+
 type categoryRemoveRequest struct {
 	ID      string
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -394,19 +561,19 @@ func makeCategoryRemoveEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*categoryRemoveRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1012, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1013, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCategoryService(userToken.UserID, userToken.Kind)
 		err = service.RemoveCategory(req.ID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1014, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postRemoveResponse{
@@ -420,6 +587,138 @@ func CategoryRemoveHandler() http.Handler {
 		makeCategoryRemoveEndPoint(),
 		decodeCategoryRemoveRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func CategoryRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Remove uma categoria",
+		Request:  categoryRemoveRequest{},
+		Response: postRemoveResponse{},
+	}
+}
+
+type categoryTreeRequest struct {
+	MID string `query:"mid"`
+}
+
+type categoryTreeResponse struct {
+	Categorys []categoryNodeEntity `json:"categorys"`
+	MID       string               `json:"mid"`
+}
+
+func decodeCategoryTreeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	dto := &categoryTreeRequest{
+		MID: r.URL.Query().Get("mid"),
+	}
+	return dto, nil
+}
+
+func makeCategoryTreeEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*categoryTreeRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewCategoryService("", "")
+		nodes, err := service.Tree()
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &categoryTreeResponse{
+			Categorys: newCategoryNodeEntities(nodes),
+			MID:       req.MID,
+		}, nil
+	}
+}
+
+func CategoryTreeHandler() http.Handler {
+	return httptransport.NewServer(
+		makeCategoryTreeEndPoint(),
+		decodeCategoryTreeRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func CategoryTreeDoc() Doc {
+	return Doc{
+		Summary:  "Árvore das categorias: as raízes com as suas subcategorias, em ordem alfabética",
+		Request:  categoryTreeRequest{},
+		Response: categoryTreeResponse{},
+	}
+}
+
+type categoryMergeRequest struct {
+	ID         string
+	Categories []string `json:"categories"`
+	MID        string   `json:"mid"`
+	Request    *http.Request
+}
+
+type categoryMergeResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodeCategoryMergeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := new(categoryMergeRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.ID = vars["id"]
+	dto.Request = r
+	return dto, nil
+}
+
+func makeCategoryMergeEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*categoryMergeRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewCategoryService(userToken.UserID, userToken.Kind)
+		err = service.MergeCategory(req.ID, req.Categories)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &categoryMergeResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func CategoryMergeHandler() http.Handler {
+	return httptransport.NewServer(
+		makeCategoryMergeEndPoint(),
+		decodeCategoryMergeRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
+
+func CategoryMergeDoc() Doc {
+	return Doc{
+		Summary:  "Junta as categorias do corpo na categoria do path, que fica com as suas postagens e subcategorias, só administradores",
+		Request:  categoryMergeRequest{},
+		Response: categoryMergeResponse{},
+	}
+}
@@ -3,7 +3,6 @@ package resource
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -11,15 +10,18 @@ import (
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
 )
 
 type commentEntity struct {
-	CommentID string `json:"commentID"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	UserID    string `json:"userID"`
-	PostID    string `json:"postID"`
+	CommentID   string `json:"commentID"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	ContentHTML string `json:"contentHTML,omitempty"`
+	UserID      string `json:"userID"`
+	PostID      string `json:"postID"`
 }
 
 type commentStoreRequest struct {
@@ -50,19 +52,19 @@ func makeCommentStoreEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*commentStoreRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCommentService(userToken.UserID, userToken.Kind)
 		err = service.CreateComment(req.PostID, req.Title, req.Content)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postStoreResponse{
@@ -76,20 +78,30 @@ func CommentStoreHandler() http.Handler {
 		makeCommentStoreEndPoint(),
 		decodeCommentStoreRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CommentStoreDoc() Doc {
+	return Doc{
+		Summary:  "Cria um comentário em uma postagem",
+		Request:  commentStoreRequest{},
+		Response: postStoreResponse{},
+	}
+}
+
 type commentListPostRequest struct {
 	PostID string
-	Offset int
-	Limit  int
-	Page   int
-	MID    string
+	Offset int    `query:"offset"`
+	Limit  int    `query:"limit"`
+	Page   int    `query:"page"`
+	Cursor string `query:"cursor"`
+	MID    string `query:"mid"`
 }
 
 type commentListPostResponse struct {
-	Count    int             `json:"count"`
+	pageEntity
 	Comments []commentEntity `json:"comments"`
 	MID      string          `json:"mid"`
 }
@@ -115,6 +127,7 @@ func decodeCommentListPostRequest(ctx context.Context, r *http.Request) (interfa
 		Offset: int(offset),
 		Limit:  int(limit),
 		Page:   int(page),
+		Cursor: r.URL.Query().Get("cursor"),
 		MID:    mid,
 	}
 	return dto, nil
@@ -125,30 +138,35 @@ func makeCommentListPostEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*commentListPostRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		service := service.NewCommentService("", "")
-		comments, count, err := service.ListCommentsPost(req.PostID, req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		comments, page, err := service.ListCommentsPost(req.PostID, p)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []commentEntity
 		for _, v := range comments {
 			entities = append(entities, commentEntity{
-				CommentID: v.CommentID,
-				Title:     v.Title,
-				Content:   v.Content,
-				UserID:    v.UserID,
-				PostID:    v.PostID,
+				CommentID:   v.CommentID,
+				Title:       v.Title,
+				Content:     v.Content,
+				ContentHTML: v.ContentHTML,
+				UserID:      v.UserID,
+				PostID:      v.PostID,
 			})
 		}
 
 		return &commentListPostResponse{
-			Count:    count,
-			Comments: entities,
-			MID:      req.MID,
+			pageEntity: newPageEntity(page),
+			Comments:   entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -158,20 +176,30 @@ func CommentListPostHandler() http.Handler {
 		makeCommentListPostEndPoint(),
 		decodeCommentListPostRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CommentListPostDoc() Doc {
+	return Doc{
+		Summary:  "Lista os comentários de uma postagem",
+		Request:  commentListPostRequest{},
+		Response: commentListPostResponse{},
+	}
+}
+
 type commentListUserRequest struct {
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
 type commentListUserResponse struct {
-	Count    int             `json:"count"`
+	pageEntity
 	Comments []commentEntity `json:"comments"`
 	MID      string          `json:"mid"`
 }
@@ -194,6 +222,7 @@ func decodeCommentListUserRequest(ctx context.Context, r *http.Request) (interfa
 		Offset:  int(offset),
 		Limit:   int(limit),
 		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
 		MID:     mid,
 		Request: r,
 	}
@@ -205,37 +234,42 @@ func makeCommentListUserEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*commentListUserRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1005, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1006, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCommentService(userToken.UserID, userToken.Kind)
-		comments, count, err := service.ListCommentsUser(req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		comments, page, err := service.ListCommentsUser(p)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1007, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []commentEntity
 		for _, v := range comments {
 			entities = append(entities, commentEntity{
-				CommentID: v.CommentID,
-				Title:     v.Title,
-				Content:   v.Content,
-				UserID:    v.UserID,
-				PostID:    v.PostID,
+				CommentID:   v.CommentID,
+				Title:       v.Title,
+				Content:     v.Content,
+				ContentHTML: v.ContentHTML,
+				UserID:      v.UserID,
+				PostID:      v.PostID,
 			})
 		}
 
 		return &commentListUserResponse{
-			Count:    count,
-			Comments: entities,
-			MID:      req.MID,
+			pageEntity: newPageEntity(page),
+			Comments:   entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -245,21 +279,31 @@ func CommentListUserHandler() http.Handler {
 		makeCommentListUserEndPoint(),
 		decodeCommentListUserRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CommentListUserDoc() Doc {
+	return Doc{
+		Summary:  "Lista os comentários do usuário",
+		Request:  commentListUserRequest{},
+		Response: commentListUserResponse{},
+	}
+}
+
 type commentListPostUserRequest struct {
 	PostID  string
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
 type commentListPostUserResponse struct {
-	Count    int             `json:"count"`
+	pageEntity
 	Comments []commentEntity `json:"comments"`
 	MID      string          `json:"mid"`
 }
@@ -285,6 +329,7 @@ func decodecommentListPostUserRequest(ctx context.Context, r *http.Request) (int
 		Offset:  int(offset),
 		Limit:   int(limit),
 		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
 		MID:     mid,
 		Request: r,
 	}
@@ -296,37 +341,42 @@ func makeCommentListPostUserEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*commentListPostUserRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1008, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1009, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCommentService(userToken.UserID, userToken.Kind)
-		comments, count, err := service.ListCommentsPostUser(req.PostID, req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1010, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		comments, page, err := service.ListCommentsPostUser(req.PostID, p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []commentEntity
 		for _, v := range comments {
 			entities = append(entities, commentEntity{
-				CommentID: v.CommentID,
-				Title:     v.Title,
-				Content:   v.Content,
-				UserID:    v.UserID,
-				PostID:    v.PostID,
+				CommentID:   v.CommentID,
+				Title:       v.Title,
+				Content:     v.Content,
+				ContentHTML: v.ContentHTML,
+				UserID:      v.UserID,
+				PostID:      v.PostID,
 			})
 		}
 
 		return &commentListPostUserResponse{
-			Count:    count,
-			Comments: entities,
-			MID:      req.MID,
+			pageEntity: newPageEntity(page),
+			Comments:   entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -336,13 +386,22 @@ func CommentListPostUserHandler() http.Handler {
 		makeCommentListPostUserEndPoint(),
 		decodecommentListPostUserRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CommentListPostUserDoc() Doc {
+	return Doc{
+		Summary:  "Lista os comentários do usuário em uma postagem",
+		Request:  commentListPostUserRequest{},
+		Response: commentListPostUserResponse{},
+	}
+}
+
 type commentFindRequest struct {
 	commentID string
-	MID       string
+	MID       string `query:"mid"`
 	Request   *http.Request
 }
 
@@ -368,29 +427,30 @@ func makeCommentFindendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*commentFindRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1011, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1012, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCommentService(userToken.UserID, userToken.Kind)
 		comment, err := service.FindComment(req.commentID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1013, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &commentFindResponse{
 			commentEntity: commentEntity{
-				CommentID: comment.CommentID,
-				Title:     comment.Title,
-				Content:   comment.CommentID,
-				UserID:    comment.UserID,
-				PostID:    comment.PostID,
+				CommentID:   comment.CommentID,
+				Title:       comment.Title,
+				Content:     comment.Content,
+				ContentHTML: comment.ContentHTML,
+				UserID:      comment.UserID,
+				PostID:      comment.PostID,
 			},
 			MID: req.MID,
 		}, nil
@@ -402,10 +462,19 @@ func CommentFindHandler() http.Handler {
 		makeCommentFindendPoint(),
 		decodeCommentFindRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CommentFindDoc() Doc {
+	return Doc{
+		Summary:  "Busca um comentário pelo id",
+		Request:  commentFindRequest{},
+		Response: commentFindResponse{},
+	}
+}
+
 type commentUpdateRequest struct {
 	commentID string
 	Title     string `json:"title"`
@@ -437,19 +506,19 @@ func makeCommentUpdateEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*commentUpdateRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1014, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1015, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCommentService(userToken.UserID, userToken.Kind)
 		err = service.UpdateComment(req.commentID, req.Title, req.Content)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1016, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &commentUpdateResponse{
@@ -463,13 +532,22 @@ func CommentUpdateHandler() http.Handler {
 		makeCommentUpdateEndPoint(),
 		decodeCommentUpdateRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func CommentUpdateDoc() Doc {
+	return Doc{
+		Summary:  "Atualiza um comentário",
+		Request:  commentUpdateRequest{},
+		Response: commentUpdateResponse{},
+	}
+}
+
 type commentRemoveRequest struct {
 	ID      string
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -493,19 +571,19 @@ func makeCommentRemoveEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*commentRemoveRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1017, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1018, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewCommentService(userToken.UserID, userToken.Kind)
 		err = service.RemoveComment(req.ID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1019, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postRemoveResponse{
@@ -519,6 +597,15 @@ func CommentRemoveHandler() http.Handler {
 		makeCommentRemoveEndPoint(),
 		decodeCommentRemoveRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
+
+func CommentRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Remove um comentário",
+		Request:  commentRemoveRequest{},
+		Response: postRemoveResponse{},
+	}
+}
@@ -3,7 +3,6 @@ package resource
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -12,6 +11,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
 )
 
@@ -52,19 +52,19 @@ func makeConfigsStoreEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*configsStoreRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewConfigsService(userToken.UserID, userToken.Kind)
 		err = service.Store(req.Collors, req.Links, req.MenuAs, req.BannerURL)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &configsStoreResponse{
@@ -78,15 +78,24 @@ func ConfigsStoreHandler() http.Handler {
 		makeConfigsStoreEndPoint(),
 		decodeConfigsStoreRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func ConfigsStoreDoc() Doc {
+	return Doc{
+		Summary:  "Cria as configurações do site",
+		Request:  configsStoreRequest{},
+		Response: configsStoreResponse{},
+	}
+}
+
 type configsListRequest struct {
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -125,19 +134,19 @@ func makeConfigsListEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*configsListRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewConfigsService(userToken.UserID, userToken.Kind)
 		configs, count, err := service.List(req.Offset, req.Limit, req.Page)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []configsEntity
@@ -164,13 +173,22 @@ func ConfigsListHandler() http.Handler {
 		makeConfigsListEndPoint(),
 		decodeConfigsListRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func ConfigsListDoc() Doc {
+	return Doc{
+		Summary:  "Lista as configurações do site",
+		Request:  configsListRequest{},
+		Response: configsListResponse{},
+	}
+}
+
 type configsFindRequest struct {
 	ID      uint
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -200,20 +218,20 @@ func makeConfigsFindendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*configsFindRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1006, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1007, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewConfigsService(userToken.UserID, userToken.Kind)
 		config, err := service.Find(int(req.ID))
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1008, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &configsFindResponse{
@@ -234,10 +252,19 @@ func ConfigsFindHandler() http.Handler {
 		makeConfigsFindendPoint(),
 		decodeConfigsFindRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func ConfigsFindDoc() Doc {
+	return Doc{
+		Summary:  "Busca uma configuração pelo id",
+		Request:  configsFindRequest{},
+		Response: configsFindResponse{},
+	}
+}
+
 type configsUpdateRequest struct {
 	ID        uint
 	Collors   []string `json:"collors"`
@@ -275,19 +302,19 @@ func makeConfigsUpdateEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*configsUpdateRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1009, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1010, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewConfigsService(userToken.UserID, userToken.Kind)
 		err = service.Update(int(req.ID), req.Collors, req.Links, req.MenuAs, req.BannerURL)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1011, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &configsUpdateResponse{
@@ -301,13 +328,22 @@ func ConfigsUpdateHandler() http.Handler {
 		makeConfigsUpdateEndPoint(),
 		decodeConfigsUpdateRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func ConfigsUpdateDoc() Doc {
+	return Doc{
+		Summary:  "Atualiza uma configuração",
+		Request:  configsUpdateRequest{},
+		Response: configsUpdateResponse{},
+	}
+}
+
 type configsRemoveRequest struct {
 	ID      uint
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -335,19 +371,19 @@ func makeConfigsRemoveEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*configsRemoveRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1012, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1013, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewConfigsService(userToken.UserID, userToken.Kind)
 		err = service.Remove(int(req.ID))
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1014, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &configsRemoveResponse{
@@ -361,6 +397,15 @@ func ConfigsRemoveHandler() http.Handler {
 		makeConfigsRemoveEndPoint(),
 		decodeConfigsRemoveRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
+
+func ConfigsRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Remove uma configuração",
+		Request:  configsRemoveRequest{},
+		Response: configsRemoveResponse{},
+	}
+}
@@ -0,0 +1,12 @@
+package resource
+
+// Doc: describes the contract of an endpoint, it's used to generate the openapi document.
+// Request and Response receive a zero value of the endpoint's dto, fields with a json tag
+// are read from the body, fields with a form tag from a multipart body and fields with a
+// query tag from the url queries. Content is the type of an answer that isn't json, a file.
+type Doc struct {
+	Summary  string
+	Request  interface{}
+	Response interface{}
+	Content  string
+}
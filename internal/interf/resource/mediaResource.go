@@ -0,0 +1,355 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
+)
+
+// multipartOverhead: room for the boundaries and the headers of the parts around the file
+const multipartOverhead = 1 << 20
+
+type mediaEntity struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"userID"`
+	Name         string    `json:"name"`
+	MIME         string    `json:"mime"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width,omitempty"`
+	Height       int       `json:"height,omitempty"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnailURL,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func newMediaEntity(media *models.Media) mediaEntity {
+	entity := mediaEntity{
+		ID:        media.MediaID,
+		UserID:    media.UserID,
+		Name:      media.Name,
+		MIME:      media.MIME,
+		Size:      media.Size,
+		Width:     media.Width,
+		Height:    media.Height,
+		URL:       models.MediaPath + media.Key,
+		CreatedAt: media.CreatedAt,
+	}
+	if media.ThumbKey != "" {
+		entity.ThumbnailURL = models.MediaPath + media.ThumbKey
+	}
+	return entity
+}
+
+type mediaUploadRequest struct {
+	File    []byte `form:"file"`
+	Name    string
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type mediaUploadResponse struct {
+	Media mediaEntity `json:"media"`
+	MID   string      `json:"mid"`
+}
+
+// uploadReadError: the body over the limit of the MaxBytesReader is a media too large, the multipart
+// reader keeps only the text of the error, it's the one way to tell it apart (go 1.18 has no MaxBytesError)
+func uploadReadError(err error) error {
+	if strings.Contains(err.Error(), "http: request body too large") {
+		return domainErrors.ErrMediaTooLarge
+	}
+	return domainErrors.ErrInvalidRequest.Wrap(err)
+}
+
+// decodeMediaUploadRequest: reads the part "file" straight from the multipart stream, without
+// temporary files, a file bigger than the limit is read only up to it
+func decodeMediaUploadRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	maxSize, err := service.NewMediaService("", "").MaxSize()
+	if err != nil {
+		return nil, err
+	}
+	if r.ContentLength > maxSize+multipartOverhead {
+		return nil, domainErrors.ErrMediaTooLarge
+	}
+	r.Body = http.MaxBytesReader(nil, r.Body, maxSize+multipartOverhead)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, uploadReadError(err)
+	}
+
+	dto := new(mediaUploadRequest)
+	dto.MID = r.URL.Query().Get("mid")
+	dto.Request = r
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, uploadReadError(err)
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		// one byte over the limit is enough to refuse the file
+		dto.Name = part.FileName()
+		dto.File, err = io.ReadAll(io.LimitReader(part, maxSize+1))
+		if err != nil {
+			return nil, uploadReadError(err)
+		}
+		break
+	}
+
+	return dto, nil
+}
+
+func makeMediaUploadEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*mediaUploadRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewMediaService(userToken.UserID, userToken.Kind)
+		media, err := service.Upload(req.Name, req.File)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &mediaUploadResponse{
+			Media: newMediaEntity(media),
+			MID:   req.MID,
+		}, nil
+	}
+}
+
+func MediaUploadHandler() http.Handler {
+	return httptransport.NewServer(
+		makeMediaUploadEndPoint(),
+		decodeMediaUploadRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func MediaUploadDoc() Doc {
+	return Doc{
+		Summary:  "Envia uma imagem (JPEG, PNG, GIF ou WebP), um conteúdo já enviado devolve a mídia existente",
+		Request:  mediaUploadRequest{},
+		Response: mediaUploadResponse{},
+	}
+}
+
+type mediaListRequest struct {
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type mediaListResponse struct {
+	pageEntity
+	Media []mediaEntity `json:"media"`
+	MID   string        `json:"mid"`
+}
+
+func decodeMediaListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil {
+		offset = 0
+	}
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil {
+		page = 1
+	}
+	dto := &mediaListRequest{
+		Offset:  int(offset),
+		Limit:   int(limit),
+		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
+		MID:     r.URL.Query().Get("mid"),
+		Request: r,
+	}
+	return dto, nil
+}
+
+func makeMediaListEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*mediaListRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewMediaService(userToken.UserID, userToken.Kind)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		medias, page, err := service.List(p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		entities := make([]mediaEntity, 0, len(medias))
+		for i := range medias {
+			entities = append(entities, newMediaEntity(&medias[i]))
+		}
+
+		return &mediaListResponse{
+			pageEntity: newPageEntity(page),
+			Media:      entities,
+			MID:        req.MID,
+		}, nil
+	}
+}
+
+func MediaListHandler() http.Handler {
+	return httptransport.NewServer(
+		makeMediaListEndPoint(),
+		decodeMediaListRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func MediaListDoc() Doc {
+	return Doc{
+		Summary:  "Lista as mídias, os autores veem somente as suas",
+		Request:  mediaListRequest{},
+		Response: mediaListResponse{},
+	}
+}
+
+type mediaRemoveRequest struct {
+	ID      string
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type mediaRemoveResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodeMediaRemoveRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := new(mediaRemoveRequest)
+	dto.ID = vars["id"]
+	dto.MID = r.URL.Query().Get("mid")
+	dto.Request = r
+	return dto, nil
+}
+
+func makeMediaRemoveEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*mediaRemoveRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewMediaService(userToken.UserID, userToken.Kind)
+		err = service.Remove(req.ID)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &mediaRemoveResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func MediaRemoveHandler() http.Handler {
+	return httptransport.NewServer(
+		makeMediaRemoveEndPoint(),
+		decodeMediaRemoveRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func MediaRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Remove uma mídia e os seus arquivos",
+		Request:  mediaRemoveRequest{},
+		Response: mediaRemoveResponse{},
+	}
+}
+
+type mediaFileRequest struct {
+	Key string
+}
+
+// MediaFileHandler: serves the stored file, the key is the hash of the content so it never
+// changes and the browsers may keep it forever
+func MediaFileHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := mux.Vars(r)["key"]
+		service := service.NewMediaService("", "")
+		file, contentType, err := service.Open(key)
+		if err != nil {
+			responseAPI.WriteError(w, r, err, "mf")
+			return
+		}
+		defer file.Close()
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("ETag", `"`+key+`"`)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		http.ServeContent(w, r, key, time.Time{}, file)
+	})
+}
+
+func MediaFileDoc() Doc {
+	return Doc{
+		Summary:  "Arquivo de uma mídia, com cache permanente",
+		Request:  mediaFileRequest{},
+		Response: struct{}{},
+		Content:  "image/*",
+	}
+}
@@ -3,13 +3,13 @@ package resource
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
 	httptransport "github.com/go-kit/kit/transport/http"
 
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
 )
 
@@ -38,19 +38,19 @@ func makeNumberLikesStoreEndPoint() endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(*numberLikesStoreRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		svcToken := service.NewAccessService()
 		userToken, err := svcToken.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		svcNumberLikes := service.NewNumberLikesService(userToken.UserID)
 		err = svcNumberLikes.LikePost(req.PostID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &numberLikesStoreResponse{
@@ -64,10 +64,19 @@ func NumberLikesStoreHandle() http.Handler {
 		makeNumberLikesStoreEndPoint(),
 		decodeNumberLikesStoreRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func NumberLikesStoreDoc() Doc {
+	return Doc{
+		Summary:  "Curte uma postagem",
+		Request:  numberLikesStoreRequest{},
+		Response: numberLikesStoreResponse{},
+	}
+}
+
 type numberLikesRemoveRequest struct {
 	PostID  string `json:"postId"`
 	MID     string `json:"mid"`
@@ -93,19 +102,19 @@ func makeNumberLikesRemoveEndPoint() endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(*numberLikesRemoveRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		svcToken := service.NewAccessService()
 		userToken, err := svcToken.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		svcNumberLikes := service.NewNumberLikesService(userToken.UserID)
 		err = svcNumberLikes.DislikePost(req.PostID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &numberLikesRemoveResponse{
@@ -119,6 +128,15 @@ func NumberLikesRemoveHandle() http.Handler {
 		makeNumberLikesRemoveEndPoint(),
 		decodeNumberLikesRemoveRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
+
+func NumberLikesRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Descurte uma postagem",
+		Request:  numberLikesRemoveRequest{},
+		Response: numberLikesRemoveResponse{},
+	}
+}
@@ -0,0 +1,18 @@
+package resource
+
+import "github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+
+// pageEntity: paging of the list responses, count is only sent for the pages asked by offset
+type pageEntity struct {
+	Count      *int   `json:"count,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+func newPageEntity(page *pagination.Page) pageEntity {
+	return pageEntity{
+		Count:      page.Total,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}
+}
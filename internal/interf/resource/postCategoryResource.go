@@ -3,13 +3,14 @@ package resource
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
 	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
 
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
 )
 
@@ -39,19 +40,19 @@ func makePostCategoryStoreEndPoint() endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(*postCategoryStoreRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		svcToken := service.NewAccessService()
 		userToken, err := svcToken.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		svcpostCategory := service.NewPostCategoryService(userToken.UserID, userToken.Kind)
 		err = svcpostCategory.StorePostCategory(req.PostID, req.Category)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postCategoryStoreResponse{
@@ -65,10 +66,19 @@ func PostCategoryStoreHandle() http.Handler {
 		makePostCategoryStoreEndPoint(),
 		decodePostCategoryStoreRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func PostCategoryStoreDoc() Doc {
+	return Doc{
+		Summary:  "Vincula uma categoria a uma postagem",
+		Request:  postCategoryStoreRequest{},
+		Response: postCategoryStoreResponse{},
+	}
+}
+
 type postCategoryRemoveRequest struct {
 	PostID     string `json:"postId"`
 	CategoryID string `json:"categoryId"`
@@ -95,19 +105,19 @@ func makePostCategoryRemoveEndPoint() endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req, ok := request.(*postCategoryRemoveRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		svcToken := service.NewAccessService()
 		userToken, err := svcToken.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		svcpostCategory := service.NewPostCategoryService(userToken.UserID, userToken.Kind)
 		err = svcpostCategory.RemovePostCategory(req.PostID, req.CategoryID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postCategoryRemoveResponse{
@@ -121,6 +131,151 @@ func PostCategoryRemoveHandle() http.Handler {
 		makePostCategoryRemoveEndPoint(),
 		decodePostCategoryRemoveRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostCategoryRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Desvincula uma categoria de uma postagem",
+		Request:  postCategoryRemoveRequest{},
+		Response: postCategoryRemoveResponse{},
+	}
+}
+
+type postCategorySetRequest struct {
+	PostID     string
+	Categories []string `json:"categories"`
+	MID        string   `json:"mid"`
+	Request    *http.Request
+}
+
+type postCategorySetResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodePostCategorySetRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := new(postCategorySetRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.PostID = vars["postID"]
+	dto.Request = r
+	return dto, nil
+}
+
+func makePostCategorySetEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(*postCategorySetRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		svcToken := service.NewAccessService()
+		userToken, err := svcToken.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		svcpostCategory := service.NewPostCategoryService(userToken.UserID, userToken.Kind)
+		err = svcpostCategory.SetPostCategories(req.PostID, req.Categories)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &postCategorySetResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func PostCategorySetHandle() http.Handler {
+	return httptransport.NewServer(
+		makePostCategorySetEndPoint(),
+		decodePostCategorySetRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostCategorySetDoc() Doc {
+	return Doc{
+		Summary:  "Troca todas as categorias de uma postagem pelas do corpo numa única transação, só administradores. Uma lista vazia remove todas",
+		Request:  postCategorySetRequest{},
+		Response: postCategorySetResponse{},
+	}
+}
+
+type postCategoryBatchRequest struct {
+	CategoryID string
+	Posts      []string `json:"posts"`
+	MID        string   `json:"mid"`
+	Request    *http.Request
+}
+
+type postCategoryBatchResponse struct {
+	Linked int    `json:"linked"`
+	MID    string `json:"mid"`
+}
+
+func decodePostCategoryBatchRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := new(postCategoryBatchRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.CategoryID = vars["id"]
+	dto.Request = r
+	return dto, nil
+}
+
+func makePostCategoryBatchEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req, ok := request.(*postCategoryBatchRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		svcToken := service.NewAccessService()
+		userToken, err := svcToken.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		svcpostCategory := service.NewPostCategoryService(userToken.UserID, userToken.Kind)
+		linked, err := svcpostCategory.StoreCategoryPosts(req.CategoryID, req.Posts)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &postCategoryBatchResponse{
+			Linked: linked,
+			MID:    req.MID,
+		}, nil
+	}
+}
+
+func PostCategoryBatchHandle() http.Handler {
+	return httptransport.NewServer(
+		makePostCategoryBatchEndPoint(),
+		decodePostCategoryBatchRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
+
+func PostCategoryBatchDoc() Doc {
+	return Doc{
+		Summary:  "Vincula a categoria a até 100 postagens numa única transação, só administradores. linked é o número de novos vínculos",
+		Request:  postCategoryBatchRequest{},
+		Response: postCategoryBatchResponse{},
+	}
+}
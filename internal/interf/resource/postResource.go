@@ -3,29 +3,162 @@ package resource
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
+	"net/url"
+	"path"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-kit/kit/endpoint"
 	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/gorilla/mux"
 
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/rateLimit"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/seo"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/views"
 )
 
 type postEntity struct {
-	PostID  string `json:"postID"`
-	Title   string `json:"title"`
-	Content string `json:"content`
-	Likes   int    `json:"likes"`
+	PostID      string            `json:"postID"`
+	Title       string            `json:"title"`
+	Slug        string            `json:"slug,omitempty"`
+	Excerpt     string            `json:"excerpt,omitempty"`
+	Content     string            `json:"content,omitempty"`
+	ContentHTML string            `json:"contentHTML,omitempty"`
+	Words       int               `json:"words,omitempty"`
+	ReadingTime int               `json:"readingTime,omitempty"`
+	TOC         []headingEntity   `json:"toc,omitempty"`
+	Likes       int               `json:"likes"`
+	Views       int               `json:"views"`
+	Author      *authorEntity     `json:"author,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	PublishedAt *time.Time        `json:"publishedAt,omitempty"`
+	PublishAt   *time.Time        `json:"publishAt,omitempty"`
+	Cover       *coverEntity      `json:"cover,omitempty"`
+	SEO         *seoEntity        `json:"seo,omitempty"`
+	Tags        []tagEntity       `json:"tags,omitempty"`
+	Series      *postSeriesEntity `json:"series,omitempty"`
+}
+
+// coverEntity: the image of a post or a category, the urls are served by /media/{key}
+type coverEntity struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnailURL,omitempty"`
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+}
+
+func newCoverEntity(cover *models.Media) *coverEntity {
+	if cover == nil {
+		return nil
+	}
+	entity := &coverEntity{
+		ID:     cover.MediaID,
+		URL:    models.MediaPath + cover.Key,
+		Width:  cover.Width,
+		Height: cover.Height,
+	}
+	if cover.ThumbKey != "" {
+		entity.ThumbnailURL = models.MediaPath + cover.ThumbKey
+	}
+	return entity
+}
+
+// seoEntity: the metadata of the post's page, the empty fields use the title, the excerpt and the cover
+type seoEntity struct {
+	MetaDescription string `json:"metaDescription"`
+	CanonicalURL    string `json:"canonicalURL"`
+	OGTitle         string `json:"ogTitle"`
+	OGDescription   string `json:"ogDescription"`
+	OGImage         string `json:"ogImage"`
+	TwitterCard     string `json:"twitterCard"`
+}
+
+// headingEntity: an entry of the table of contents, id is the anchor of the heading in contentHTML
+type headingEntity struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	ID    string `json:"id"`
+}
+
+// newPostEntity: the lists bring the excerpt, the content only when it's asked with fields=content
+func newPostEntity(post *models.Post, content bool) postEntity {
+	entity := postEntity{
+		PostID:      post.PostID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Excerpt:     post.Summary.Excerpt,
+		Words:       post.Summary.Words,
+		ReadingTime: post.Summary.ReadingTime,
+		Likes:       post.Likes,
+		Views:       post.Views,
+		Author:      newAuthorEntity(post),
+		PublishedAt: post.PublishedAt,
+		Cover:       newCoverEntity(post.Cover),
+		Tags:        newTagEntities(post.Tags),
+		Series:      newPostSeriesEntity(post.Series),
+	}
+	if post.Excerpt != "" {
+		entity.Excerpt = post.Excerpt
+	}
+	if content {
+		postSEO := seoEntity(post.SEO)
+		entity.SEO = &postSEO
+		entity.Content = post.Content
+		entity.ContentHTML = post.ContentHTML
+		entity.TOC = make([]headingEntity, 0, len(post.Summary.TOC))
+		for _, v := range post.Summary.TOC {
+			entity.TOC = append(entity.TOC, headingEntity{
+				Level: v.Level,
+				Text:  v.Text,
+				ID:    v.ID,
+			})
+		}
+	}
+	return entity
+}
+
+// withContent: fields is a list separated by commas
+func withContent(fields string) bool {
+	for _, v := range strings.Split(fields, ",") {
+		if strings.TrimSpace(v) == "content" {
+			return true
+		}
+	}
+	return false
+}
+
+// authorEntity: the writer of a post, the posts created before the authorship have none
+type authorEntity struct {
+	Nick string `json:"nick"`
+	Name string `json:"name"`
+}
+
+func newAuthorEntity(post *models.Post) *authorEntity {
+	if post.AuthorNick == "" {
+		return nil
+	}
+	return &authorEntity{
+		Nick: post.AuthorNick,
+		Name: post.AuthorName,
+	}
 }
 
 type postStoreRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	MID     string `json:"mid"`
+	Title   string    `json:"title"`
+	Content string    `json:"content"`
+	Excerpt string    `json:"excerpt"`
+	CoverID string    `json:"coverID"`
+	SEO     seoEntity `json:"seo"`
+	Tags    []string  `json:"tags"`
+	MID     string    `json:"mid"`
 	Request *http.Request
 }
 
@@ -49,19 +182,19 @@ func makePostStoreEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*postStoreRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewPostService(userToken.UserID, userToken.Kind)
-		err = service.Store(req.Title, req.Content)
+		err = service.Store(req.Title, req.Content, req.Excerpt, req.CoverID, models.PostSEO(req.SEO), req.Tags)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postStoreResponse{
@@ -75,20 +208,37 @@ func PostStoreHandler() http.Handler {
 		makePostStoreEndPoint(),
 		decodePostStoreRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func PostStoreDoc() Doc {
+	return Doc{
+		Summary:  "Cria uma postagem",
+		Request:  postStoreRequest{},
+		Response: postStoreResponse{},
+	}
+}
+
 type postListRequest struct {
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
-	Request *http.Request
+	Offset   int    `query:"offset"`
+	Limit    int    `query:"limit"`
+	Page     int    `query:"page"`
+	Cursor   string `query:"cursor"`
+	Sort     string `query:"sort"`
+	Order    string `query:"order"`
+	Category string `query:"category"`
+	From     string `query:"from"`
+	To       string `query:"to"`
+	Author   string `query:"author"`
+	Fields   string `query:"fields"`
+	MID      string `query:"mid"`
+	Request  *http.Request
 }
 
 type postListResponse struct {
-	Count int          `json:"count"`
+	pageEntity
 	Posts []postEntity `json:"posts"`
 	MID   string       `json:"mid"`
 }
@@ -108,11 +258,19 @@ func decodePostListRequest(ctx context.Context, r *http.Request) (interface{}, e
 	}
 	mid := r.URL.Query().Get("mid")
 	dto := &postListRequest{
-		Offset:  int(offset),
-		Limit:   int(limit),
-		Page:    int(page),
-		MID:     mid,
-		Request: r,
+		Offset:   int(offset),
+		Limit:    int(limit),
+		Page:     int(page),
+		Cursor:   r.URL.Query().Get("cursor"),
+		Sort:     r.URL.Query().Get("sort"),
+		Order:    r.URL.Query().Get("order"),
+		Category: r.URL.Query().Get("category"),
+		From:     r.URL.Query().Get("from"),
+		To:       r.URL.Query().Get("to"),
+		Author:   r.URL.Query().Get("author"),
+		MID:      mid,
+		Request:  r,
+		Fields:   r.URL.Query().Get("fields"),
 	}
 	return dto, nil
 }
@@ -122,34 +280,29 @@ func makePostListEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*postListRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		service := service.NewPostService("", "")
-		posts, err := service.List(req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
-
-		count, err := service.Count()
+		posts, page, err := service.List(req.Sort, req.Order, req.Category, req.From, req.To, req.Author, p)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1006, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []postEntity
-		for _, v := range posts {
-			entities = append(entities, postEntity{
-				PostID:  v.PostID,
-				Title:   v.Title,
-				Content: v.Content,
-				Likes:   v.Likes,
-			})
+		content := withContent(req.Fields)
+		for i := range posts {
+			entities = append(entities, newPostEntity(&posts[i], content))
 		}
 
 		return &postListResponse{
-			Count: count,
-			Posts: entities,
-			MID:   req.MID,
+			pageEntity: newPageEntity(page),
+			Posts:      entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -159,13 +312,22 @@ func PostListHandler() http.Handler {
 		makePostListEndPoint(),
 		decodePostListRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func PostListDoc() Doc {
+	return Doc{
+		Summary:  "Lista as postagens",
+		Request:  postListRequest{},
+		Response: postListResponse{},
+	}
+}
+
 type postFindRequest struct {
 	ID      string
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -191,30 +353,29 @@ func makePostFindendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*postFindRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1007, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1008, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewPostService(userToken.UserID, userToken.Kind)
 		post, err := service.Find(req.ID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1009, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
+		entity := newPostEntity(post, true)
+		entity.Status = post.Status
+		entity.PublishAt = post.PublishAt
+
 		return &postFindResponse{
-			postEntity: postEntity{
-				PostID:  post.PostID,
-				Title:   post.Title,
-				Content: post.Content,
-				Likes:   post.Likes,
-			},
-			MID: req.MID,
+			postEntity: entity,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -224,21 +385,251 @@ func PostFindHandler() http.Handler {
 		makePostFindendPoint(),
 		decodePostFindRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostFindDoc() Doc {
+	return Doc{
+		Summary:  "Busca uma postagem pelo id",
+		Request:  postFindRequest{},
+		Response: postFindResponse{},
+	}
+}
+
+type postSlugRequest struct {
+	Slug    string
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+func decodePostSlugRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := &postSlugRequest{
+		Slug:    vars["slug"],
+		MID:     r.URL.Query().Get("mid"),
+		Request: r,
+	}
+	return dto, nil
+}
+
+// slugLocation: the url of the request with the current slug in the place of the old one
+func slugLocation(r *http.Request, slug string) string {
+	location := path.Dir(r.URL.Path) + "/" + url.PathEscape(slug)
+	if r.URL.RawQuery != "" {
+		location += "?" + r.URL.RawQuery
+	}
+	return location
+}
+
+func makePostSlugEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postSlugRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewPostService("", "")
+		post, err := service.FindSlug(req.Slug, visitOf(req.Request))
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		// an old slug moved for good to the current one
+		if post.Slug != req.Slug {
+			return &responseAPI.Redirect{
+				Status:   http.StatusMovedPermanently,
+				Location: slugLocation(req.Request, post.Slug),
+				MID:      req.MID,
+			}, nil
+		}
+
+		return newPostPublicResponse(post, req.MID), nil
+	}
+}
+
+func PostSlugHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostSlugEndPoint(),
+		decodePostSlugRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostSlugDoc() Doc {
+	return Doc{
+		Summary:  "Busca uma postagem publicada pelo slug, um slug antigo responde 301 com o slug atual no header Location",
+		Request:  postSlugRequest{},
+		Response: postPublicResponse{},
+	}
+}
+
+type postHeadResponse struct {
+	Title  string           `json:"title"`
+	Meta   []seo.Meta       `json:"meta"`
+	Links  []seo.Link       `json:"links"`
+	JSONLD *seo.BlogPosting `json:"jsonLD"`
+	HTML   string           `json:"html"`
+	MID    string           `json:"mid"`
+}
+
+func makePostHeadEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postSlugRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewPostService("", "")
+		head, err := service.Head(req.Slug)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		text, err := head.HTML()
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &postHeadResponse{
+			Title:  head.Title,
+			Meta:   head.Meta,
+			Links:  head.Links,
+			JSONLD: head.JSONLD,
+			HTML:   text,
+			MID:    req.MID,
+		}, nil
+	}
+}
+
+func PostHeadHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostHeadEndPoint(),
+		decodePostSlugRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostHeadDoc() Doc {
+	return Doc{
+		Summary:  "Metadados do <head> da página de uma postagem publicada: título, descrição, url canônica, Open Graph, Twitter card e JSON-LD BlogPosting",
+		Request:  postSlugRequest{},
+		Response: postHeadResponse{},
+	}
+}
+
+// visitOf: the reader of the request whose view is counted, without visitor for the bots
+func visitOf(r *http.Request) views.Visit {
+	if views.IsBot(r.UserAgent()) {
+		return views.Visit{}
+	}
+
+	// the token is optional in the public routes, a logged in user is the same visitor in any device
+	var userID string
+	tokenFunc := service.NewAccessService()
+	userToken, err := tokenFunc.ExtractTokenInfo(r)
+	if err == nil {
+		userID = userToken.UserID
+	}
+
+	// the same day in the hash and in the stored view, the database's date may be of another timezone
+	now := time.Now()
+	return views.Visit{
+		Visitor: views.Visitor(userID, rateLimit.RequestIP(r.Context()), r.UserAgent(), now),
+		Day:     views.Day(now),
+	}
+}
+
+type postPublicRequest struct {
+	ID      string
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type postPublicResponse struct {
+	postEntity
+	Categories  []string               `json:"categories"`
+	Breadcrumbs [][]categoryLinkEntity `json:"breadcrumbs"`
+	Comments    int                    `json:"comments"`
+	MID         string                 `json:"mid"`
+}
+
+func newPostPublicResponse(post *models.Post, mid string) *postPublicResponse {
+	return &postPublicResponse{
+		postEntity:  newPostEntity(post, true),
+		Categories:  post.Categories,
+		Breadcrumbs: newCategoryPaths(post.Breadcrumbs),
+		Comments:    post.Comments,
+		MID:         mid,
+	}
+}
+
+func decodePostPublicRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := &postPublicRequest{
+		ID:      vars["id"],
+		MID:     r.URL.Query().Get("mid"),
+		Request: r,
+	}
+	return dto, nil
+}
+
+func makePostPublicEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postPublicRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewPostService("", "")
+		post, err := service.FindPublic(req.ID, visitOf(req.Request))
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return newPostPublicResponse(post, req.MID), nil
+	}
+}
+
+func PostPublicHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostPublicEndPoint(),
+		decodePostPublicRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func PostPublicDoc() Doc {
+	return Doc{
+		Summary:  "Busca uma postagem publicada com curtidas, categorias, autor e comentários, a visualização é contada uma vez por dia por visitante",
+		Request:  postPublicRequest{},
+		Response: postPublicResponse{},
+	}
+}
+
 type postListTitleRequest struct {
 	title   string
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	Fields  string `query:"fields"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
 type postListTitleResponse struct {
-	Count int          `json:"count"`
+	pageEntity
 	Posts []postEntity `json:"posts"`
 	MID   string       `json:"mid"`
 }
@@ -264,8 +655,10 @@ func decodePostListTitleRequest(ctx context.Context, r *http.Request) (interface
 		Offset:  int(offset),
 		Limit:   int(limit),
 		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
 		MID:     mid,
 		Request: r,
+		Fields:  r.URL.Query().Get("fields"),
 	}
 	return dto, nil
 }
@@ -275,40 +668,35 @@ func makePostListTitleEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*postListTitleRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// tokenFunc := service.NewAccessService()
 		// userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		// if err != nil {
-		// 	return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1004, err, req.MID)
+		// 	return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		// }
 
 		service := service.NewPostService("", "")
-		posts, err := service.ListTitle(req.title, req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
-
-		count, err := service.CountTitle(req.title)
+		posts, page, err := service.ListTitle(req.title, p)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1006, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []postEntity
-		for _, v := range posts {
-			entities = append(entities, postEntity{
-				PostID:  v.PostID,
-				Title:   v.Title,
-				Content: v.Content,
-				Likes:   v.Likes,
-			})
+		content := withContent(req.Fields)
+		for i := range posts {
+			entities = append(entities, newPostEntity(&posts[i], content))
 		}
 
 		return &postListTitleResponse{
-			Count: count,
-			Posts: entities,
-			MID:   req.MID,
+			pageEntity: newPageEntity(page),
+			Posts:      entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -318,15 +706,28 @@ func PostListTitleHandler() http.Handler {
 		makePostListTitleEndPoint(),
 		decodePostListTitleRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func PostListTitleDoc() Doc {
+	return Doc{
+		Summary:  "Lista as postagens pelo titulo",
+		Request:  postListTitleRequest{},
+		Response: postListTitleResponse{},
+	}
+}
+
 type postUpdateRequest struct {
 	ID      string
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	MID     string `json:"mid"`
+	Title   string    `json:"title"`
+	Content string    `json:"content"`
+	Excerpt string    `json:"excerpt"`
+	CoverID string    `json:"coverID"`
+	SEO     seoEntity `json:"seo"`
+	Tags    []string  `json:"tags"`
+	MID     string    `json:"mid"`
 	Request *http.Request
 }
 
@@ -353,19 +754,19 @@ func makePostUpdateEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*postUpdateRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewPostService(userToken.UserID, userToken.Kind)
-		err = service.Update(req.ID, req.Title, req.Content)
+		err = service.Update(req.ID, req.Title, req.Content, req.Excerpt, req.CoverID, models.PostSEO(req.SEO), req.Tags)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postUpdateResponse{
@@ -379,13 +780,22 @@ func PostUpdateHandler() http.Handler {
 		makePostUpdateEndPoint(),
 		decodePostUpdateRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func PostUpdateDoc() Doc {
+	return Doc{
+		Summary:  "Atualiza uma postagem",
+		Request:  postUpdateRequest{},
+		Response: postUpdateResponse{},
+	}
+}
+
 type postRemoveRequest struct {
 	ID      string
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -409,19 +819,19 @@ func makePostRemoveEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*postRemoveRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewPostService(userToken.UserID, userToken.Kind)
 		err = service.Remove(req.ID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &postRemoveResponse{
@@ -435,21 +845,33 @@ func PostRemoveHandler() http.Handler {
 		makePostRemoveEndPoint(),
 		decodePostRemoveRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func PostRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Remove uma postagem",
+		Request:  postRemoveRequest{},
+		Response: postRemoveResponse{},
+	}
+}
+
 type postListCategoryRequest struct {
-	Category string
-	Offset   int
-	Limit    int
-	Page     int
-	MID      string
-	Request  *http.Request
+	Category    string
+	Descendants bool   `query:"descendants"`
+	Offset      int    `query:"offset"`
+	Limit       int    `query:"limit"`
+	Page        int    `query:"page"`
+	Cursor      string `query:"cursor"`
+	Fields      string `query:"fields"`
+	MID         string `query:"mid"`
+	Request     *http.Request
 }
 
 type postListCategoryResponse struct {
-	Count int          `json:"count"`
+	pageEntity
 	Posts []postEntity `json:"posts"`
 	MID   string       `json:"mid"`
 }
@@ -469,14 +891,21 @@ func decodePostListCategoryRequest(ctx context.Context, r *http.Request) (interf
 	if err != nil {
 		page = 1
 	}
+	descendants, err := strconv.ParseBool(r.URL.Query().Get("descendants"))
+	if err != nil {
+		descendants = false
+	}
 	mid := r.URL.Query().Get("mid")
 	dto := &postListCategoryRequest{
-		Category: category,
-		Offset:   int(offset),
-		Limit:    int(limit),
-		Page:     int(page),
-		MID:      mid,
-		Request:  r,
+		Category:    category,
+		Descendants: descendants,
+		Offset:      int(offset),
+		Limit:       int(limit),
+		Page:        int(page),
+		Cursor:      r.URL.Query().Get("cursor"),
+		MID:         mid,
+		Request:     r,
+		Fields:      r.URL.Query().Get("fields"),
 	}
 	return dto, nil
 }
@@ -486,29 +915,29 @@ func makePostListCategoryEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*postListCategoryRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		service := service.NewPostService("", "")
-		posts, count, err := service.ListByCategory(req.Category, req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		posts, page, err := service.ListByCategory(req.Category, req.Descendants, p)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []postEntity
-		for _, v := range posts {
-			entities = append(entities, postEntity{
-				PostID:  v.PostID,
-				Title:   v.Title,
-				Content: v.Content,
-				Likes:   v.Likes,
-			})
+		content := withContent(req.Fields)
+		for i := range posts {
+			entities = append(entities, newPostEntity(&posts[i], content))
 		}
 
 		return &postListTitleResponse{
-			Count: count,
-			Posts: entities,
-			MID:   req.MID,
+			pageEntity: newPageEntity(page),
+			Posts:      entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -518,6 +947,505 @@ func PostListCategoryHandler() http.Handler {
 		makePostListCategoryEndPoint(),
 		decodePostListCategoryRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
+
+func PostListCategoryDoc() Doc {
+	return Doc{
+		Summary:  "Lista as postagens de uma categoria, pelo slug ou pelo nome. Com descendants=true também as das suas subcategorias",
+		Request:  postListCategoryRequest{},
+		Response: postListTitleResponse{},
+	}
+}
+
+type postListAuthorRequest struct {
+	Nick    string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	Fields  string `query:"fields"`
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+func decodePostListAuthorRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	nick := vars["nick"]
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil {
+		offset = 0
+	}
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil {
+		page = 1
+	}
+	mid := r.URL.Query().Get("mid")
+	dto := &postListAuthorRequest{
+		Nick:    nick,
+		Offset:  int(offset),
+		Limit:   int(limit),
+		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
+		MID:     mid,
+		Request: r,
+		Fields:  r.URL.Query().Get("fields"),
+	}
+	return dto, nil
+}
+
+func makePostListAuthorEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postListAuthorRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewPostService("", "")
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		posts, page, err := service.ListByAuthor(req.Nick, p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		var entities []postEntity
+		content := withContent(req.Fields)
+		for i := range posts {
+			entities = append(entities, newPostEntity(&posts[i], content))
+		}
+
+		return &postListTitleResponse{
+			pageEntity: newPageEntity(page),
+			Posts:      entities,
+			MID:        req.MID,
+		}, nil
+	}
+}
+
+func PostListAuthorHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostListAuthorEndPoint(),
+		decodePostListAuthorRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostListAuthorDoc() Doc {
+	return Doc{
+		Summary:  "Lista as postagens publicadas de um autor pelo nick",
+		Request:  postListAuthorRequest{},
+		Response: postListTitleResponse{},
+	}
+}
+
+type postListTagRequest struct {
+	Tag     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	Fields  string `query:"fields"`
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+func decodePostListTagRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil {
+		offset = 0
+	}
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil {
+		page = 1
+	}
+	dto := &postListTagRequest{
+		Tag:     vars["tag"],
+		Offset:  int(offset),
+		Limit:   int(limit),
+		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
+		Fields:  r.URL.Query().Get("fields"),
+		MID:     r.URL.Query().Get("mid"),
+		Request: r,
+	}
+	return dto, nil
+}
+
+func makePostListTagEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postListTagRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewPostService("", "")
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		posts, page, err := service.ListByTag(req.Tag, p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		var entities []postEntity
+		content := withContent(req.Fields)
+		for i := range posts {
+			entities = append(entities, newPostEntity(&posts[i], content))
+		}
+
+		return &postListTitleResponse{
+			pageEntity: newPageEntity(page),
+			Posts:      entities,
+			MID:        req.MID,
+		}, nil
+	}
+}
+
+func PostListTagHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostListTagEndPoint(),
+		decodePostListTagRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostListTagDoc() Doc {
+	return Doc{
+		Summary:  "Lista as postagens publicadas de uma tag, pelo nome ou pelo slug",
+		Request:  postListTagRequest{},
+		Response: postListTitleResponse{},
+	}
+}
+
+type postRelatedRequest struct {
+	ID     string
+	Limit  int    `query:"limit"`
+	Fields string `query:"fields"`
+	MID    string `query:"mid"`
+}
+
+type postRelatedResponse struct {
+	Posts []postEntity `json:"posts"`
+	MID   string       `json:"mid"`
+}
+
+func decodePostRelatedRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 0
+	}
+	dto := &postRelatedRequest{
+		ID:     vars["id"],
+		Limit:  int(limit),
+		Fields: r.URL.Query().Get("fields"),
+		MID:    r.URL.Query().Get("mid"),
+	}
+	return dto, nil
+}
+
+func makePostRelatedEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postRelatedRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewPostService("", "")
+		posts, err := service.Related(req.ID, req.Limit)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		entities := make([]postEntity, 0, len(posts))
+		content := withContent(req.Fields)
+		for i := range posts {
+			entities = append(entities, newPostEntity(&posts[i], content))
+		}
+
+		return &postRelatedResponse{
+			Posts: entities,
+			MID:   req.MID,
+		}, nil
+	}
+}
+
+func PostRelatedHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostRelatedEndPoint(),
+		decodePostRelatedRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostRelatedDoc() Doc {
+	return Doc{
+		Summary:  "Lista as postagens relacionadas a uma postagem publicada, pelas categorias e tags em comum e pelo texto",
+		Request:  postRelatedRequest{},
+		Response: postRelatedResponse{},
+	}
+}
+
+type postListOwnRequest struct {
+	Status  string `query:"status"`
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	Fields  string `query:"fields"`
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type postListOwnResponse struct {
+	pageEntity
+	Posts []postEntity `json:"posts"`
+	MID   string       `json:"mid"`
+}
+
+func decodePostListOwnRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil {
+		offset = 0
+	}
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil {
+		page = 1
+	}
+	mid := r.URL.Query().Get("mid")
+	dto := &postListOwnRequest{
+		Status:  r.URL.Query().Get("status"),
+		Offset:  int(offset),
+		Limit:   int(limit),
+		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
+		MID:     mid,
+		Request: r,
+		Fields:  r.URL.Query().Get("fields"),
+	}
+	return dto, nil
+}
+
+func makePostListOwnEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postListOwnRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewPostService(userToken.UserID, userToken.Kind)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		posts, page, err := service.ListOwn(req.Status, p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		var entities []postEntity
+		content := withContent(req.Fields)
+		for i := range posts {
+			entity := newPostEntity(&posts[i], content)
+			entity.Status = posts[i].Status
+			entity.PublishAt = posts[i].PublishAt
+			entities = append(entities, entity)
+		}
+
+		return &postListOwnResponse{
+			pageEntity: newPageEntity(page),
+			Posts:      entities,
+			MID:        req.MID,
+		}, nil
+	}
+}
+
+func PostListOwnHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostListOwnEndPoint(),
+		decodePostListOwnRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostListOwnDoc() Doc {
+	return Doc{
+		Summary:  "Lista as postagens do usuário em qualquer status (rascunhos, em revisão, publicadas e arquivadas)",
+		Request:  postListOwnRequest{},
+		Response: postListOwnResponse{},
+	}
+}
+
+type postStatusRequest struct {
+	ID      string
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type postStatusResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodePostStatusRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	mid := r.URL.Query().Get("mid")
+	dto := &postStatusRequest{
+		ID:      id,
+		MID:     mid,
+		Request: r,
+	}
+	return dto, nil
+}
+
+// makePostStatusEndPoint: the endpoints of the workflow only differ by the action
+func makePostStatusEndPoint(action string) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postStatusRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewPostService(userToken.UserID, userToken.Kind)
+		err = service.ChangeStatus(req.ID, action)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &postStatusResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+// PostStatusHandler: action is submit, approve, publish, unpublish, unschedule or archive
+func PostStatusHandler(action string) http.Handler {
+	return httptransport.NewServer(
+		makePostStatusEndPoint(action),
+		decodePostStatusRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostStatusDoc(summary string) Doc {
+	return Doc{
+		Summary:  summary,
+		Request:  postStatusRequest{},
+		Response: postStatusResponse{},
+	}
+}
+
+type postScheduleRequest struct {
+	ID        string
+	PublishAt string `json:"publishAt"`
+	MID       string `json:"mid"`
+	Request   *http.Request
+}
+
+type postScheduleResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodePostScheduleRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	dto := new(postScheduleRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.ID = id
+	dto.Request = r
+	return dto, nil
+}
+
+func makePostScheduleEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postScheduleRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewPostService(userToken.UserID, userToken.Kind)
+		err = service.Schedule(req.ID, req.PublishAt)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &postScheduleResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func PostScheduleHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostScheduleEndPoint(),
+		decodePostScheduleRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostScheduleDoc() Doc {
+	return Doc{
+		Summary:  "Agenda a publicação da postagem, publishAt em RFC 3339",
+		Request:  postScheduleRequest{},
+		Response: postScheduleResponse{},
+	}
+}
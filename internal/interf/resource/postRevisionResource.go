@@ -0,0 +1,320 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
+)
+
+type postRevisionEntity struct {
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	Content      string    `json:"content,omitempty"`
+	UserID       string    `json:"userID,omitempty"`
+	RestoredFrom int       `json:"restoredFrom,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func newPostRevisionEntity(revision *models.PostRevision, content bool) postRevisionEntity {
+	entity := postRevisionEntity{
+		Number:       revision.Number,
+		Title:        revision.Title,
+		UserID:       revision.UserID,
+		RestoredFrom: revision.RestoredFrom,
+		CreatedAt:    revision.CreatedAt,
+	}
+	if content {
+		entity.Content = revision.Content
+	}
+	return entity
+}
+
+type postRevisionListRequest struct {
+	ID      string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type postRevisionListResponse struct {
+	pageEntity
+	Revisions []postRevisionEntity `json:"revisions"`
+	MID       string               `json:"mid"`
+}
+
+func decodePostRevisionListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil {
+		offset = 0
+	}
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil {
+		page = 1
+	}
+	dto := &postRevisionListRequest{
+		ID:      vars["id"],
+		Offset:  int(offset),
+		Limit:   int(limit),
+		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
+		MID:     r.URL.Query().Get("mid"),
+		Request: r,
+	}
+	return dto, nil
+}
+
+func makePostRevisionListEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postRevisionListRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewPostRevisionService(userToken.UserID, userToken.Kind)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		revisions, page, err := service.List(req.ID, p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		entities := make([]postRevisionEntity, 0, len(revisions))
+		for i := range revisions {
+			entities = append(entities, newPostRevisionEntity(&revisions[i], true))
+		}
+
+		return &postRevisionListResponse{
+			pageEntity: newPageEntity(page),
+			Revisions:  entities,
+			MID:        req.MID,
+		}, nil
+	}
+}
+
+func PostRevisionListHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostRevisionListEndPoint(),
+		decodePostRevisionListRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostRevisionListDoc() Doc {
+	return Doc{
+		Summary:  "Lista as revisões da postagem, da mais nova para a mais antiga",
+		Request:  postRevisionListRequest{},
+		Response: postRevisionListResponse{},
+	}
+}
+
+type diffLineEntity struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+func newDiffLineEntities(lines []models.DiffLine) []diffLineEntity {
+	entities := make([]diffLineEntity, 0, len(lines))
+	for _, v := range lines {
+		entities = append(entities, diffLineEntity{
+			Op:   v.Op,
+			Text: v.Text,
+		})
+	}
+	return entities
+}
+
+type postRevisionDiffRequest struct {
+	ID      string
+	From    int    `query:"from"`
+	To      int    `query:"to"`
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type postRevisionDiffResponse struct {
+	From    postRevisionEntity `json:"from"`
+	To      postRevisionEntity `json:"to"`
+	Title   []diffLineEntity   `json:"title"`
+	Content []diffLineEntity   `json:"content"`
+	MID     string             `json:"mid"`
+}
+
+// queryInt: an absent param is 0, an invalid one is a bad request
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func decodePostRevisionDiffRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	from, err := queryInt(r, "from")
+	if err != nil {
+		return nil, err
+	}
+	to, err := queryInt(r, "to")
+	if err != nil {
+		return nil, err
+	}
+	dto := &postRevisionDiffRequest{
+		ID:      vars["id"],
+		From:    from,
+		To:      to,
+		MID:     r.URL.Query().Get("mid"),
+		Request: r,
+	}
+	return dto, nil
+}
+
+func makePostRevisionDiffEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postRevisionDiffRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewPostRevisionService(userToken.UserID, userToken.Kind)
+		result, err := service.Diff(req.ID, req.From, req.To)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &postRevisionDiffResponse{
+			From:    newPostRevisionEntity(result.From, false),
+			To:      newPostRevisionEntity(result.To, false),
+			Title:   newDiffLineEntities(result.Title),
+			Content: newDiffLineEntities(result.Content),
+			MID:     req.MID,
+		}, nil
+	}
+}
+
+func PostRevisionDiffHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostRevisionDiffEndPoint(),
+		decodePostRevisionDiffRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostRevisionDiffDoc() Doc {
+	return Doc{
+		Summary:  "Diferença linha a linha entre duas revisões da postagem, por padrão entre a última e a anterior",
+		Request:  postRevisionDiffRequest{},
+		Response: postRevisionDiffResponse{},
+	}
+}
+
+type postRevisionRestoreRequest struct {
+	ID      string
+	Number  int
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type postRevisionRestoreResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodePostRevisionRestoreRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	number, err := strconv.Atoi(vars["number"])
+	if err != nil {
+		return nil, err
+	}
+	dto := &postRevisionRestoreRequest{
+		ID:      vars["id"],
+		Number:  number,
+		MID:     r.URL.Query().Get("mid"),
+		Request: r,
+	}
+	return dto, nil
+}
+
+func makePostRevisionRestoreEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*postRevisionRestoreRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewPostRevisionService(userToken.UserID, userToken.Kind)
+		err = service.Restore(req.ID, req.Number)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &postRevisionRestoreResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func PostRevisionRestoreHandler() http.Handler {
+	return httptransport.NewServer(
+		makePostRevisionRestoreEndPoint(),
+		decodePostRevisionRestoreRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func PostRevisionRestoreDoc() Doc {
+	return Doc{
+		Summary:  "Restaura uma revisão antiga, ela é salva como uma nova revisão",
+		Request:  postRevisionRestoreRequest{},
+		Response: postRevisionRestoreResponse{},
+	}
+}
@@ -3,7 +3,6 @@ package resource
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -12,6 +11,8 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
 )
 
@@ -19,6 +20,7 @@ type responseCommentEntity struct {
 	ResponseCommentID string `json:"responseCommentId"`
 	Title             string `json:"title"`
 	Content           string `json:"content"`
+	ContentHTML       string `json:"contentHTML,omitempty"`
 	CommentID         string `json:"commentId"`
 	UserID            string `json:"userId"`
 }
@@ -51,19 +53,19 @@ func makeResponseCommentStoreEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*responseCommentStoreRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewResponseCommentService(userToken.UserID, userToken.Kind)
 		err = service.Store(req.CommentID, req.Title, req.Content)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1002, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &responseCommentStoreResponse{
@@ -77,21 +79,31 @@ func ResponseCommentStoreHandler() http.Handler {
 		makeResponseCommentStoreEndPoint(),
 		decodeResponseCommentStoreRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func ResponseCommentStoreDoc() Doc {
+	return Doc{
+		Summary:  "Responde um comentário",
+		Request:  responseCommentStoreRequest{},
+		Response: responseCommentStoreResponse{},
+	}
+}
+
 type responseCommentListRequest struct {
 	CommentID string
-	Offset    int
-	Limit     int
-	Page      int
-	MID       string
+	Offset    int    `query:"offset"`
+	Limit     int    `query:"limit"`
+	Page      int    `query:"page"`
+	Cursor    string `query:"cursor"`
+	MID       string `query:"mid"`
 	Request   *http.Request
 }
 
 type responseCommentListResponse struct {
-	Count           int                     `json:"count"`
+	pageEntity
 	ReponseComments []responseCommentEntity `json:"responseComments"`
 	MID             string                  `json:"mid"`
 }
@@ -117,6 +129,7 @@ func decoderesponseCommentListRequest(ctx context.Context, r *http.Request) (int
 		Offset:    int(offset),
 		Limit:     int(limit),
 		Page:      int(page),
+		Cursor:    r.URL.Query().Get("cursor"),
 		MID:       mid,
 		Request:   r,
 	}
@@ -128,13 +141,17 @@ func makeResponseCommentListEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*responseCommentListRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		service := service.NewResponseCommentService("", "")
-		responseComments, count, err := service.List(req.CommentID, req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		responseComments, page, err := service.List(req.CommentID, p)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []responseCommentEntity
@@ -143,13 +160,14 @@ func makeResponseCommentListEndPoint() endpoint.Endpoint {
 				CommentID:         v.CommentID,
 				Title:             v.Title,
 				Content:           v.Content,
+				ContentHTML:       v.ContentHTML,
 				UserID:            v.UserID,
 				ResponseCommentID: v.ResponseCommentID,
 			})
 		}
 
 		return &responseCommentListResponse{
-			Count:           count,
+			pageEntity:      newPageEntity(page),
 			ReponseComments: entities,
 			MID:             req.MID,
 		}, nil
@@ -161,20 +179,30 @@ func ResponseCommentListHandler() http.Handler {
 		makeResponseCommentListEndPoint(),
 		decoderesponseCommentListRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func ResponseCommentListDoc() Doc {
+	return Doc{
+		Summary:  "Lista as respostas de um comentário",
+		Request:  responseCommentListRequest{},
+		Response: responseCommentListResponse{},
+	}
+}
+
 type responseCommentListUserRequest struct {
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
 type responseCommentListUserResponse struct {
-	Count           int                     `json:"count"`
+	pageEntity
 	ReponseComments []responseCommentEntity `json:"responseComments"`
 	MID             string                  `json:"mid"`
 }
@@ -197,6 +225,7 @@ func decoderesponseCommentListUserRequest(ctx context.Context, r *http.Request)
 		Offset:  int(offset),
 		Limit:   int(limit),
 		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
 		MID:     mid,
 		Request: r,
 	}
@@ -208,19 +237,23 @@ func makeResponseCommentListUserEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*responseCommentListUserRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1003, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewResponseCommentService(userToken.UserID, userToken.Kind)
-		responseComments, count, err := service.ListUser(req.Offset, req.Limit, req.Page)
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		responseComments, page, err := service.ListUser(p)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []responseCommentEntity
@@ -229,13 +262,14 @@ func makeResponseCommentListUserEndPoint() endpoint.Endpoint {
 				CommentID:         v.CommentID,
 				Title:             v.Title,
 				Content:           v.Content,
+				ContentHTML:       v.ContentHTML,
 				UserID:            v.UserID,
 				ResponseCommentID: v.ResponseCommentID,
 			})
 		}
 
 		return &responseCommentListUserResponse{
-			Count:           count,
+			pageEntity:      newPageEntity(page),
 			ReponseComments: entities,
 			MID:             req.MID,
 		}, nil
@@ -247,10 +281,19 @@ func ResponseCommentListUserHandler() http.Handler {
 		makeResponseCommentListUserEndPoint(),
 		decoderesponseCommentListUserRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func ResponseCommentListUserDoc() Doc {
+	return Doc{
+		Summary:  "Lista as respostas do usuário",
+		Request:  responseCommentListUserRequest{},
+		Response: responseCommentListUserResponse{},
+	}
+}
+
 type responseCommentUpdateRequest struct {
 	ResponseCommentID string
 	Title             string `json:"title"`
@@ -282,19 +325,19 @@ func makeResponseCommentUpdateEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*responseCommentUpdateRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1014, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1015, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewResponseCommentService(userToken.UserID, userToken.Kind)
 		err = service.Update(req.ResponseCommentID, req.Title, req.Content)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1016, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &responseCommentUpdateResponse{
@@ -308,13 +351,22 @@ func ResponseCommentUpdateHandler() http.Handler {
 		makeResponseCommentUpdateEndPoint(),
 		decoderesponseCommentUpdateRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func ResponseCommentUpdateDoc() Doc {
+	return Doc{
+		Summary:  "Atualiza uma resposta",
+		Request:  responseCommentUpdateRequest{},
+		Response: responseCommentUpdateResponse{},
+	}
+}
+
 type responseCommentRemoveRequest struct {
 	ID      string
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -338,19 +390,19 @@ func makeResponseCommentRemoveEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*responseCommentRemoveRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1017, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1018, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewResponseCommentService(userToken.UserID, userToken.Kind)
 		err = service.Remove(req.ID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1019, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &responseCommentRemoveResponse{
@@ -364,6 +416,15 @@ func ResponseCommentRemoveHandler() http.Handler {
 		makeResponseCommentRemoveEndPoint(),
 		decoderesponseCommentRemoveRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
+
+func ResponseCommentRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Remove uma resposta",
+		Request:  responseCommentRemoveRequest{},
+		Response: responseCommentRemoveResponse{},
+	}
+}
@@ -0,0 +1,165 @@
+package resource
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
+)
+
+type searchPostEntity struct {
+	postEntity
+	Rank            float64               `json:"rank"`
+	TitleHeadline   string                `json:"titleHeadline"`
+	ContentHeadline string                `json:"contentHeadline"`
+	Comments        []searchCommentEntity `json:"comments,omitempty"`
+}
+
+type searchCommentEntity struct {
+	commentEntity
+	Rank            float64 `json:"rank"`
+	ContentHeadline string  `json:"contentHeadline"`
+}
+
+func newSearchCommentEntity(comment *models.SearchComment) searchCommentEntity {
+	return searchCommentEntity{
+		commentEntity: commentEntity{
+			CommentID: comment.CommentID,
+			Title:     comment.Title,
+			Content:   comment.Content,
+			UserID:    comment.UserID,
+			PostID:    comment.PostID,
+		},
+		Rank:            comment.Rank,
+		ContentHeadline: comment.ContentHeadline,
+	}
+}
+
+type searchRequest struct {
+	Q        string `query:"q"`
+	Type     string `query:"type"`
+	Comments bool   `query:"comments"`
+	Offset   int    `query:"offset"`
+	Limit    int    `query:"limit"`
+	Page     int    `query:"page"`
+	Cursor   string `query:"cursor"`
+	MID      string `query:"mid"`
+	Request  *http.Request
+}
+
+type searchResponse struct {
+	pageEntity
+	Posts    []searchPostEntity    `json:"posts,omitempty"`
+	Comments []searchCommentEntity `json:"comments,omitempty"`
+	MID      string                `json:"mid"`
+}
+
+func decodeSearchRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil {
+		offset = 0
+	}
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil {
+		page = 1
+	}
+	comments, err := strconv.ParseBool(r.URL.Query().Get("comments"))
+	if err != nil {
+		comments = false
+	}
+	mid := r.URL.Query().Get("mid")
+	dto := &searchRequest{
+		Q:        r.URL.Query().Get("q"),
+		Type:     r.URL.Query().Get("type"),
+		Comments: comments,
+		Offset:   int(offset),
+		Limit:    int(limit),
+		Page:     int(page),
+		Cursor:   r.URL.Query().Get("cursor"),
+		MID:      mid,
+		Request:  r,
+	}
+	return dto, nil
+}
+
+func makeSearchEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*searchRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewSearchService()
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		posts, comments, page, err := service.Search(req.Q, req.Type, req.Comments, p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		var postEntities []searchPostEntity
+		for _, v := range posts {
+			entity := searchPostEntity{
+				postEntity: postEntity{
+					PostID:  v.PostID,
+					Title:   v.Title,
+					Slug:    v.Slug,
+					Content: v.Content,
+					Likes:   v.Likes,
+					Author:  newAuthorEntity(&v.Post),
+				},
+				Rank:            v.Rank,
+				TitleHeadline:   v.TitleHeadline,
+				ContentHeadline: v.ContentHeadline,
+			}
+			for i := range v.Comments {
+				entity.Comments = append(entity.Comments, newSearchCommentEntity(&v.Comments[i]))
+			}
+			postEntities = append(postEntities, entity)
+		}
+
+		var commentEntities []searchCommentEntity
+		for i := range comments {
+			commentEntities = append(commentEntities, newSearchCommentEntity(&comments[i]))
+		}
+
+		return &searchResponse{
+			pageEntity: newPageEntity(page),
+			Posts:      postEntities,
+			Comments:   commentEntities,
+			MID:        req.MID,
+		}, nil
+	}
+}
+
+func SearchHandler() http.Handler {
+	return httptransport.NewServer(
+		makeSearchEndPoint(),
+		decodeSearchRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func SearchDoc() Doc {
+	return Doc{
+		Summary:  "Busca postagens (ou comentários, com type=comments) pelo título e conteúdo. Com comments=true cada postagem traz também os seus comentários encontrados",
+		Request:  searchRequest{},
+		Response: searchResponse{},
+	}
+}
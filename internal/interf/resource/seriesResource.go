@@ -0,0 +1,556 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
+)
+
+// seriesPartEntity: a published post of a series, position starts at 1
+type seriesPartEntity struct {
+	PostID      string     `json:"postID"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Position    int        `json:"position"`
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+}
+
+func newSeriesPartEntity(part *models.SeriesPart) *seriesPartEntity {
+	if part == nil {
+		return nil
+	}
+	return &seriesPartEntity{
+		PostID:      part.PostID,
+		Title:       part.Title,
+		Slug:        part.Slug,
+		Position:    part.Position,
+		PublishedAt: part.PublishedAt,
+	}
+}
+
+// postSeriesEntity: the navigation of a post in its series, previous and next are absent at the ends
+type postSeriesEntity struct {
+	ID       string            `json:"id"`
+	Title    string            `json:"title"`
+	Slug     string            `json:"slug"`
+	Position int               `json:"position"`
+	Parts    int               `json:"parts"`
+	Previous *seriesPartEntity `json:"previous,omitempty"`
+	Next     *seriesPartEntity `json:"next,omitempty"`
+}
+
+func newPostSeriesEntity(series *models.PostSeries) *postSeriesEntity {
+	if series == nil {
+		return nil
+	}
+	return &postSeriesEntity{
+		ID:       series.SeriesID,
+		Title:    series.Title,
+		Slug:     series.Slug,
+		Position: series.Position,
+		Parts:    series.Parts,
+		Previous: newSeriesPartEntity(series.Previous),
+		Next:     newSeriesPartEntity(series.Next),
+	}
+}
+
+// seriesEntity: posts is the number of published parts, the parts only come with a single series
+type seriesEntity struct {
+	ID          string             `json:"id"`
+	Title       string             `json:"title"`
+	Slug        string             `json:"slug"`
+	Description string             `json:"description,omitempty"`
+	Posts       int                `json:"posts"`
+	Parts       []seriesPartEntity `json:"parts,omitempty"`
+}
+
+func newSeriesEntity(series *models.Series) seriesEntity {
+	entity := seriesEntity{
+		ID:          series.SeriesID,
+		Title:       series.Title,
+		Slug:        series.Slug,
+		Description: series.Description,
+		Posts:       series.Posts,
+	}
+	for i := range series.Parts {
+		entity.Parts = append(entity.Parts, *newSeriesPartEntity(&series.Parts[i]))
+	}
+	return entity
+}
+
+type seriesStoreRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	MID         string `json:"mid"`
+	Request     *http.Request
+}
+
+type seriesStoreResponse struct {
+	ID  string `json:"id"`
+	MID string `json:"mid"`
+}
+
+func decodeSeriesStoreRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	dto := new(seriesStoreRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.Request = r
+	return dto, nil
+}
+
+func makeSeriesStoreEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*seriesStoreRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewSeriesService(userToken.UserID, userToken.Kind)
+		id, err := service.Store(req.Title, req.Description)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &seriesStoreResponse{
+			ID:  id,
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func SeriesStoreHandler() http.Handler {
+	return httptransport.NewServer(
+		makeSeriesStoreEndPoint(),
+		decodeSeriesStoreRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func SeriesStoreDoc() Doc {
+	return Doc{
+		Summary:  "Cria uma série de postagens, autores, editores e administradores. O slug é feito do título",
+		Request:  seriesStoreRequest{},
+		Response: seriesStoreResponse{},
+	}
+}
+
+type seriesListRequest struct {
+	Offset int    `query:"offset"`
+	Limit  int    `query:"limit"`
+	Page   int    `query:"page"`
+	Cursor string `query:"cursor"`
+	MID    string `query:"mid"`
+}
+
+type seriesListResponse struct {
+	pageEntity
+	Series []seriesEntity `json:"series"`
+	MID    string         `json:"mid"`
+}
+
+func decodeSeriesListRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	offset, err := strconv.ParseInt(r.URL.Query().Get("offset"), 10, 64)
+	if err != nil {
+		offset = 0
+	}
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 10
+	}
+	page, err := strconv.ParseInt(r.URL.Query().Get("page"), 10, 64)
+	if err != nil {
+		page = 1
+	}
+	dto := &seriesListRequest{
+		Offset: int(offset),
+		Limit:  int(limit),
+		Page:   int(page),
+		Cursor: r.URL.Query().Get("cursor"),
+		MID:    r.URL.Query().Get("mid"),
+	}
+	return dto, nil
+}
+
+func makeSeriesListEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*seriesListRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewSeriesService("", "")
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+		list, page, err := service.List(p)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		entities := make([]seriesEntity, 0, len(list))
+		for i := range list {
+			entities = append(entities, newSeriesEntity(&list[i]))
+		}
+
+		return &seriesListResponse{
+			pageEntity: newPageEntity(page),
+			Series:     entities,
+			MID:        req.MID,
+		}, nil
+	}
+}
+
+func SeriesListHandler() http.Handler {
+	return httptransport.NewServer(
+		makeSeriesListEndPoint(),
+		decodeSeriesListRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func SeriesListDoc() Doc {
+	return Doc{
+		Summary:  "Lista as séries com postagens publicadas e o número delas",
+		Request:  seriesListRequest{},
+		Response: seriesListResponse{},
+	}
+}
+
+type seriesFindRequest struct {
+	ID  string
+	MID string `query:"mid"`
+}
+
+type seriesFindResponse struct {
+	seriesEntity
+	MID string `json:"mid"`
+}
+
+func decodeSeriesFindRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := &seriesFindRequest{
+		ID:  vars["id"],
+		MID: r.URL.Query().Get("mid"),
+	}
+	return dto, nil
+}
+
+func makeSeriesFindEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*seriesFindRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewSeriesService("", "")
+		series, err := service.Find(req.ID)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &seriesFindResponse{
+			seriesEntity: newSeriesEntity(series),
+			MID:          req.MID,
+		}, nil
+	}
+}
+
+func SeriesFindHandler() http.Handler {
+	return httptransport.NewServer(
+		makeSeriesFindEndPoint(),
+		decodeSeriesFindRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func SeriesFindDoc() Doc {
+	return Doc{
+		Summary:  "Busca uma série pelo id com as suas postagens publicadas em ordem",
+		Request:  seriesFindRequest{},
+		Response: seriesFindResponse{},
+	}
+}
+
+type seriesSlugRequest struct {
+	Slug string
+	MID  string `query:"mid"`
+}
+
+func decodeSeriesSlugRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := &seriesSlugRequest{
+		Slug: vars["slug"],
+		MID:  r.URL.Query().Get("mid"),
+	}
+	return dto, nil
+}
+
+func makeSeriesSlugEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*seriesSlugRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewSeriesService("", "")
+		series, err := service.FindSlug(req.Slug)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &seriesFindResponse{
+			seriesEntity: newSeriesEntity(series),
+			MID:          req.MID,
+		}, nil
+	}
+}
+
+func SeriesSlugHandler() http.Handler {
+	return httptransport.NewServer(
+		makeSeriesSlugEndPoint(),
+		decodeSeriesSlugRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func SeriesSlugDoc() Doc {
+	return Doc{
+		Summary:  "Busca uma série pelo slug com as suas postagens publicadas em ordem",
+		Request:  seriesSlugRequest{},
+		Response: seriesFindResponse{},
+	}
+}
+
+type seriesUpdateRequest struct {
+	ID          string
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	MID         string `json:"mid"`
+	Request     *http.Request
+}
+
+type seriesUpdateResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodeSeriesUpdateRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := new(seriesUpdateRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.ID = vars["id"]
+	dto.Request = r
+	return dto, nil
+}
+
+func makeSeriesUpdateEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*seriesUpdateRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewSeriesService(userToken.UserID, userToken.Kind)
+		err = service.Update(req.ID, req.Title, req.Description)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &seriesUpdateResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func SeriesUpdateHandler() http.Handler {
+	return httptransport.NewServer(
+		makeSeriesUpdateEndPoint(),
+		decodeSeriesUpdateRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func SeriesUpdateDoc() Doc {
+	return Doc{
+		Summary:  "Atualiza o título e a descrição de uma série, o slug não muda. Os autores só as suas",
+		Request:  seriesUpdateRequest{},
+		Response: seriesUpdateResponse{},
+	}
+}
+
+type seriesRemoveRequest struct {
+	ID      string
+	MID     string `query:"mid"`
+	Request *http.Request
+}
+
+type seriesRemoveResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodeSeriesRemoveRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := &seriesRemoveRequest{
+		ID:      vars["id"],
+		MID:     r.URL.Query().Get("mid"),
+		Request: r,
+	}
+	return dto, nil
+}
+
+func makeSeriesRemoveEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*seriesRemoveRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewSeriesService(userToken.UserID, userToken.Kind)
+		err = service.Remove(req.ID)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &seriesRemoveResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func SeriesRemoveHandler() http.Handler {
+	return httptransport.NewServer(
+		makeSeriesRemoveEndPoint(),
+		decodeSeriesRemoveRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func SeriesRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Remove uma série, as suas postagens continuam fora de qualquer série",
+		Request:  seriesRemoveRequest{},
+		Response: seriesRemoveResponse{},
+	}
+}
+
+type seriesPartsRequest struct {
+	ID      string
+	Posts   []string `json:"posts"`
+	MID     string   `json:"mid"`
+	Request *http.Request
+}
+
+type seriesPartsResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodeSeriesPartsRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := new(seriesPartsRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.ID = vars["id"]
+	dto.Request = r
+	return dto, nil
+}
+
+func makeSeriesPartsEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*seriesPartsRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewSeriesService(userToken.UserID, userToken.Kind)
+		err = service.SetParts(req.ID, req.Posts)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &seriesPartsResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func SeriesPartsHandler() http.Handler {
+	return httptransport.NewServer(
+		makeSeriesPartsEndPoint(),
+		decodeSeriesPartsRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func SeriesPartsDoc() Doc {
+	return Doc{
+		Summary:  "As postagens do corpo, na sua ordem, passam a ser todas as partes da série numa única transação. Serve também para reordenar",
+		Request:  seriesPartsRequest{},
+		Response: seriesPartsResponse{},
+	}
+}
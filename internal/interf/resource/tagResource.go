@@ -0,0 +1,246 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/models"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
+)
+
+// tagEntity: posts is the number of published posts, only in the cloud
+type tagEntity struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Posts int    `json:"posts,omitempty"`
+}
+
+func newTagEntities(tags []models.Tag) []tagEntity {
+	if tags == nil {
+		return nil
+	}
+	entities := make([]tagEntity, 0, len(tags))
+	for _, v := range tags {
+		entities = append(entities, tagEntity{
+			ID:   v.TagID,
+			Name: v.Name,
+			Slug: v.Slug,
+		})
+	}
+	return entities
+}
+
+type tagCloudRequest struct {
+	Limit int    `query:"limit"`
+	MID   string `query:"mid"`
+}
+
+type tagCloudResponse struct {
+	Tags []tagEntity `json:"tags"`
+	MID  string      `json:"mid"`
+}
+
+func decodeTagCloudRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	limit, err := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 64)
+	if err != nil {
+		limit = 0
+	}
+	dto := &tagCloudRequest{
+		Limit: int(limit),
+		MID:   r.URL.Query().Get("mid"),
+	}
+	return dto, nil
+}
+
+func makeTagCloudEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*tagCloudRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		service := service.NewTagService("", "")
+		tags, err := service.Cloud(req.Limit)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		entities := make([]tagEntity, 0, len(tags))
+		for _, v := range tags {
+			entities = append(entities, tagEntity{
+				ID:    v.TagID,
+				Name:  v.Name,
+				Slug:  v.Slug,
+				Posts: v.Posts,
+			})
+		}
+
+		return &tagCloudResponse{
+			Tags: entities,
+			MID:  req.MID,
+		}, nil
+	}
+}
+
+func TagCloudHandler() http.Handler {
+	return httptransport.NewServer(
+		makeTagCloudEndPoint(),
+		decodeTagCloudRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func TagCloudDoc() Doc {
+	return Doc{
+		Summary:  "Nuvem de tags: as tags com postagens publicadas e o seu número, as mais usadas primeiro",
+		Request:  tagCloudRequest{},
+		Response: tagCloudResponse{},
+	}
+}
+
+type tagRenameRequest struct {
+	ID      string
+	Name    string `json:"name"`
+	MID     string `json:"mid"`
+	Request *http.Request
+}
+
+type tagRenameResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodeTagRenameRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := new(tagRenameRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.ID = vars["id"]
+	dto.Request = r
+	return dto, nil
+}
+
+func makeTagRenameEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*tagRenameRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewTagService(userToken.UserID, userToken.Kind)
+		err = service.Rename(req.ID, req.Name)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &tagRenameResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func TagRenameHandler() http.Handler {
+	return httptransport.NewServer(
+		makeTagRenameEndPoint(),
+		decodeTagRenameRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func TagRenameDoc() Doc {
+	return Doc{
+		Summary:  "Renomeia uma tag, só administradores. Um nome com o slug de outra tag responde 409, junte as duas",
+		Request:  tagRenameRequest{},
+		Response: tagRenameResponse{},
+	}
+}
+
+type tagMergeRequest struct {
+	ID      string
+	Tags    []string `json:"tags"`
+	MID     string   `json:"mid"`
+	Request *http.Request
+}
+
+type tagMergeResponse struct {
+	MID string `json:"mid"`
+}
+
+func decodeTagMergeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+	dto := new(tagMergeRequest)
+	docoder := json.NewDecoder(r.Body)
+	err := docoder.Decode(dto)
+	if err != nil {
+		return nil, err
+	}
+	dto.ID = vars["id"]
+	dto.Request = r
+	return dto, nil
+}
+
+func makeTagMergeEndPoint() endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		// retrieve request data
+		req, ok := request.(*tagMergeRequest)
+		if !ok {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		tokenFunc := service.NewAccessService()
+		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
+		}
+
+		service := service.NewTagService(userToken.UserID, userToken.Kind)
+		err = service.Merge(req.ID, req.Tags)
+		if err != nil {
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
+		}
+
+		return &tagMergeResponse{
+			MID: req.MID,
+		}, nil
+	}
+}
+
+func TagMergeHandler() http.Handler {
+	return httptransport.NewServer(
+		makeTagMergeEndPoint(),
+		decodeTagMergeRequest,
+		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
+		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
+	)
+}
+
+func TagMergeDoc() Doc {
+	return Doc{
+		Summary:  "Junta as tags do corpo na tag do path, que fica com as suas postagens, só administradores",
+		Request:  tagMergeRequest{},
+		Response: tagMergeResponse{},
+	}
+}
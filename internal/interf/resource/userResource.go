@@ -3,7 +3,6 @@ package resource
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -12,6 +11,9 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/messages"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/pagination"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
 )
 
@@ -21,6 +23,7 @@ type userStoreResquest struct {
 	Nick      string `json:"nick"`
 	Email     string `json:"email"`
 	Secret    string `json:"secret"`
+	Language  string `json:"language"`
 	MID       string `json:"mid"`
 }
 
@@ -43,13 +46,19 @@ func makeUserStoreendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userStoreResquest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1000, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
+		}
+
+		// without a choice, the user keeps the language of the request
+		language := req.Language
+		if language == "" {
+			language = messages.Language(ctx)
 		}
 
 		service := service.NewUserService("", "")
-		err := service.Store(req.Name, req.Telephone, req.Nick, req.Email, req.Secret)
+		err := service.Store(req.Name, req.Telephone, req.Nick, req.Email, req.Secret, language)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1001, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userStoreResponse{
@@ -63,10 +72,19 @@ func UserStoreHandler() http.Handler {
 		makeUserStoreendPoint(),
 		decodeUserStoreRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserStoreDoc() Doc {
+	return Doc{
+		Summary:  "Cadastra um usuário",
+		Request:  userStoreResquest{},
+		Response: userStoreResponse{},
+	}
+}
+
 type userStoreADMResquest struct {
 	Name      string `json:"name"`
 	Telephone string `json:"telephone"`
@@ -74,6 +92,7 @@ type userStoreADMResquest struct {
 	Email     string `json:"email"`
 	Secret    string `json:"secret"`
 	Kind      string `json:"kind"`
+	Language  string `json:"language"`
 	MID       string `json:"mid"`
 	Request   *http.Request
 }
@@ -98,20 +117,20 @@ func makeUserStoreADMendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userStoreADMResquest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1002, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1004, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(userToken.UserID, userToken.Kind)
-		err = service.StoreADM(req.Name, req.Telephone, req.Nick, req.Email, req.Secret, req.Kind)
+		err = service.StoreADM(req.Name, req.Telephone, req.Nick, req.Email, req.Secret, req.Kind, req.Language)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1005, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userStoreADMResponse{
@@ -125,10 +144,19 @@ func UserStoreADMHandler() http.Handler {
 		makeUserStoreADMendPoint(),
 		decodeUserStoreADMRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserStoreADMDoc() Doc {
+	return Doc{
+		Summary:  "Cadastra um usuário pelo admin",
+		Request:  userStoreADMResquest{},
+		Response: userStoreADMResponse{},
+	}
+}
+
 type userEntity struct {
 	PersonID  string `json:"personID"`
 	UserID    string `json:"userID"`
@@ -137,18 +165,20 @@ type userEntity struct {
 	Nick      string `json:"nick"`
 	Email     string `json:"email"`
 	Kind      string `json:"kind"`
+	Language  string `json:"language"`
 }
 
 type userListRequest struct {
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
 type userListResponse struct {
-	Count int          `json:"count"`
+	pageEntity
 	Users []userEntity `json:"users"`
 	MID   string       `json:"mid"`
 }
@@ -171,6 +201,7 @@ func decodeUserListRequest(ctx context.Context, r *http.Request) (interface{}, e
 		Offset:  int(offset),
 		Limit:   int(limit),
 		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
 		MID:     mid,
 		Request: r,
 	}
@@ -182,26 +213,25 @@ func makeUserListendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userListRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1006, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1007, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(userToken.UserID, userToken.Kind)
-		users, err := service.List(req.Offset, req.Limit, req.Page)
-
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1008, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
+		users, page, err := service.List(p)
 
-		count, err := service.Count()
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1009, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []userEntity
@@ -214,13 +244,14 @@ func makeUserListendPoint() endpoint.Endpoint {
 				Nick:      v.Nick,
 				Email:     v.Email,
 				Kind:      v.Kind,
+				Language:  v.Language,
 			})
 		}
 
 		return &userListResponse{
-			Count: count,
-			Users: entities,
-			MID:   req.MID,
+			pageEntity: newPageEntity(page),
+			Users:      entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -230,21 +261,31 @@ func UserListHandler() http.Handler {
 		makeUserListendPoint(),
 		decodeUserListRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserListDoc() Doc {
+	return Doc{
+		Summary:  "Lista os usuários",
+		Request:  userListRequest{},
+		Response: userListResponse{},
+	}
+}
+
 type userListNameRequest struct {
 	Name    string
-	Offset  int
-	Limit   int
-	Page    int
-	MID     string
+	Offset  int    `query:"offset"`
+	Limit   int    `query:"limit"`
+	Page    int    `query:"page"`
+	Cursor  string `query:"cursor"`
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
 type userListNameResponse struct {
-	Count int          `json:"count"`
+	pageEntity
 	Users []userEntity `json:"users"`
 	MID   string       `json:"mid"`
 }
@@ -270,6 +311,7 @@ func decodeUserListNameRequest(ctx context.Context, r *http.Request) (interface{
 		Offset:  int(offset),
 		Limit:   int(limit),
 		Page:    int(page),
+		Cursor:  r.URL.Query().Get("cursor"),
 		MID:     mid,
 		Request: r,
 	}
@@ -281,26 +323,25 @@ func makeUserListNameendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userListNameRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1010, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1011, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(userToken.UserID, userToken.Kind)
-		users, err := service.ListName(req.Name, req.Offset, req.Limit, req.Page)
-
+		p, err := pagination.NewParams(req.Offset, req.Limit, req.Page, req.Cursor)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1012, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
+		users, page, err := service.ListName(req.Name, p)
 
-		count, err := service.CountName(req.Name)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1013, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		var entities []userEntity
@@ -313,13 +354,14 @@ func makeUserListNameendPoint() endpoint.Endpoint {
 				Nick:      v.Nick,
 				Email:     v.Email,
 				Kind:      v.Kind,
+				Language:  v.Language,
 			})
 		}
 
 		return &userListResponse{
-			Count: count,
-			Users: entities,
-			MID:   req.MID,
+			pageEntity: newPageEntity(page),
+			Users:      entities,
+			MID:        req.MID,
 		}, nil
 	}
 }
@@ -329,13 +371,22 @@ func UserListNameHandler() http.Handler {
 		makeUserListNameendPoint(),
 		decodeUserListNameRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserListNameDoc() Doc {
+	return Doc{
+		Summary:  "Lista os usuários pelo nome",
+		Request:  userListNameRequest{},
+		Response: userListResponse{},
+	}
+}
+
 type userFindRequest struct {
 	ID      string
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -361,20 +412,20 @@ func makeUserFindendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userFindRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1014, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1015, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(userToken.UserID, userToken.Kind)
 		user, err := service.Find(req.ID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1016, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userFindResponse{
@@ -386,6 +437,7 @@ func makeUserFindendPoint() endpoint.Endpoint {
 				Nick:      user.Nick,
 				Email:     user.Email,
 				Kind:      user.Kind,
+				Language:  user.Language,
 			},
 			MID: req.MID,
 		}, nil
@@ -397,10 +449,19 @@ func UserFindHandler() http.Handler {
 		makeUserFindendPoint(),
 		decodeUserFindRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserFindDoc() Doc {
+	return Doc{
+		Summary:  "Busca um usuário pelo id",
+		Request:  userFindRequest{},
+		Response: userFindResponse{},
+	}
+}
+
 type userUpdateRequest struct {
 	ID        string
 	Name      string `json:"name"`
@@ -408,6 +469,7 @@ type userUpdateRequest struct {
 	Nick      string `json:"nick"`
 	Email     string `json:"email"`
 	Kind      string `json:"kind"`
+	Language  string `json:"language"`
 	MID       string `json:"mid"`
 	Request   *http.Request
 }
@@ -435,20 +497,20 @@ func makeUserUpdateendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userUpdateRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1017, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1018, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(userToken.UserID, userToken.Kind)
-		err = service.Update(req.ID, req.Name, req.Telephone, req.Nick, req.Email, req.Kind)
+		err = service.Update(req.ID, req.Name, req.Telephone, req.Nick, req.Email, req.Kind, req.Language)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1019, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userUpdateResponse{
@@ -462,13 +524,22 @@ func UserUpdateHandler() http.Handler {
 		makeUserUpdateendPoint(),
 		decodeUserUpdateRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserUpdateDoc() Doc {
+	return Doc{
+		Summary:  "Atualiza um usuário",
+		Request:  userUpdateRequest{},
+		Response: userUpdateResponse{},
+	}
+}
+
 type userRemoveRequest struct {
 	ID      string
-	MID     string
+	MID     string `query:"mid"`
 	Request *http.Request
 }
 
@@ -493,20 +564,20 @@ func makeUserRemoveendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userRemoveRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1020, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1021, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(userToken.UserID, userToken.Kind)
 		err = service.Remove(req.ID)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1022, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userRemoveResponse{
@@ -520,10 +591,19 @@ func UserRemoveHandler() http.Handler {
 		makeUserRemoveendPoint(),
 		decodeUserRemoveRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserRemoveDoc() Doc {
+	return Doc{
+		Summary:  "Remove um usuário",
+		Request:  userRemoveRequest{},
+		Response: userRemoveResponse{},
+	}
+}
+
 type userLoginRequest struct {
 	EmailOrNick string `json:"nick"`
 	Secret      string `json:"password"`
@@ -550,13 +630,13 @@ func makeUserLoginendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userLoginRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1023, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		service := service.NewUserService("", "")
 		token, err := service.Login(req.EmailOrNick, req.Secret)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1024, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userLoginResponse{
@@ -571,10 +651,19 @@ func UserLoginHandler() http.Handler {
 		makeUserLoginendPoint(),
 		decodeUserLoginRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserLoginDoc() Doc {
+	return Doc{
+		Summary:  "Autentica um usuário",
+		Request:  userLoginRequest{},
+		Response: userLoginResponse{},
+	}
+}
+
 type userSendEmailRequest struct {
 	Email string `json:"email"`
 	MID   string `json:"mid"`
@@ -599,13 +688,13 @@ func makeSendEmailEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userSendEmailRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1025, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		service := service.NewUserService("", "")
 		err := service.SendCodeGeneratedToEmail(req.Email)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1026, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userSendEmailResponse{
@@ -619,10 +708,19 @@ func UserSendEmailHandler() http.Handler {
 		makeSendEmailEndPoint(),
 		decodeSendEmailRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserSendEmailDoc() Doc {
+	return Doc{
+		Summary:  "Envia o código de recuperação de senha por email",
+		Request:  userSendEmailRequest{},
+		Response: userSendEmailResponse{},
+	}
+}
+
 type userVerificCodeRequest struct {
 	Code string `json:"code"`
 	MID  string `json:"mid"`
@@ -648,13 +746,13 @@ func makeVerificCodeEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userVerificCodeRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1027, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		service := service.NewUserService("", "")
 		token, err := service.VerificCode(req.Code)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1028, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userVerificCodeResponse{
@@ -669,10 +767,19 @@ func UserVerificCodeHandler() http.Handler {
 		makeVerificCodeEndPoint(),
 		decodeVerificCodeRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserVerificCodeDoc() Doc {
+	return Doc{
+		Summary:  "Verifica o código de recuperação de senha",
+		Request:  userVerificCodeRequest{},
+		Response: userVerificCodeResponse{},
+	}
+}
+
 type userPasswordRecoveryRequest struct {
 	NewPassword string `json:"newPassword"`
 	MID         string `json:"mid"`
@@ -699,20 +806,20 @@ func makePasswordRecoveryEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userPasswordRecoveryRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1029, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		useID, err := tokenFunc.ValidateAndExtractTokenRecovery(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1030, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(useID, "")
 		err = service.SecretRecovery(req.NewPassword)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1031, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userPasswordRecoveryResponse{
@@ -726,10 +833,19 @@ func UserPasswordRecoveryHandler() http.Handler {
 		makePasswordRecoveryEndPoint(),
 		decodePasswordRecoveryRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserPasswordRecoveryDoc() Doc {
+	return Doc{
+		Summary:  "Altera a senha com o token de recuperação",
+		Request:  userPasswordRecoveryRequest{},
+		Response: userPasswordRecoveryResponse{},
+	}
+}
+
 type userPasswordUpdateRequest struct {
 	OldPassword string `json:"oldPassword"`
 	NewPassword string `json:"newPassword"`
@@ -757,20 +873,20 @@ func makePasswordUpdateEndPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userPasswordUpdateRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1032, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1033, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(userToken.UserID, userToken.Kind)
 		err = service.SecretUpdate(req.OldPassword, req.NewPassword)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1034, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userPasswordUpdateResponse{
@@ -784,10 +900,19 @@ func UserPasswordUpdateHandler() http.Handler {
 		makePasswordUpdateEndPoint(),
 		decodePasswordUpdateRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
 
+func UserPasswordUpdateDoc() Doc {
+	return Doc{
+		Summary:  "Altera a senha do usuário",
+		Request:  userPasswordUpdateRequest{},
+		Response: userPasswordUpdateResponse{},
+	}
+}
+
 type userLogoutRequest struct {
 	MID     string `json:"mid"`
 	Request *http.Request
@@ -813,20 +938,20 @@ func makeUserLogoutendPoint() endpoint.Endpoint {
 		// retrieve request data
 		req, ok := request.(*userLogoutRequest)
 		if !ok {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusBadRequest, 1023, errors.New("invalid request"), "na")
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.ErrInvalidRequest, "na")
 		}
 
 		// gets token's informations
 		tokenFunc := service.NewAccessService()
 		userToken, err := tokenFunc.ExtractTokenInfo(req.Request)
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusUnauthorized, 1033, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(domainErrors.Unauthorized(err), req.MID)
 		}
 
 		service := service.NewUserService(userToken.UserID, userToken.Kind)
 		err = service.Logout()
 		if err != nil {
-			return nil, responseAPI.CreateHttpErrorResponse(http.StatusInternalServerError, 1024, err, req.MID)
+			return nil, responseAPI.CreateHttpErrorResponse(err, req.MID)
 		}
 
 		return &userLogoutResponse{
@@ -840,6 +965,15 @@ func UserLogoutHandler() http.Handler {
 		makeUserLogoutendPoint(),
 		decodeUserLogoutRequest,
 		responseAPI.EncodeResponse,
+		httptransport.ServerBefore(httptransport.PopulateRequestContext),
 		httptransport.ServerErrorEncoder(responseAPI.ErrorEncoder()),
 	)
 }
+
+func UserLogoutDoc() Doc {
+	return Doc{
+		Summary:  "Encerra a sessão do usuário",
+		Request:  userLogoutRequest{},
+		Response: userLogoutResponse{},
+	}
+}
@@ -0,0 +1,609 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/rateLimit"
+	"github.com/johnHPX/blog-hard-backend/internal/interf/resource"
+)
+
+// apiV1Routes: resource oriented version of the api, the legacy routes point to these as their successors
+var apiV1Routes = []Router{
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/users",
+		EndPointer: resource.UserStoreHandler().ServeHTTP,
+		Doc:        resource.UserStoreDoc(),
+		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 5, Per: time.Hour},
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/admin/users",
+		EndPointer: resource.UserStoreADMHandler().ServeHTTP,
+		Doc:        resource.UserStoreADMDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users",
+		EndPointer: resource.UserListHandler().ServeHTTP,
+		Doc:        resource.UserListDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/names/{name}",
+		EndPointer: resource.UserListNameHandler().ServeHTTP,
+		Doc:        resource.UserListNameDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/{id}",
+		EndPointer: resource.UserFindHandler().ServeHTTP,
+		Doc:        resource.UserFindDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/{id}",
+		EndPointer: resource.UserUpdateHandler().ServeHTTP,
+		Doc:        resource.UserUpdateDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/{id}",
+		EndPointer: resource.UserRemoveHandler().ServeHTTP,
+		Doc:        resource.UserRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/me/password",
+		EndPointer: resource.UserPasswordUpdateHandler().ServeHTTP,
+		Doc:        resource.UserPasswordUpdateDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/me/comments",
+		EndPointer: resource.CommentListUserHandler().ServeHTTP,
+		Doc:        resource.CommentListUserDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/me/posts/{postID}/comments",
+		EndPointer: resource.CommentListPostUserHandler().ServeHTTP,
+		Doc:        resource.CommentListPostUserDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/me/responses",
+		EndPointer: resource.ResponseCommentListUserHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentListUserDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/sessions",
+		EndPointer: resource.UserLoginHandler().ServeHTTP,
+		Doc:        resource.UserLoginDoc(),
+		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 10, Per: time.Minute},
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/sessions",
+		EndPointer: resource.UserLogoutHandler().ServeHTTP,
+		Doc:        resource.UserLogoutDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/password-recovery/codes",
+		EndPointer: resource.UserSendEmailHandler().ServeHTTP,
+		Doc:        resource.UserSendEmailDoc(),
+		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 3, Per: 10 * time.Minute},
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/password-recovery/verifications",
+		EndPointer: resource.UserVerificCodeHandler().ServeHTTP,
+		Doc:        resource.UserVerificCodeDoc(),
+		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 10, Per: 10 * time.Minute},
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/password-recovery",
+		EndPointer: resource.UserPasswordRecoveryHandler().ServeHTTP,
+		Doc:        resource.UserPasswordRecoveryDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/posts",
+		EndPointer: resource.PostListHandler().ServeHTTP,
+		Doc:        resource.PostListDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts",
+		EndPointer: resource.PostStoreHandler().ServeHTTP,
+		Doc:        resource.PostStoreDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/users/me/posts",
+		EndPointer: resource.PostListOwnHandler().ServeHTTP,
+		Doc:        resource.PostListOwnDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/revisions",
+		EndPointer: resource.PostRevisionListHandler().ServeHTTP,
+		Doc:        resource.PostRevisionListDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/revisions/diff",
+		EndPointer: resource.PostRevisionDiffHandler().ServeHTTP,
+		Doc:        resource.PostRevisionDiffDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/revisions/{number}/restore",
+		EndPointer: resource.PostRevisionRestoreHandler().ServeHTTP,
+		Doc:        resource.PostRevisionRestoreDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/submit",
+		EndPointer: resource.PostStatusHandler("submit").ServeHTTP,
+		Doc:        resource.PostStatusDoc("Envia o rascunho para revisão"),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/approve",
+		EndPointer: resource.PostStatusHandler("approve").ServeHTTP,
+		Doc:        resource.PostStatusDoc("Aprova a postagem em revisão e a publica"),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/publish",
+		EndPointer: resource.PostStatusHandler("publish").ServeHTTP,
+		Doc:        resource.PostStatusDoc("Publica a postagem sem passar pela revisão"),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/unpublish",
+		EndPointer: resource.PostStatusHandler("unpublish").ServeHTTP,
+		Doc:        resource.PostStatusDoc("Tira a postagem do ar, ela volta a ser rascunho"),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/schedule",
+		EndPointer: resource.PostScheduleHandler().ServeHTTP,
+		Doc:        resource.PostScheduleDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/unschedule",
+		EndPointer: resource.PostStatusHandler("unschedule").ServeHTTP,
+		Doc:        resource.PostStatusDoc("Cancela o agendamento, a postagem volta a ser rascunho"),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}/archive",
+		EndPointer: resource.PostStatusHandler("archive").ServeHTTP,
+		Doc:        resource.PostStatusDoc("Arquiva a postagem"),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/posts/slug/{slug}",
+		EndPointer: resource.PostSlugHandler().ServeHTTP,
+		Doc:        resource.PostSlugDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/posts/slug/{slug}/head",
+		EndPointer: resource.PostHeadHandler().ServeHTTP,
+		Doc:        resource.PostHeadDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/posts/public/{id}",
+		EndPointer: resource.PostPublicHandler().ServeHTTP,
+		Doc:        resource.PostPublicDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/posts/titles/{title}",
+		EndPointer: resource.PostListTitleHandler().ServeHTTP,
+		Doc:        resource.PostListTitleDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}",
+		EndPointer: resource.PostFindHandler().ServeHTTP,
+		Doc:        resource.PostFindDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}",
+		EndPointer: resource.PostUpdateHandler().ServeHTTP,
+		Doc:        resource.PostUpdateDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{id}",
+		EndPointer: resource.PostRemoveHandler().ServeHTTP,
+		Doc:        resource.PostRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/posts/{id}/related",
+		EndPointer: resource.PostRelatedHandler().ServeHTTP,
+		Doc:        resource.PostRelatedDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/posts/{postID}/comments",
+		EndPointer: resource.CommentListPostHandler().ServeHTTP,
+		Doc:        resource.CommentListPostDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{postID}/categories",
+		EndPointer: resource.CategoryListPostHandler().ServeHTTP,
+		Doc:        resource.CategoryListPostDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/posts/{postID}/categories",
+		EndPointer: resource.PostCategorySetHandle().ServeHTTP,
+		Doc:        resource.PostCategorySetDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/likes",
+		EndPointer: resource.NumberLikesStoreHandle().ServeHTTP,
+		Doc:        resource.NumberLikesStoreDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/likes",
+		EndPointer: resource.NumberLikesRemoveHandle().ServeHTTP,
+		Doc:        resource.NumberLikesRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/post-categories",
+		EndPointer: resource.PostCategoryStoreHandle().ServeHTTP,
+		Doc:        resource.PostCategoryStoreDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/post-categories",
+		EndPointer: resource.PostCategoryRemoveHandle().ServeHTTP,
+		Doc:        resource.PostCategoryRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/comments",
+		EndPointer: resource.CommentStoreHandler().ServeHTTP,
+		Doc:        resource.CommentStoreDoc(),
+		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 10, Per: time.Minute, ByUser: true},
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/comments/{id}",
+		EndPointer: resource.CommentFindHandler().ServeHTTP,
+		Doc:        resource.CommentFindDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/comments/{id}",
+		EndPointer: resource.CommentUpdateHandler().ServeHTTP,
+		Doc:        resource.CommentUpdateDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/comments/{id}",
+		EndPointer: resource.CommentRemoveHandler().ServeHTTP,
+		Doc:        resource.CommentRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/comments/{commentID}/responses",
+		EndPointer: resource.ResponseCommentListHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentListDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/responses",
+		EndPointer: resource.ResponseCommentStoreHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentStoreDoc(),
+		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 10, Per: time.Minute, ByUser: true},
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/responses/{id}",
+		EndPointer: resource.ResponseCommentUpdateHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentUpdateDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/responses/{id}",
+		EndPointer: resource.ResponseCommentRemoveHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/categories",
+		EndPointer: resource.CategoryListHandler().ServeHTTP,
+		Doc:        resource.CategoryListDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/categories",
+		EndPointer: resource.CategoryStoreHandler().ServeHTTP,
+		Doc:        resource.CategoryStoreDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/categories/public",
+		EndPointer: resource.CategoryListPublicHandler().ServeHTTP,
+		Doc:        resource.CategoryListPublicDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/categories/tree",
+		EndPointer: resource.CategoryTreeHandler().ServeHTTP,
+		Doc:        resource.CategoryTreeDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/categories/name/{category}/posts",
+		EndPointer: resource.PostListCategoryHandler().ServeHTTP,
+		Doc:        resource.PostListCategoryDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/categories/slug/{category}/posts",
+		EndPointer: resource.PostListCategoryHandler().ServeHTTP,
+		Doc:        resource.PostListCategoryDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/authors/{nick}/posts",
+		EndPointer: resource.PostListAuthorHandler().ServeHTTP,
+		Doc:        resource.PostListAuthorDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/series",
+		EndPointer: resource.SeriesListHandler().ServeHTTP,
+		Doc:        resource.SeriesListDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/series",
+		EndPointer: resource.SeriesStoreHandler().ServeHTTP,
+		Doc:        resource.SeriesStoreDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/series/slug/{slug}",
+		EndPointer: resource.SeriesSlugHandler().ServeHTTP,
+		Doc:        resource.SeriesSlugDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/series/{id}",
+		EndPointer: resource.SeriesFindHandler().ServeHTTP,
+		Doc:        resource.SeriesFindDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/series/{id}",
+		EndPointer: resource.SeriesUpdateHandler().ServeHTTP,
+		Doc:        resource.SeriesUpdateDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/series/{id}",
+		EndPointer: resource.SeriesRemoveHandler().ServeHTTP,
+		Doc:        resource.SeriesRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/series/{id}/posts",
+		EndPointer: resource.SeriesPartsHandler().ServeHTTP,
+		Doc:        resource.SeriesPartsDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/tags",
+		EndPointer: resource.TagCloudHandler().ServeHTTP,
+		Doc:        resource.TagCloudDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/tags/{tag}/posts",
+		EndPointer: resource.PostListTagHandler().ServeHTTP,
+		Doc:        resource.PostListTagDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/tags/{id}",
+		EndPointer: resource.TagRenameHandler().ServeHTTP,
+		Doc:        resource.TagRenameDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/tags/{id}/merge",
+		EndPointer: resource.TagMergeHandler().ServeHTTP,
+		Doc:        resource.TagMergeDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/categories/{id}",
+		EndPointer: resource.CategoryFindHandler().ServeHTTP,
+		Doc:        resource.CategoryFindDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/categories/{id}",
+		EndPointer: resource.CategoryUpdateHandler().ServeHTTP,
+		Doc:        resource.CategoryUpdateDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/categories/{id}",
+		EndPointer: resource.CategoryRemoveHandler().ServeHTTP,
+		Doc:        resource.CategoryRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/categories/{id}/posts",
+		EndPointer: resource.PostCategoryBatchHandle().ServeHTTP,
+		Doc:        resource.PostCategoryBatchDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/categories/{id}/merge",
+		EndPointer: resource.CategoryMergeHandler().ServeHTTP,
+		Doc:        resource.CategoryMergeDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/configs",
+		EndPointer: resource.ConfigsListHandler().ServeHTTP,
+		Doc:        resource.ConfigsListDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/configs",
+		EndPointer: resource.ConfigsStoreHandler().ServeHTTP,
+		Doc:        resource.ConfigsStoreDoc(),
+		Method:     http.MethodPost,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/configs/{id}",
+		EndPointer: resource.ConfigsFindHandler().ServeHTTP,
+		Doc:        resource.ConfigsFindDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/configs/{id}",
+		EndPointer: resource.ConfigsUpdateHandler().ServeHTTP,
+		Doc:        resource.ConfigsUpdateDoc(),
+		Method:     http.MethodPut,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/configs/{id}",
+		EndPointer: resource.ConfigsRemoveHandler().ServeHTTP,
+		Doc:        resource.ConfigsRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/api/v1/search",
+		EndPointer: resource.SearchHandler().ServeHTTP,
+		Doc:        resource.SearchDoc(),
+		Method:     http.MethodGet,
+		RateLimit:  &rateLimit.Rule{Requests: 30, Per: time.Minute},
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/media",
+		EndPointer: resource.MediaUploadHandler().ServeHTTP,
+		Doc:        resource.MediaUploadDoc(),
+		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 30, Per: time.Hour, ByUser: true},
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/media",
+		EndPointer: resource.MediaListHandler().ServeHTTP,
+		Doc:        resource.MediaListDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: true,
+		Path:       "/api/v1/media/{id}",
+		EndPointer: resource.MediaRemoveHandler().ServeHTTP,
+		Doc:        resource.MediaRemoveDoc(),
+		Method:     http.MethodDelete,
+	},
+}
@@ -11,36 +11,55 @@ var categoryRoutes = []Router{
 		TokenIsReq: true,
 		Path:       "/category/store",
 		EndPointer: resource.CategoryStoreHandler().ServeHTTP,
+		Doc:        resource.CategoryStoreDoc(),
+		Successor:  "/api/v1/categories",
 		Method:     http.MethodPost,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/category/list",
 		EndPointer: resource.CategoryListHandler().ServeHTTP,
+		Doc:        resource.CategoryListDoc(),
+		Successor:  "/api/v1/categories",
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/category/public/list",
+		EndPointer: resource.CategoryListPublicHandler().ServeHTTP,
+		Doc:        resource.CategoryListPublicDoc(),
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/category/list/post/id/{postID}",
 		EndPointer: resource.CategoryListPostHandler().ServeHTTP,
+		Doc:        resource.CategoryListPostDoc(),
+		Successor:  "/api/v1/posts/{postID}/categories",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/category/find/id/{id}",
 		EndPointer: resource.CategoryFindHandler().ServeHTTP,
+		Doc:        resource.CategoryFindDoc(),
+		Successor:  "/api/v1/categories/{id}",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/category/update/id/{id}",
 		EndPointer: resource.CategoryUpdateHandler().ServeHTTP,
+		Doc:        resource.CategoryUpdateDoc(),
+		Successor:  "/api/v1/categories/{id}",
 		Method:     http.MethodPut,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/category/remove/id/{id}",
 		EndPointer: resource.CategoryRemoveHandler().ServeHTTP,
+		Doc:        resource.CategoryRemoveDoc(),
+		Successor:  "/api/v1/categories/{id}",
 		Method:     http.MethodDelete,
 	},
 }
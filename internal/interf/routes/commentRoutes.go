@@ -2,7 +2,9 @@ package routes
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/rateLimit"
 	"github.com/johnHPX/blog-hard-backend/internal/interf/resource"
 )
 
@@ -11,42 +13,57 @@ var commentRoutes = []Router{
 		TokenIsReq: true,
 		Path:       "/comment/store",
 		EndPointer: resource.CommentStoreHandler().ServeHTTP,
+		Doc:        resource.CommentStoreDoc(),
+		Successor:  "/api/v1/comments",
 		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 10, Per: time.Minute, ByUser: true},
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/comment/list/post/id/{postID}",
 		EndPointer: resource.CommentListPostHandler().ServeHTTP,
+		Doc:        resource.CommentListPostDoc(),
+		Successor:  "/api/v1/posts/{postID}/comments",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/comment/list/user",
 		EndPointer: resource.CommentListUserHandler().ServeHTTP,
+		Doc:        resource.CommentListUserDoc(),
+		Successor:  "/api/v1/users/me/comments",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/comment/list/user/post/id/{postID}",
 		EndPointer: resource.CommentListPostUserHandler().ServeHTTP,
+		Doc:        resource.CommentListPostUserDoc(),
+		Successor:  "/api/v1/users/me/posts/{postID}/comments",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/comment/find/id/{id}",
 		EndPointer: resource.CommentFindHandler().ServeHTTP,
+		Doc:        resource.CommentFindDoc(),
+		Successor:  "/api/v1/comments/{id}",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/comment/update/id/{id}",
 		EndPointer: resource.CommentUpdateHandler().ServeHTTP,
+		Doc:        resource.CommentUpdateDoc(),
+		Successor:  "/api/v1/comments/{id}",
 		Method:     http.MethodPut,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/comment/remove/id/{id}",
 		EndPointer: resource.CommentRemoveHandler().ServeHTTP,
+		Doc:        resource.CommentRemoveDoc(),
+		Successor:  "/api/v1/comments/{id}",
 		Method:     http.MethodDelete,
 	},
 }
@@ -11,30 +11,40 @@ var configsRoutes = []Router{
 		TokenIsReq: true,
 		Path:       "/config/store",
 		EndPointer: resource.ConfigsStoreHandler().ServeHTTP,
+		Doc:        resource.ConfigsStoreDoc(),
+		Successor:  "/api/v1/configs",
 		Method:     http.MethodPost,
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/config/list",
 		EndPointer: resource.ConfigsListHandler().ServeHTTP,
+		Doc:        resource.ConfigsListDoc(),
+		Successor:  "/api/v1/configs",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/config/find/id/{id}",
 		EndPointer: resource.ConfigsFindHandler().ServeHTTP,
+		Doc:        resource.ConfigsFindDoc(),
+		Successor:  "/api/v1/configs/{id}",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/config/update/id/{id}",
 		EndPointer: resource.ConfigsUpdateHandler().ServeHTTP,
+		Doc:        resource.ConfigsUpdateDoc(),
+		Successor:  "/api/v1/configs/{id}",
 		Method:     http.MethodPut,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/config/remove/id/{id}",
 		EndPointer: resource.ConfigsRemoveHandler().ServeHTTP,
+		Doc:        resource.ConfigsRemoveDoc(),
+		Successor:  "/api/v1/configs/{id}",
 		Method:     http.MethodDelete,
 	},
 }
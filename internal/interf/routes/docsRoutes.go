@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/johnHPX/blog-hard-backend/internal/interf/resource"
+)
+
+//go:generate go run ../../../cmd/swaggerui -out swaggerui
+
+//go:embed swagger.html
+var swaggerPage []byte
+
+// swaggerAssets: the Swagger UI vendored by go generate and its initializer, served without a CDN
+//
+//go:embed swaggerui
+var swaggerAssets embed.FS
+
+func (s *webServiceImpl) docsRoutes() []Router {
+	return []Router{
+		{
+			TokenIsReq: false,
+			Path:       "/openapi.json",
+			EndPointer: s.openAPIHandler,
+			Doc:        resource.Doc{Summary: "Documento OpenAPI 3 da API"},
+			Method:     http.MethodGet,
+		},
+		{
+			TokenIsReq: false,
+			Path:       "/docs",
+			EndPointer: swaggerHandler,
+			Doc:        resource.Doc{Summary: "Swagger UI da API"},
+			Method:     http.MethodGet,
+		},
+		{
+			TokenIsReq: false,
+			Path:       "/docs/{file}",
+			EndPointer: swaggerAssetsHandler(),
+			Doc:        resource.Doc{Summary: "Arquivos do Swagger UI"},
+			Method:     http.MethodGet,
+		},
+	}
+}
+
+func (s *webServiceImpl) openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(s.OpenAPI)
+}
+
+func swaggerHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(swaggerPage)
+}
+
+// swaggerAssetsHandler: the files of swaggerui under /docs
+func swaggerAssetsHandler() http.HandlerFunc {
+	assets, err := fs.Sub(swaggerAssets, "swaggerui")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix("/docs/", http.FileServer(http.FS(assets))).ServeHTTP
+}
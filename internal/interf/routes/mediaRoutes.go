@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/johnHPX/blog-hard-backend/internal/interf/resource"
+)
+
+// mediaRoutes: the files aren't part of the api, their urls are kept in the posts' content
+var mediaRoutes = []Router{
+	{
+		TokenIsReq: false,
+		Path:       "/media/{key}",
+		EndPointer: resource.MediaFileHandler().ServeHTTP,
+		Doc:        resource.MediaFileDoc(),
+		Method:     http.MethodGet,
+	},
+}
@@ -11,12 +11,16 @@ var numberLikes = []Router{
 		TokenIsReq: true,
 		Path:       "/user/post/like",
 		EndPointer: resource.NumberLikesStoreHandle().ServeHTTP,
+		Doc:        resource.NumberLikesStoreDoc(),
+		Successor:  "/api/v1/likes",
 		Method:     http.MethodPost,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/post/dslike",
 		EndPointer: resource.NumberLikesRemoveHandle().ServeHTTP,
+		Doc:        resource.NumberLikesRemoveDoc(),
+		Successor:  "/api/v1/likes",
 		Method:     http.MethodDelete,
 	},
 }
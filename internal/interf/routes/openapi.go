@@ -0,0 +1,333 @@
+package routes
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
+)
+
+var pathVarsRegexp = regexp.MustCompile(`\{(\w+)\}`)
+
+// openAPIBuilder: generates the openapi 3 document from the routers and their dtos
+type openAPIBuilder struct {
+	schemas map[string]interface{}
+}
+
+func newOpenAPIBuilder() *openAPIBuilder {
+	return &openAPIBuilder{
+		schemas: map[string]interface{}{
+			// problem details (rfc 7807), the codes are listed in docs/errors.md
+			"Error": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"type":     map[string]interface{}{"type": "string", "format": "uri"},
+					"title":    map[string]interface{}{"type": "string"},
+					"status":   map[string]interface{}{"type": "integer"},
+					"detail":   map[string]interface{}{"type": "string"},
+					"instance": map[string]interface{}{"type": "string"},
+					"code":     map[string]interface{}{"type": "string", "enum": errorCodes()},
+					"mid":      map[string]interface{}{"type": "string"},
+					"errors": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"field":   map[string]interface{}{"type": "string"},
+								"message": map[string]interface{}{"type": "string"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (b *openAPIBuilder) document(routers []Router) map[string]interface{} {
+	paths := make(map[string]interface{})
+	for _, router := range routers {
+		item, ok := paths[router.Path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[router.Path] = item
+		}
+		item[strings.ToLower(router.Method)] = b.operation(router)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "BlogHard API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": b.schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+	}
+}
+
+func (b *openAPIBuilder) operation(router Router) map[string]interface{} {
+	operation := map[string]interface{}{
+		"summary": router.Doc.Summary,
+		"tags":    []string{strings.Split(strings.TrimPrefix(strings.TrimPrefix(router.Path, "/api/v1"), "/"), "/")[0]},
+	}
+	if router.Successor != "" {
+		operation["deprecated"] = true
+		operation["description"] = "Rota legada, use " + router.Successor
+	}
+
+	parameters := make([]interface{}, 0)
+	for _, v := range pathVarsRegexp.FindAllStringSubmatch(router.Path, -1) {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     v[1],
+			"in":       "path",
+			"required": true,
+			"schema":   b.pathSchema(router.Doc.Request, v[1]),
+		})
+	}
+
+	if router.Doc.Request != nil {
+		t := indirect(reflect.TypeOf(router.Doc.Request))
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := field.Tag.Lookup("query")
+			if !ok {
+				continue
+			}
+			parameters = append(parameters, map[string]interface{}{
+				"name":   name,
+				"in":     "query",
+				"schema": b.schema(field.Type),
+			})
+		}
+
+		if router.Method != http.MethodGet && hasFormFields(t) {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"multipart/form-data": map[string]interface{}{
+						"schema": b.form(t),
+					},
+				},
+			}
+		} else if router.Method != http.MethodGet && hasJSONFields(t) {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": b.schema(t),
+					},
+				},
+			}
+		}
+	}
+
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	responses := map[string]interface{}{
+		"default": map[string]interface{}{
+			"description": "Erro",
+			"content": map[string]interface{}{
+				"application/problem+json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+				},
+			},
+		},
+	}
+
+	ok := map[string]interface{}{"description": "OK"}
+	if router.Doc.Content != "" {
+		ok["content"] = map[string]interface{}{
+			router.Doc.Content: map[string]interface{}{
+				"schema": map[string]interface{}{"type": "string", "format": "binary"},
+			},
+		}
+	} else if router.Doc.Response != nil {
+		ok["content"] = map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": b.schema(reflect.TypeOf(router.Doc.Response)),
+			},
+		}
+	}
+	responses["200"] = ok
+
+	if router.TokenIsReq {
+		operation["security"] = []interface{}{map[string]interface{}{"bearerAuth": []string{}}}
+		responses["401"] = map[string]interface{}{"description": "Token ausente, inválido ou expirado"}
+	}
+
+	if router.RateLimit != nil {
+		integer := map[string]interface{}{"schema": map[string]interface{}{"type": "integer"}}
+		responses["429"] = map[string]interface{}{
+			"description": "Limite de requisições excedido",
+			"headers": map[string]interface{}{
+				"Retry-After":           integer,
+				"X-RateLimit-Limit":     integer,
+				"X-RateLimit-Remaining": integer,
+				"X-RateLimit-Reset":     integer,
+			},
+		}
+	}
+
+	operation["responses"] = responses
+
+	return operation
+}
+
+// pathSchema: types a path var by the dto's field of the same name, defaults to string
+func (b *openAPIBuilder) pathSchema(request interface{}, name string) interface{} {
+	if request != nil {
+		t := indirect(reflect.TypeOf(request))
+		for i := 0; i < t.NumField(); i++ {
+			if strings.EqualFold(t.Field(i).Name, name) {
+				return b.schema(t.Field(i).Type)
+			}
+		}
+	}
+	return map[string]interface{}{"type": "string"}
+}
+
+func (b *openAPIBuilder) schema(t reflect.Type) interface{} {
+	t = indirect(t)
+
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		if _, ok := b.schemas[t.Name()]; !ok {
+			// reserves the name before walking the fields, so recursive types end
+			b.schemas[t.Name()] = nil
+			b.schemas[t.Name()] = b.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	}
+
+	return map[string]interface{}{}
+}
+
+func (b *openAPIBuilder) object(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	b.properties(t, properties)
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// properties: follows the encoding/json rules, embedded structs without a tag are flattened
+func (b *openAPIBuilder) properties(t reflect.Type, properties map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup("json")
+		name := strings.Split(tag, ",")[0]
+
+		if field.Anonymous && !hasTag && indirect(field.Type).Kind() == reflect.Struct {
+			b.properties(indirect(field.Type), properties)
+			continue
+		}
+		if !hasTag || name == "-" || !isExported(field.Name) {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = b.schema(field.Type)
+	}
+}
+
+// form: the parts of a multipart body, the files are binaries
+func (b *openAPIBuilder) form(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := field.Tag.Lookup("form")
+		if !ok {
+			continue
+		}
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Uint8 {
+			properties[name] = map[string]interface{}{"type": "string", "format": "binary"}
+			continue
+		}
+		properties[name] = b.schema(field.Type)
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func errorCodes() []string {
+	codes := make([]string, 0, len(domainErrors.Catalogue))
+	for _, v := range domainErrors.Catalogue {
+		codes = append(codes, v.Code)
+	}
+	return codes
+}
+
+func hasFormFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("form"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func hasJSONFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("json"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isExported(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestOpenAPI(t *testing.T) {
+	t.Run("teste positivo", func(t *testing.T) {
+		ws := &webServiceImpl{Router: mux.NewRouter()}
+		err := ws.Init()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		ws.GetRouters().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status: %d", w.Code)
+		}
+
+		spec := struct {
+			Paths map[string]map[string]interface{} `json:"paths"`
+		}{}
+		err = json.Unmarshal(w.Body.Bytes(), &spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// every route served by the mux must be in the document
+		err = ws.Router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+			path, err := route.GetPathTemplate()
+			if err != nil {
+				return err
+			}
+			methods, err := route.GetMethods()
+			if err != nil {
+				return err
+			}
+			for _, method := range methods {
+				if method == http.MethodOptions {
+					continue
+				}
+				if _, ok := spec.Paths[path][strings.ToLower(method)]; !ok {
+					t.Errorf("%s %s is missing from the openapi document", method, path)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		for _, router := range apiRoutes() {
+			if router.Doc.Summary == "" || router.Doc.Request == nil || router.Doc.Response == nil {
+				t.Errorf("%s %s has no doc", router.Method, router.Path)
+			}
+		}
+	})
+}
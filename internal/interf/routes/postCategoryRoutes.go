@@ -11,12 +11,16 @@ var postCategory = []Router{
 		TokenIsReq: true,
 		Path:       "/post/category/store",
 		EndPointer: resource.PostCategoryStoreHandle().ServeHTTP,
+		Doc:        resource.PostCategoryStoreDoc(),
+		Successor:  "/api/v1/post-categories",
 		Method:     http.MethodPost,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/post/category/remove",
 		EndPointer: resource.PostCategoryRemoveHandle().ServeHTTP,
+		Doc:        resource.PostCategoryRemoveDoc(),
+		Successor:  "/api/v1/post-categories",
 		Method:     http.MethodDelete,
 	},
 }
@@ -11,42 +11,98 @@ var postRoutes = []Router{
 		TokenIsReq: true,
 		Path:       "/post/store",
 		EndPointer: resource.PostStoreHandler().ServeHTTP,
+		Doc:        resource.PostStoreDoc(),
+		Successor:  "/api/v1/posts",
 		Method:     http.MethodPost,
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/post/list",
 		EndPointer: resource.PostListHandler().ServeHTTP,
+		Doc:        resource.PostListDoc(),
+		Successor:  "/api/v1/posts",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/post/list/title/{title}",
 		EndPointer: resource.PostListTitleHandler().ServeHTTP,
+		Doc:        resource.PostListTitleDoc(),
+		Successor:  "/api/v1/posts/titles/{title}",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/post/list/category/name/{category}",
 		EndPointer: resource.PostListCategoryHandler().ServeHTTP,
+		Doc:        resource.PostListCategoryDoc(),
+		Successor:  "/api/v1/categories/name/{category}/posts",
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/post/list/category/slug/{category}",
+		EndPointer: resource.PostListCategoryHandler().ServeHTTP,
+		Doc:        resource.PostListCategoryDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/post/list/author/{nick}",
+		EndPointer: resource.PostListAuthorHandler().ServeHTTP,
+		Doc:        resource.PostListAuthorDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/post/list/tag/{tag}",
+		EndPointer: resource.PostListTagHandler().ServeHTTP,
+		Doc:        resource.PostListTagDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/post/slug/{slug}",
+		EndPointer: resource.PostSlugHandler().ServeHTTP,
+		Doc:        resource.PostSlugDoc(),
+		Method:     http.MethodGet,
+	},
+	{
+		TokenIsReq: false,
+		Path:       "/post/public/id/{id}",
+		EndPointer: resource.PostPublicHandler().ServeHTTP,
+		Doc:        resource.PostPublicDoc(),
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/post/find/id/{id}",
 		EndPointer: resource.PostFindHandler().ServeHTTP,
+		Doc:        resource.PostFindDoc(),
+		Successor:  "/api/v1/posts/{id}",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/post/update/id/{id}",
 		EndPointer: resource.PostUpdateHandler().ServeHTTP,
+		Doc:        resource.PostUpdateDoc(),
+		Successor:  "/api/v1/posts/{id}",
 		Method:     http.MethodPut,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/post/remove/id/{id}",
 		EndPointer: resource.PostRemoveHandler().ServeHTTP,
+		Doc:        resource.PostRemoveDoc(),
+		Successor:  "/api/v1/posts/{id}",
 		Method:     http.MethodDelete,
 	},
+	{
+		TokenIsReq: false,
+		Path:       "/post/{id}/related",
+		EndPointer: resource.PostRelatedHandler().ServeHTTP,
+		Doc:        resource.PostRelatedDoc(),
+		Method:     http.MethodGet,
+	},
 }
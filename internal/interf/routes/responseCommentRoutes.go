@@ -2,7 +2,9 @@ package routes
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/rateLimit"
 	"github.com/johnHPX/blog-hard-backend/internal/interf/resource"
 )
 
@@ -11,30 +13,41 @@ var responseComment = []Router{
 		TokenIsReq: true,
 		Path:       "/response/comment/store",
 		EndPointer: resource.ResponseCommentStoreHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentStoreDoc(),
+		Successor:  "/api/v1/responses",
 		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 10, Per: time.Minute, ByUser: true},
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/response/comment/list/comment/id/{commentID}",
 		EndPointer: resource.ResponseCommentListHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentListDoc(),
+		Successor:  "/api/v1/comments/{commentID}/responses",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/response/comment/list/user",
 		EndPointer: resource.ResponseCommentListUserHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentListUserDoc(),
+		Successor:  "/api/v1/users/me/responses",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/response/comment/update/id/{id}",
 		EndPointer: resource.ResponseCommentUpdateHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentUpdateDoc(),
+		Successor:  "/api/v1/responses/{id}",
 		Method:     http.MethodPut,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/response/comment/remove/id/{id}",
 		EndPointer: resource.ResponseCommentRemoveHandler().ServeHTTP,
+		Doc:        resource.ResponseCommentRemoveDoc(),
+		Successor:  "/api/v1/responses/{id}",
 		Method:     http.MethodDelete,
 	},
 }
@@ -1,30 +1,46 @@
 package routes
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/johnHPX/blog-hard-backend/internal/domain/domainErrors"
 	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/authn"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/cors"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/rateLimit"
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/responseAPI"
+	"github.com/johnHPX/blog-hard-backend/internal/interf/resource"
 )
 
 type Router struct {
 	TokenIsReq bool
 	Path       string
 	EndPointer http.HandlerFunc
+	Doc        resource.Doc
+	Successor  string
 	Method     string
+	RateLimit  *rateLimit.Rule
 }
 
 type WebService interface {
-	Init()
+	Init() error
 	GetRouters() http.Handler
 }
 
 type webServiceImpl struct {
-	Router *mux.Router
+	Router         *mux.Router
+	RateLimitStore rateLimit.Store
+	OpenAPI        []byte
 }
 
-func (s *webServiceImpl) configuration() {
+func apiRoutes() []Router {
 	routers := []Router{}
+	routers = append(routers, apiV1Routes...)
 	routers = append(routers, userRoutes...)
 	routers = append(routers, postRoutes...)
 	routers = append(routers, numberLikes...)
@@ -33,16 +49,92 @@ func (s *webServiceImpl) configuration() {
 	routers = append(routers, postCategory...)
 	routers = append(routers, responseComment...)
 	routers = append(routers, configsRoutes...)
+	routers = append(routers, searchRoutes...)
+	routers = append(routers, mediaRoutes...)
+	return routers
+}
+
+func (s *webServiceImpl) configuration() error {
+	policy, err := cors.NewPolicy()
+	if err != nil {
+		return err
+	}
+	limiter, err := rateLimit.NewLimiter(s.RateLimitStore, authn.UserKey)
+	if err != nil {
+		return err
+	}
+
+	routers := append(apiRoutes(), s.docsRoutes()...)
+
+	s.OpenAPI, err = json.Marshal(newOpenAPIBuilder().document(routers))
+	if err != nil {
+		return err
+	}
+
+	// the methods of each path, in the order of the routes, for its preflight
+	paths := make([]string, 0)
+	pathMethods := make(map[string][]string)
+
 	for _, router := range routers {
+		key := pathKey(router.Path)
+		if _, ok := pathMethods[key]; !ok {
+			paths = append(paths, router.Path)
+		}
+		pathMethods[key] = append(pathMethods[key], router.Method)
+
+		handler := router.EndPointer
 		if router.TokenIsReq {
-			s.Router.HandleFunc(router.Path, authn.HeaderMethods(authn.Authenticate(router.EndPointer), router.Method))
+			handler = authn.Authenticate(handler)
+		}
+		if router.RateLimit != nil {
+			// a legacy route shares the buckets of its successor
+			name := router.Path
+			if router.Successor != "" {
+				name = router.Successor
+			}
+			handler = limiter.Handler(router.Method+" "+name, *router.RateLimit, handler)
 		}
-		s.Router.HandleFunc(router.Path, authn.HeaderMethods(router.EndPointer, router.Method))
+		if router.Successor != "" {
+			handler = deprecated(router.Successor, handler)
+		}
+		handler = limiter.Identify(handler)
+		handler = authn.Localize(handler)
+		s.Router.HandleFunc(router.Path, policy.Handler(handler)).Methods(router.Method)
+	}
+	for _, path := range paths {
+		s.Router.HandleFunc(path, policy.Preflight(pathMethods[pathKey(path)]...)).Methods(http.MethodOptions)
+	}
+	s.Router.MethodNotAllowedHandler = authn.Localize(methodNotAllowed)
+
+	return nil
+}
+
+var pathVars = regexp.MustCompile(`\{[^}]*\}`)
+
+// pathKey: the paths that differ only by the names of their variables are the same path for the router
+func pathKey(path string) string {
+	return pathVars.ReplaceAllString(path, "{}")
+}
+
+// deprecated: flags a legacy route and links the route that replaces it
+func deprecated(successor string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		link := successor
+		for k, v := range mux.Vars(r) {
+			link = strings.ReplaceAll(link, "{"+k+"}", url.PathEscape(v))
+		}
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", link))
+		next(w, r)
 	}
 }
 
-func (s *webServiceImpl) Init() {
-	s.configuration()
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	responseAPI.WriteError(w, r, domainErrors.ErrMethodNotAllowed, "na")
+}
+
+func (s *webServiceImpl) Init() error {
+	return s.configuration()
 }
 
 func (s *webServiceImpl) GetRouters() http.Handler {
@@ -51,6 +143,7 @@ func (s *webServiceImpl) GetRouters() http.Handler {
 
 func NewWebService() WebService {
 	return &webServiceImpl{
-		Router: mux.NewRouter(),
+		Router:         mux.NewRouter(),
+		RateLimitStore: rateLimit.NewMemoryStore(),
 	}
 }
@@ -0,0 +1,106 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/johnHPX/blog-hard-backend/internal/appl/service"
+)
+
+func TestPreflight(t *testing.T) {
+	ws := &webServiceImpl{Router: mux.NewRouter()}
+	err := ws.Init()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	preflight := func(path, origin, method string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodOptions, path, nil)
+		r.Header.Set("Origin", origin)
+		r.Header.Set("Access-Control-Request-Method", method)
+		w := httptest.NewRecorder()
+		ws.GetRouters().ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("teste positivo", func(t *testing.T) {
+		// the paths with several methods answer the preflight of each of them
+		cases := map[string][]string{
+			"/api/v1/posts/123": {http.MethodGet, http.MethodPut, http.MethodDelete},
+			"/api/v1/users":     {http.MethodGet, http.MethodPost},
+		}
+		for path, methods := range cases {
+			for _, method := range methods {
+				w := preflight(path, "http://localhost:3000", method)
+				if w.Code != http.StatusNoContent {
+					t.Errorf("%s %s: status %d", method, path, w.Code)
+				}
+				if w.Header().Get("Access-Control-Allow-Origin") != "http://localhost:3000" {
+					t.Errorf("%s %s: no allowed origin", method, path)
+				}
+				allowed := w.Header().Get("Access-Control-Allow-Methods")
+				for _, v := range methods {
+					if !strings.Contains(allowed, v) {
+						t.Errorf("%s %s: %s missing from %q", method, path, v, allowed)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		w := preflight("/api/v1/posts/123", "http://evil.example", http.MethodPut)
+		if w.Header().Get("Access-Control-Allow-Origin") != "" {
+			t.Errorf("origin not configured was allowed")
+		}
+
+		w = preflight("/api/v1/posts/123", "http://localhost:3000", http.MethodPatch)
+		if w.Header().Get("Access-Control-Allow-Origin") != "" {
+			t.Errorf("method not served was allowed")
+		}
+	})
+}
+
+func TestRecoveryToken(t *testing.T) {
+	ws := &webServiceImpl{Router: mux.NewRouter()}
+	err := ws.Init()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := service.NewAccessService().GenerateTokenRecovery("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("teste positivo", func(t *testing.T) {
+		// the recovery token has no user nor kind, the language falls back to the Accept-Language
+		requests := []*http.Request{
+			httptest.NewRequest(http.MethodPut, "/api/v1/password-recovery", strings.NewReader(`{"password": "senha1234"}`)),
+			httptest.NewRequest(http.MethodPut, "/user/password/recovery", strings.NewReader(`{"password": "senha1234"}`)),
+			httptest.NewRequest(http.MethodGet, "/api/v1/posts/public/123", nil),
+		}
+		for _, r := range requests {
+			r.Header.Set("Authorization", "Bearer "+token)
+			r.Header.Set("Accept-Language", "en")
+			r.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64) Firefox/120.0")
+			w := httptest.NewRecorder()
+			ws.GetRouters().ServeHTTP(w, r)
+			if w.Code >= http.StatusBadRequest && w.Header().Get("Content-Language") != "en" {
+				t.Errorf("%s %s: language %q", r.Method, r.URL.Path, w.Header().Get("Content-Language"))
+			}
+		}
+	})
+
+	t.Run("teste negativo", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		_, err := service.NewAccessService().ExtractTokenInfo(r)
+		if err == nil {
+			t.Errorf("recovery token taken as a user token")
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/rateLimit"
+	"github.com/johnHPX/blog-hard-backend/internal/interf/resource"
+)
+
+var searchRoutes = []Router{
+	{
+		TokenIsReq: false,
+		Path:       "/search",
+		EndPointer: resource.SearchHandler().ServeHTTP,
+		Doc:        resource.SearchDoc(),
+		Method:     http.MethodGet,
+		RateLimit:  &rateLimit.Rule{Requests: 30, Per: time.Minute},
+	},
+}
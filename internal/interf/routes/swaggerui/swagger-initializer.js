@@ -0,0 +1,6 @@
+window.onload = function () {
+	window.ui = SwaggerUIBundle({
+		url: "/openapi.json",
+		dom_id: "#swagger-ui",
+	});
+};
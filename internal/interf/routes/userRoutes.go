@@ -2,7 +2,9 @@ package routes
 
 import (
 	"net/http"
+	"time"
 
+	"github.com/johnHPX/blog-hard-backend/internal/infra/utils/rateLimit"
 	"github.com/johnHPX/blog-hard-backend/internal/interf/resource"
 )
 
@@ -11,78 +13,108 @@ var userRoutes = []Router{
 		TokenIsReq: false,
 		Path:       "/user/store",
 		EndPointer: resource.UserStoreHandler().ServeHTTP,
+		Doc:        resource.UserStoreDoc(),
+		Successor:  "/api/v1/users",
 		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 5, Per: time.Hour},
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/adm/store",
 		EndPointer: resource.UserStoreADMHandler().ServeHTTP,
+		Doc:        resource.UserStoreADMDoc(),
+		Successor:  "/api/v1/admin/users",
 		Method:     http.MethodPost,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/list",
 		EndPointer: resource.UserListHandler().ServeHTTP,
+		Doc:        resource.UserListDoc(),
+		Successor:  "/api/v1/users",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/list/name/{name}",
 		EndPointer: resource.UserListNameHandler().ServeHTTP,
+		Doc:        resource.UserListNameDoc(),
+		Successor:  "/api/v1/users/names/{name}",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/find/id/{id}",
 		EndPointer: resource.UserFindHandler().ServeHTTP,
+		Doc:        resource.UserFindDoc(),
+		Successor:  "/api/v1/users/{id}",
 		Method:     http.MethodGet,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/update/id/{id}",
 		EndPointer: resource.UserUpdateHandler().ServeHTTP,
+		Doc:        resource.UserUpdateDoc(),
+		Successor:  "/api/v1/users/{id}",
 		Method:     http.MethodPut,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/remove/id/{id}",
 		EndPointer: resource.UserRemoveHandler().ServeHTTP,
+		Doc:        resource.UserRemoveDoc(),
+		Successor:  "/api/v1/users/{id}",
 		Method:     http.MethodDelete,
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/user/login",
 		EndPointer: resource.UserLoginHandler().ServeHTTP,
+		Doc:        resource.UserLoginDoc(),
+		Successor:  "/api/v1/sessions",
 		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 10, Per: time.Minute},
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/user/recor/email",
 		EndPointer: resource.UserSendEmailHandler().ServeHTTP,
+		Doc:        resource.UserSendEmailDoc(),
+		Successor:  "/api/v1/password-recovery/codes",
 		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 3, Per: 10 * time.Minute},
 	},
 	{
 		TokenIsReq: false,
 		Path:       "/user/verific/code",
 		EndPointer: resource.UserVerificCodeHandler().ServeHTTP,
+		Doc:        resource.UserVerificCodeDoc(),
+		Successor:  "/api/v1/password-recovery/verifications",
 		Method:     http.MethodPost,
+		RateLimit:  &rateLimit.Rule{Requests: 10, Per: 10 * time.Minute},
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/password/recovery",
 		EndPointer: resource.UserPasswordRecoveryHandler().ServeHTTP,
+		Doc:        resource.UserPasswordRecoveryDoc(),
+		Successor:  "/api/v1/password-recovery",
 		Method:     http.MethodPut,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/password/update",
 		EndPointer: resource.UserPasswordUpdateHandler().ServeHTTP,
+		Doc:        resource.UserPasswordUpdateDoc(),
+		Successor:  "/api/v1/users/me/password",
 		Method:     http.MethodPut,
 	},
 	{
 		TokenIsReq: true,
 		Path:       "/user/logout",
 		EndPointer: resource.UserLogoutHandler().ServeHTTP,
+		Doc:        resource.UserLogoutDoc(),
+		Successor:  "/api/v1/sessions",
 		Method:     http.MethodPost,
 	},
 }
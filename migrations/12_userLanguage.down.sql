@@ -0,0 +1 @@
+alter table tb_user drop column if exists language;
\ No newline at end of file
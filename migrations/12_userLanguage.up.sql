@@ -0,0 +1 @@
+alter table tb_user add column if not exists language varchar(10) not null DEFAULT 'pt-BR';
\ No newline at end of file
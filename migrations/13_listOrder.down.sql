@@ -0,0 +1,6 @@
+drop index if exists ix_post_created_at_id;
+drop index if exists ix_comment_post_created_at_id;
+drop index if exists ix_comment_user_created_at_id;
+drop index if exists ix_response_comment_comment_created_at_id;
+drop index if exists ix_category_created_at_id;
+drop index if exists ix_user_created_at_id;
\ No newline at end of file
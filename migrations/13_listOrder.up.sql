@@ -0,0 +1,6 @@
+create index if not exists ix_post_created_at_id on tb_post (created_at DESC, id DESC);
+create index if not exists ix_comment_post_created_at_id on tb_comment (post_pid, created_at DESC, id DESC);
+create index if not exists ix_comment_user_created_at_id on tb_comment (user_uid, created_at DESC, id DESC);
+create index if not exists ix_response_comment_comment_created_at_id on tb_response_comment (comment_cid, created_at DESC, id DESC);
+create index if not exists ix_category_created_at_id on tb_category (created_at DESC, id DESC);
+create index if not exists ix_user_created_at_id on tb_user (created_at DESC, id DESC);
\ No newline at end of file
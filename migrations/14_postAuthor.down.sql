@@ -0,0 +1,3 @@
+drop index if exists ix_post_user_created_at_id;
+alter table tb_post drop constraint if exists fk_pk_post_0;
+alter table tb_post drop column if exists user_uid;
\ No newline at end of file
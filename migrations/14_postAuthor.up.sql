@@ -0,0 +1,3 @@
+alter table tb_post add column if not exists user_uid varchar(36);
+alter table tb_post add constraint fk_pk_post_0 foreign key (user_uid) references tb_user(id);
+create index if not exists ix_post_user_created_at_id on tb_post (user_uid, created_at DESC, id DESC);
\ No newline at end of file
@@ -0,0 +1,4 @@
+drop index if exists ix_comment_search;
+alter table tb_comment drop column if exists search;
+drop index if exists ix_post_search;
+alter table tb_post drop column if exists search;
\ No newline at end of file
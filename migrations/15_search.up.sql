@@ -0,0 +1,14 @@
+alter table tb_post add column if not exists search tsvector generated always as (
+    setweight(to_tsvector('portuguese', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('portuguese', coalesce(content, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'B')
+) stored;
+create index if not exists ix_post_search on tb_post using gin (search);
+alter table tb_comment add column if not exists search tsvector generated always as (
+    setweight(to_tsvector('portuguese', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('portuguese', coalesce(content, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'B')
+) stored;
+create index if not exists ix_comment_search on tb_comment using gin (search);
\ No newline at end of file
@@ -0,0 +1,3 @@
+drop index if exists ix_post_status_created_at_id;
+alter table tb_post drop column if exists published_at;
+alter table tb_post drop column if exists status;
\ No newline at end of file
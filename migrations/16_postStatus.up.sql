@@ -0,0 +1,5 @@
+alter table tb_post add column if not exists status varchar(20) not null DEFAULT 'published';
+alter table tb_post add column if not exists published_at timestamp;
+update tb_post set published_at = created_at where status = 'published' and published_at is null;
+alter table tb_post alter column status set DEFAULT 'draft';
+create index if not exists ix_post_status_created_at_id on tb_post (status, created_at DESC, id DESC);
\ No newline at end of file
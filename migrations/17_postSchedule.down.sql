@@ -0,0 +1,3 @@
+update tb_post set status = 'draft' where status = 'scheduled';
+drop index if exists ix_post_scheduled_publish_at;
+alter table tb_post drop column if exists publish_at;
\ No newline at end of file
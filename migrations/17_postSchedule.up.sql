@@ -0,0 +1,2 @@
+alter table tb_post add column if not exists publish_at timestamp;
+create index if not exists ix_post_scheduled_publish_at on tb_post (publish_at) where status = 'scheduled';
\ No newline at end of file
@@ -0,0 +1 @@
+drop table if exists tb_post_revision;
\ No newline at end of file
@@ -0,0 +1,17 @@
+create table if not exists tb_post_revision (
+    id varchar(36) not null,
+    post_pid varchar(36) not null,
+    number int not null,
+    title varchar(255) not null,
+    content text not null,
+    user_uid varchar(36),
+    restored_from int,
+    created_at timestamp not null DEFAULT Now(),
+    constraint pk_post_revision primary key (id),
+    constraint uq_post_revision_number unique (post_pid, number),
+    constraint fk_pk_post_revision_0 foreign key (post_pid) references tb_post(id),
+    constraint fk_pk_post_revision_1 foreign key (user_uid) references tb_user(id)
+);
+create index if not exists ix_post_revision_post_created_at_id on tb_post_revision (post_pid, created_at DESC, id DESC);
+insert into tb_post_revision (id, post_pid, number, title, content, user_uid, created_at)
+select gen_random_uuid()::varchar, id, 1, title, content, user_uid, coalesce(updated_at, created_at) from tb_post where deleted_at is null;
\ No newline at end of file
@@ -0,0 +1,4 @@
+drop table if exists tb_post_slug;
+drop index if exists ix_post_slug;
+alter table tb_post drop column if exists slug;
+drop function if exists fn_slug(text, text);
\ No newline at end of file
@@ -0,0 +1,43 @@
+alter table tb_post add column if not exists slug varchar(300);
+create table if not exists tb_post_slug (
+    slug varchar(300) not null,
+    post_pid varchar(36) not null,
+    created_at timestamp not null DEFAULT Now(),
+    constraint pk_post_slug primary key (slug),
+    constraint fk_pk_post_slug_0 foreign key (post_pid) references tb_post(id)
+);
+create or replace function fn_slug(title text, fallback text) returns text as $$
+declare
+    s text;
+begin
+    s := replace(replace(replace(lower(title), 'æ', 'ae'), 'œ', 'oe'), 'ß', 'ss');
+    s := translate(s, 'áàâãäåéèêëíìîïóòôõöúùûüçñýÿø', 'aaaaaaeeeeiiiiooooouuuucnyyo');
+    s := rtrim(left(trim(both '-' from regexp_replace(s, '[^a-z0-9]+', '-', 'g')), 255), '-');
+    if s = '' then
+        return fallback;
+    end if;
+    return s;
+end;
+$$ language plpgsql immutable;
+do $$
+declare
+    p record;
+    base text;
+    candidate text;
+    n int;
+begin
+    for p in select id, title from tb_post order by created_at, id loop
+        base := fn_slug(p.title, 'post');
+        candidate := base;
+        n := 2;
+        while exists (select 1 from tb_post_slug where slug = candidate) loop
+            candidate := base || '-' || n;
+            n := n + 1;
+        end loop;
+        update tb_post set slug = candidate where id = p.id;
+        insert into tb_post_slug (slug, post_pid) values (candidate, p.id);
+    end loop;
+end;
+$$;
+alter table tb_post alter column slug set not null;
+create unique index if not exists ix_post_slug on tb_post (slug);
\ No newline at end of file
@@ -0,0 +1,3 @@
+drop index if exists ix_post_views;
+alter table tb_post drop column if exists views;
+drop table if exists tb_post_view;
\ No newline at end of file
@@ -0,0 +1,10 @@
+create table if not exists tb_post_view (
+    post_pid varchar(36) not null,
+    visitor varchar(64) not null,
+    day date not null DEFAULT current_date,
+    created_at timestamp not null DEFAULT Now(),
+    constraint pk_post_view primary key (post_pid, visitor, day),
+    constraint fk_pk_post_view_0 foreign key (post_pid) references tb_post(id)
+);
+alter table tb_post add column if not exists views int not null DEFAULT 0;
+create index if not exists ix_post_views on tb_post (views);
\ No newline at end of file
@@ -0,0 +1,3 @@
+alter table tb_response_comment drop column if exists content_html;
+alter table tb_comment drop column if exists content_html;
+alter table tb_post drop column if exists content_html;
\ No newline at end of file
@@ -0,0 +1,3 @@
+alter table tb_post add column if not exists content_html text;
+alter table tb_comment add column if not exists content_html text;
+alter table tb_response_comment add column if not exists content_html text;
\ No newline at end of file
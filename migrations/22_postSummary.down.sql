@@ -0,0 +1,2 @@
+alter table tb_post drop column if exists summary;
+alter table tb_post drop column if exists excerpt;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table tb_post add column if not exists excerpt varchar(500);
+alter table tb_post add column if not exists summary jsonb;
\ No newline at end of file
@@ -0,0 +1 @@
+drop table if exists tb_media;
\ No newline at end of file
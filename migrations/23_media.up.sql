@@ -0,0 +1,18 @@
+create table if not exists tb_media (
+    id varchar(36) not null,
+    user_uid varchar(36),
+    name varchar(255) not null,
+    hash varchar(64) not null,
+    mime varchar(100) not null,
+    size bigint not null,
+    width int,
+    height int,
+    file_key varchar(100) not null,
+    thumb_key varchar(100),
+    created_at timestamp not null DEFAULT Now(),
+    deleted_at timestamp,
+    constraint pk_media primary key (id),
+    constraint fk_pk_media_0 foreign key (user_uid) references tb_user(id)
+);
+create unique index if not exists ix_media_hash on tb_media (user_uid, hash) where deleted_at is null;
+create index if not exists ix_media_user on tb_media (user_uid, created_at) where deleted_at is null;
\ No newline at end of file
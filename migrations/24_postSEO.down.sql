@@ -0,0 +1,3 @@
+alter table tb_post drop column if exists seo;
+alter table tb_post drop constraint if exists fk_pk_post_1;
+alter table tb_post drop column if exists cover_mid;
\ No newline at end of file
@@ -0,0 +1,3 @@
+alter table tb_post add column if not exists cover_mid varchar(36);
+alter table tb_post add constraint fk_pk_post_1 foreign key (cover_mid) references tb_media(id);
+alter table tb_post add column if not exists seo jsonb;
\ No newline at end of file
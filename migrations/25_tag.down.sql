@@ -0,0 +1,2 @@
+drop table if exists tb_post_tag;
+drop table if exists tb_tag;
\ No newline at end of file
@@ -0,0 +1,19 @@
+create table if not exists tb_tag (
+    id varchar(36) not null,
+    name varchar(50) not null,
+    slug varchar(100) not null,
+    created_at timestamp not null DEFAULT Now(),
+    updated_at timestamp,
+    deleted_at timestamp,
+    constraint pk_tag primary key (id)
+);
+create unique index if not exists ix_tag_slug on tb_tag (slug) where deleted_at is null;
+create table if not exists tb_post_tag (
+    post_pid varchar(36) not null,
+    tag_tid varchar(36) not null,
+    created_at timestamp not null DEFAULT Now(),
+    constraint pk_post_tag primary key (post_pid, tag_tid),
+    constraint fk_pk_post_tag_0 foreign key (post_pid) references tb_post(id),
+    constraint fk_pk_post_tag_1 foreign key (tag_tid) references tb_tag(id)
+);
+create index if not exists ix_post_tag_tag on tb_post_tag (tag_tid);
\ No newline at end of file
@@ -0,0 +1,3 @@
+drop index if exists ix_category_parent;
+alter table tb_category drop constraint if exists fk_pk_category_0;
+alter table tb_category drop column if exists parent_id;
\ No newline at end of file
@@ -0,0 +1,3 @@
+alter table tb_category add column if not exists parent_id varchar(36);
+alter table tb_category add constraint fk_pk_category_0 foreign key (parent_id) references tb_category(id);
+create index if not exists ix_category_parent on tb_category (parent_id);
\ No newline at end of file
@@ -0,0 +1,5 @@
+drop index if exists ix_category_slug;
+alter table tb_category drop constraint if exists fk_pk_category_1;
+alter table tb_category drop column if exists cover_mid;
+alter table tb_category drop column if exists description;
+alter table tb_category drop column if exists slug;
\ No newline at end of file
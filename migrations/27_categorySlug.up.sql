@@ -0,0 +1,25 @@
+alter table tb_category add column if not exists slug varchar(300);
+alter table tb_category add column if not exists description text;
+alter table tb_category add column if not exists cover_mid varchar(36);
+alter table tb_category add constraint fk_pk_category_1 foreign key (cover_mid) references tb_media(id);
+do $$
+declare
+    c record;
+    base text;
+    candidate text;
+    n int;
+begin
+    for c in select id, name from tb_category order by deleted_at nulls first, created_at, id loop
+        base := fn_slug(c.name, 'categoria');
+        candidate := base;
+        n := 2;
+        while exists (select 1 from tb_category where slug = candidate) loop
+            candidate := base || '-' || n;
+            n := n + 1;
+        end loop;
+        update tb_category set slug = candidate where id = c.id;
+    end loop;
+end;
+$$;
+alter table tb_category alter column slug set not null;
+create unique index if not exists ix_category_slug on tb_category (slug) where deleted_at is null;
\ No newline at end of file
@@ -0,0 +1,2 @@
+drop table if exists tb_series_post;
+drop table if exists tb_series;
\ No newline at end of file
@@ -0,0 +1,24 @@
+create table if not exists tb_series (
+    id varchar(36) not null,
+    title varchar(255) not null,
+    slug varchar(300) not null,
+    description text,
+    user_uid varchar(36) not null,
+    created_at timestamp not null DEFAULT Now(),
+    updated_at timestamp,
+    deleted_at timestamp,
+    constraint pk_series primary key (id),
+    constraint fk_pk_series_0 foreign key (user_uid) references tb_user(id)
+);
+create unique index if not exists ix_series_slug on tb_series (slug) where deleted_at is null;
+create table if not exists tb_series_post (
+    series_sid varchar(36) not null,
+    post_pid varchar(36) not null,
+    position int not null,
+    created_at timestamp not null DEFAULT Now(),
+    constraint pk_series_post primary key (series_sid, post_pid),
+    constraint fk_pk_series_post_0 foreign key (series_sid) references tb_series(id),
+    constraint fk_pk_series_post_1 foreign key (post_pid) references tb_post(id)
+);
+create unique index if not exists ix_series_post_post on tb_series_post (post_pid);
+create unique index if not exists ix_series_post_position on tb_series_post (series_sid, position);
\ No newline at end of file
@@ -0,0 +1,16 @@
+drop trigger if exists tg_post_related_tag on tb_tag;
+drop trigger if exists tg_post_related_category on tb_category;
+drop trigger if exists tg_post_related_post_tag_update on tb_post_tag;
+drop trigger if exists tg_post_related_post_tag on tb_post_tag;
+drop trigger if exists tg_post_related_post_category_update on tb_post_category;
+drop trigger if exists tg_post_related_post_category on tb_post_category;
+drop trigger if exists tg_post_related_post_delete on tb_post;
+drop trigger if exists tg_post_related_post on tb_post;
+drop function if exists fn_post_related_tag();
+drop function if exists fn_post_related_category();
+drop function if exists fn_post_related_post_tag();
+drop function if exists fn_post_related_post_category();
+drop function if exists fn_post_related_post();
+drop function if exists fn_post_related_neighbours(varchar);
+drop function if exists fn_post_related_forget(varchar[]);
+drop table if exists tb_post_related;
\ No newline at end of file
@@ -0,0 +1,105 @@
+create table if not exists tb_post_related (
+    post_pid varchar(36) not null,
+    related varchar(36)[] not null,
+    created_at timestamp not null DEFAULT Now(),
+    constraint pk_post_related primary key (post_pid),
+    constraint fk_pk_post_related_0 foreign key (post_pid) references tb_post(id)
+);
+create index if not exists ix_post_related_related on tb_post_related using gin (related);
+create or replace function fn_post_related_forget(ids varchar[]) returns void as $$
+begin
+    delete from tb_post_related where post_pid = any(ids) or related && ids;
+end;
+$$ language plpgsql;
+create or replace function fn_post_related_neighbours(varchar) returns varchar[] as $$
+    select array(
+        select pc.post_pid
+        from tb_post_category pc
+        inner join tb_post_category o on o.category_cid = pc.category_cid
+        where o.post_pid = $1 and o.deleted_at is null and pc.deleted_at is null
+        union
+        select pt.post_pid
+        from tb_post_tag pt
+        inner join tb_post_tag o on o.tag_tid = pt.tag_tid
+        where o.post_pid = $1
+    );
+$$ language sql stable;
+create or replace function fn_post_related_post() returns trigger as $$
+begin
+    if TG_OP = 'DELETE' then
+        perform fn_post_related_forget(array[OLD.id]);
+        return OLD;
+    end if;
+    if OLD.status is distinct from NEW.status or OLD.deleted_at is distinct from NEW.deleted_at then
+        perform fn_post_related_forget(array[NEW.id] || fn_post_related_neighbours(NEW.id));
+    else
+        perform fn_post_related_forget(array[NEW.id]);
+    end if;
+    return NEW;
+end;
+$$ language plpgsql;
+create or replace function fn_post_related_post_category() returns trigger as $$
+begin
+    if TG_OP <> 'INSERT' then
+        perform fn_post_related_forget(array[OLD.post_pid] || array(select post_pid from tb_post_category where deleted_at is null and category_cid = OLD.category_cid));
+    end if;
+    if TG_OP <> 'DELETE' then
+        perform fn_post_related_forget(array[NEW.post_pid] || array(select post_pid from tb_post_category where deleted_at is null and category_cid = NEW.category_cid));
+    end if;
+    return null;
+end;
+$$ language plpgsql;
+create or replace function fn_post_related_post_tag() returns trigger as $$
+begin
+    if TG_OP <> 'INSERT' then
+        perform fn_post_related_forget(array[OLD.post_pid] || array(select post_pid from tb_post_tag where tag_tid = OLD.tag_tid));
+    end if;
+    if TG_OP <> 'DELETE' then
+        perform fn_post_related_forget(array[NEW.post_pid] || array(select post_pid from tb_post_tag where tag_tid = NEW.tag_tid));
+    end if;
+    return null;
+end;
+$$ language plpgsql;
+create or replace function fn_post_related_category() returns trigger as $$
+begin
+    perform fn_post_related_forget(array(select post_pid from tb_post_category where deleted_at is null and category_cid = NEW.id));
+    return null;
+end;
+$$ language plpgsql;
+create or replace function fn_post_related_tag() returns trigger as $$
+begin
+    perform fn_post_related_forget(array(select post_pid from tb_post_tag where tag_tid = NEW.id));
+    return null;
+end;
+$$ language plpgsql;
+drop trigger if exists tg_post_related_post on tb_post;
+create trigger tg_post_related_post after update of title, content, status, deleted_at on tb_post
+    for each row when (OLD.title is distinct from NEW.title or OLD.content is distinct from NEW.content
+        or OLD.status is distinct from NEW.status or OLD.deleted_at is distinct from NEW.deleted_at)
+    execute function fn_post_related_post();
+drop trigger if exists tg_post_related_post_delete on tb_post;
+create trigger tg_post_related_post_delete before delete on tb_post
+    for each row execute function fn_post_related_post();
+drop trigger if exists tg_post_related_post_category on tb_post_category;
+create trigger tg_post_related_post_category after insert or delete on tb_post_category
+    for each row execute function fn_post_related_post_category();
+drop trigger if exists tg_post_related_post_category_update on tb_post_category;
+create trigger tg_post_related_post_category_update after update on tb_post_category
+    for each row when (OLD.post_pid is distinct from NEW.post_pid or OLD.category_cid is distinct from NEW.category_cid
+        or OLD.deleted_at is distinct from NEW.deleted_at)
+    execute function fn_post_related_post_category();
+drop trigger if exists tg_post_related_post_tag on tb_post_tag;
+create trigger tg_post_related_post_tag after insert or delete on tb_post_tag
+    for each row execute function fn_post_related_post_tag();
+drop trigger if exists tg_post_related_post_tag_update on tb_post_tag;
+create trigger tg_post_related_post_tag_update after update on tb_post_tag
+    for each row when (OLD.* is distinct from NEW.*)
+    execute function fn_post_related_post_tag();
+drop trigger if exists tg_post_related_category on tb_category;
+create trigger tg_post_related_category after update of deleted_at on tb_category
+    for each row when (OLD.deleted_at is distinct from NEW.deleted_at)
+    execute function fn_post_related_category();
+drop trigger if exists tg_post_related_tag on tb_tag;
+create trigger tg_post_related_tag after update of deleted_at on tb_tag
+    for each row when (OLD.deleted_at is distinct from NEW.deleted_at)
+    execute function fn_post_related_tag();
\ No newline at end of file